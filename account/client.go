@@ -4,8 +4,10 @@ package account
 
 import (
 	"context"
+	"time"
 
 	"Microservices-based-E-commerce-System/account/pb"
+	"Microservices-based-E-commerce-System/auth"
 
 	"google.golang.org/grpc"
 )
@@ -18,8 +20,9 @@ type Client struct {
 // lets other services talk to the gRPC server.
 // grpc.Dial() creates a gRPC connection (without TLS)
 // WithInsecure() disables TLS (Transport Layer Security).
+// The client interceptor forwards the caller's access token found in the context.
 func NewClient(url string) (*Client, error) {
-	conn, err := grpc.Dial(url, grpc.WithInsecure())
+	conn, err := grpc.Dial(url, grpc.WithInsecure(), grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor()))
 	if err != nil {
 		return nil, err
 	}
@@ -31,15 +34,24 @@ func (c *Client) Close() {
 	c.conn.Close()
 }
 
-func (c *Client) PostAccount(ctx context.Context, name string) (*Account, error) {
-	r, err := c.service.PostAccount(ctx, &pb.PostAccountRequest{Name: name}) // Calls the gRPC server and returns a local Account struct.
+// Creates an account. idempotencyKey is optional; retries with the same key return the account created first.
+func (c *Client) PostAccount(ctx context.Context, name, email, password, idempotencyKey string) (*Account, error) {
+	r, err := c.service.PostAccount(ctx, &pb.PostAccountRequest{
+		Name:           name,
+		Email:          email,
+		Password:       password,
+		IdempotencyKey: idempotencyKey,
+	}) // Calls the gRPC server and returns a local Account struct.
 
 	if err != nil {
 		return nil, err
 	}
 	return &Account{
-		ID:   r.Account.Id,
-		Name: r.Account.Name,
+		ID:                r.Account.Id,
+		Name:              r.Account.Name,
+		Email:             r.Account.Email,
+		Role:              r.Account.Role,
+		PreferredCurrency: r.Account.PreferredCurrency,
 	}, nil
 }
 
@@ -49,8 +61,11 @@ func (c *Client) GetAccount(ctx context.Context, id string) (*Account, error) {
 		return nil, err
 	}
 	return &Account{
-		ID:   r.Account.Id,
-		Name: r.Account.Name,
+		ID:                r.Account.Id,
+		Name:              r.Account.Name,
+		Email:             r.Account.Email,
+		Role:              r.Account.Role,
+		PreferredCurrency: r.Account.PreferredCurrency,
 	}, nil
 }
 
@@ -66,9 +81,190 @@ func (c *Client) GetAccounts(ctx context.Context, skip uint64, take uint64) ([]A
 	accounts := []Account{}
 	for _, a := range r.Accounts {
 		accounts = append(accounts, Account{
-			ID:   a.Id,
-			Name: a.Name,
+			ID:                a.Id,
+			Name:              a.Name,
+			Email:             a.Email,
+			Role:              a.Role,
+			PreferredCurrency: a.PreferredCurrency,
 		})
 	}
 	return accounts, nil
 }
+
+// Fetches a page of accounts after the given cursor ("" for the first page).
+func (c *Client) ListAccounts(ctx context.Context, after string, first uint64) (*AccountPage, error) {
+	r, err := c.service.ListAccounts(ctx, &pb.ListAccountsRequest{
+		After: after,
+		First: first,
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := &AccountPage{
+		Edges:       []AccountEdge{},
+		HasNextPage: r.HasNextPage,
+		TotalCount:  r.TotalCount,
+	}
+	for _, e := range r.Edges {
+		page.Edges = append(page.Edges, AccountEdge{
+			Cursor: e.Cursor,
+			Account: Account{
+				ID:                e.Account.Id,
+				Name:              e.Account.Name,
+				Email:             e.Account.Email,
+				Role:              e.Account.Role,
+				PreferredCurrency: e.Account.PreferredCurrency,
+			},
+		})
+	}
+	return page, nil
+}
+
+func (c *Client) UpdateAccount(ctx context.Context, id string, name string) (*Account, error) {
+	r, err := c.service.UpdateAccount(ctx, &pb.UpdateAccountRequest{
+		Id:   id,
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		ID:                r.Account.Id,
+		Name:              r.Account.Name,
+		Email:             r.Account.Email,
+		Role:              r.Account.Role,
+		PreferredCurrency: r.Account.PreferredCurrency,
+	}, nil
+}
+
+func (c *Client) SetAccountRole(ctx context.Context, id string, role string) (*Account, error) {
+	r, err := c.service.SetAccountRole(ctx, &pb.SetAccountRoleRequest{
+		Id:   id,
+		Role: role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		ID:                r.Account.Id,
+		Name:              r.Account.Name,
+		Email:             r.Account.Email,
+		Role:              r.Account.Role,
+		PreferredCurrency: r.Account.PreferredCurrency,
+	}, nil
+}
+
+// sets the currency the account's orders are priced in by default; "" clears it.
+func (c *Client) SetPreferredCurrency(ctx context.Context, id string, currency string) (*Account, error) {
+	r, err := c.service.SetPreferredCurrency(ctx, &pb.SetPreferredCurrencyRequest{
+		Id:       id,
+		Currency: currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		ID:                r.Account.Id,
+		Name:              r.Account.Name,
+		Email:             r.Account.Email,
+		Role:              r.Account.Role,
+		PreferredCurrency: r.Account.PreferredCurrency,
+	}, nil
+}
+
+func (c *Client) DeleteAccount(ctx context.Context, id string) error {
+	_, err := c.service.DeleteAccount(ctx, &pb.DeleteAccountRequest{Id: id})
+	return err
+}
+
+func (c *Client) AddAddress(ctx context.Context, a Address) (*Address, error) {
+	r, err := c.service.AddAddress(ctx, &pb.AddAddressRequest{Address: addressToProto(a)})
+	if err != nil {
+		return nil, err
+	}
+	address := addressFromProto(r.Address)
+	return &address, nil
+}
+
+func (c *Client) ListAddresses(ctx context.Context, accountID string) ([]Address, error) {
+	r, err := c.service.ListAddresses(ctx, &pb.ListAddressesRequest{AccountId: accountID})
+	if err != nil {
+		return nil, err
+	}
+	addresses := []Address{}
+	for _, a := range r.Addresses {
+		addresses = append(addresses, addressFromProto(a))
+	}
+	return addresses, nil
+}
+
+func (c *Client) UpdateAddress(ctx context.Context, a Address) (*Address, error) {
+	r, err := c.service.UpdateAddress(ctx, &pb.UpdateAddressRequest{Address: addressToProto(a)})
+	if err != nil {
+		return nil, err
+	}
+	address := addressFromProto(r.Address)
+	return &address, nil
+}
+
+func (c *Client) DeleteAddress(ctx context.Context, accountID string, id string) error {
+	_, err := c.service.DeleteAddress(ctx, &pb.DeleteAddressRequest{
+		AccountId: accountID,
+		Id:        id,
+	})
+	return err
+}
+
+func (c *Client) SetDefaultAddress(ctx context.Context, accountID string, id string) (*Address, error) {
+	r, err := c.service.SetDefaultAddress(ctx, &pb.SetDefaultAddressRequest{
+		AccountId: accountID,
+		Id:        id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	address := addressFromProto(r.Address)
+	return &address, nil
+}
+
+// Exchanges an email/password pair for the account and a new token pair.
+func (c *Client) Login(ctx context.Context, email, password string) (*Account, *auth.Tokens, error) {
+	r, err := c.service.Login(ctx, &pb.LoginRequest{
+		Email:    email,
+		Password: password,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Account{
+		ID:                r.Account.Id,
+		Name:              r.Account.Name,
+		Email:             r.Account.Email,
+		Role:              r.Account.Role,
+		PreferredCurrency: r.Account.PreferredCurrency,
+	}, tokensFromProto(r.Tokens), nil
+}
+
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*Account, *auth.Tokens, error) {
+	r, err := c.service.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Account{
+		ID:                r.Account.Id,
+		Name:              r.Account.Name,
+		Email:             r.Account.Email,
+		Role:              r.Account.Role,
+		PreferredCurrency: r.Account.PreferredCurrency,
+	}, tokensFromProto(r.Tokens), nil
+}
+
+func tokensFromProto(t *pb.AuthTokens) *auth.Tokens {
+	tokens := &auth.Tokens{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+	}
+	tokens.AccessTokenExpiresAt = time.Time{}
+	tokens.AccessTokenExpiresAt.UnmarshalBinary(t.AccessTokenExpiresAt)
+	return tokens
+}
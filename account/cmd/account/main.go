@@ -4,6 +4,8 @@ package main
 
 import (
 	"Microservices-based-E-commerce-System/account"
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/idempotency"
 	"log"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 
 type Config struct {
 	DatabaseURL string `envconfig:"DATABASE_URL"`
+	JWTSecret   string `envconfig:"JWT_SECRET" required:"true"` // shared with every service that verifies tokens
 }
 
 func main() {
@@ -31,7 +34,12 @@ func main() {
 		return
 	})
 	defer r.Close()
+	keys, err := idempotency.NewPostgresStore(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
 	log.Println("Listening on port 8080...")
-	s := account.NewService(r)
-	log.Fatal(account.ListenGRPC(s, 8080)) // Starts the gRPC server.
+	tokens := auth.NewTokenManager(cfg.JWTSecret)
+	s := account.NewService(r, tokens)
+	log.Fatal(account.ListenGRPC(s, tokens, keys, 8080)) // Starts the gRPC server.
 }
@@ -0,0 +1,67 @@
+// Domain errors of the account service and their translation to gRPC status codes.
+
+package account
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"net"
+
+	"Microservices-based-E-commerce-System/auth"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrNotFound           = errors.New("account not found")
+	ErrAddressNotFound    = errors.New("address not found")
+	ErrEmailTaken         = errors.New("email is already registered")
+	ErrInvalidName        = errors.New("name must be between 1 and 24 characters long")
+	ErrInvalidEmail       = errors.New("invalid email address")
+	ErrWeakPassword       = errors.New("password must be at least 8 characters long")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrInvalidRole        = errors.New("unknown role")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrInvalidAddress     = errors.New("address requires a kind (shipping or billing), line1, city, postal code and a 2-letter country code")
+	ErrInvalidCurrency    = errors.New("currency must be a 3-letter ISO 4217 code")
+	ErrAddressTooLong     = errors.New("address field is too long")
+)
+
+// Converts an error returned by the service into a gRPC status error, so clients can tell
+// "not found" from "bad input" from "try again later" by its code.
+// Errors that already carry a status (e.g. from the auth interceptor) are passed through;
+// anything unexpected is logged and reported as Internal without leaking details.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrAddressNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrEmailTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrInvalidName), errors.Is(err, ErrInvalidEmail), errors.Is(err, ErrWeakPassword), errors.Is(err, ErrInvalidCurrency),
+		errors.Is(err, ErrInvalidRole), errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrInvalidAddress),
+		errors.Is(err, ErrAddressTooLong):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrInvalidCredentials), errors.Is(err, auth.ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+		log.Println(err)
+		return status.Error(codes.Unavailable, "account database unavailable")
+	}
+	log.Println(err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -5,15 +5,28 @@ package account
 import (
 	"context"
 	"database/sql"
+	"errors"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Repository interface {
 	Close()
 	PutAccount(ctx context.Context, a Account) error
 	GetAccountByID(ctx context.Context, id string) (*Account, error)
+	GetAccountByEmail(ctx context.Context, email string) (*Account, error)
 	ListAccounts(ctx context.Context, skip uint64, take uint64) ([]Account, error)
+	ListAccountsAfter(ctx context.Context, afterID string, take uint64) ([]Account, error)
+	CountAccounts(ctx context.Context) (uint64, error)
+	UpdateAccount(ctx context.Context, a Account) error
+	DeleteAccount(ctx context.Context, id string) error
+	UpdateAccountRole(ctx context.Context, id string, role string) error
+	UpdatePreferredCurrency(ctx context.Context, id string, currency string) error
+	PutAddress(ctx context.Context, a Address) (isDefault bool, err error)
+	ListAddresses(ctx context.Context, accountID string) ([]Address, error)
+	UpdateAddress(ctx context.Context, a Address) error
+	DeleteAddress(ctx context.Context, accountID string, id string) error
+	SetDefaultAddress(ctx context.Context, accountID string, id string) error
 }
 
 // Internal implementation using *sql.DB
@@ -47,17 +60,39 @@ func (r *postgresRepository) Ping() error {
 	return r.db.Ping()
 }
 
-// Adds a new account into the database. $1, $2 are placeholders for a.id and a.name
+// Adds a new account into the database. $1, $2, ... are placeholders for a.id, a.name, ...
+// A clash on the unique email index is reported as ErrEmailTaken.
 func (r *postgresRepository) PutAccount(ctx context.Context, a Account) error {
-	_, err := r.db.ExecContext(ctx, "INSERT INTO accounts(id, name) VALUES($1, $2)", a.ID, a.Name)
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO accounts(id, name, email, password_hash, role) VALUES($1, $2, $3, $4, $5)",
+		a.ID, a.Name, a.Email, a.PasswordHash, a.Role,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+		return ErrEmailTaken
+	}
 	return err
 }
 
-// Queries a single account by ID
+// Queries a single account by ID. Soft-deleted accounts are treated as missing (ErrNotFound).
 func (r *postgresRepository) GetAccountByID(ctx context.Context, id string) (*Account, error) {
-	row := r.db.QueryRowContext(ctx, "SELECT id, name FROM accounts WHERE id = $1", id)
+	row := r.db.QueryRowContext(ctx, "SELECT id, name, email, role, COALESCE(preferred_currency, '') FROM accounts WHERE id = $1 AND deleted_at IS NULL", id)
 	a := &Account{}
-	if err := row.Scan(&a.ID, &a.Name); err != nil {
+	if err := row.Scan(&a.ID, &a.Name, &a.Email, &a.Role, &a.PreferredCurrency); err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Looks up an account by its login email, including the password hash so the service can check credentials.
+func (r *postgresRepository) GetAccountByEmail(ctx context.Context, email string) (*Account, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT id, name, email, role, COALESCE(preferred_currency, ''), password_hash FROM accounts WHERE email = $1 AND deleted_at IS NULL", email)
+	a := &Account{}
+	if err := row.Scan(&a.ID, &a.Name, &a.Email, &a.Role, &a.PreferredCurrency, &a.PasswordHash); err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
 		return nil, err
 	}
 	return a, nil
@@ -67,7 +102,7 @@ func (r *postgresRepository) GetAccountByID(ctx context.Context, id string) (*Ac
 // rows.Next() moves the cursor to the next row in the result set.
 // rows.Scan() reads the current row's column values into variables.
 func (r *postgresRepository) ListAccounts(ctx context.Context, skip uint64, take uint64) ([]Account, error) {
-	rows, err := r.db.QueryContext(ctx, "SELECT id, name FROM accounts ORDER BY id DESC OFFSET $1 LIMIT $2", skip, take)
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, email, role, COALESCE(preferred_currency, '') FROM accounts WHERE deleted_at IS NULL ORDER BY id DESC OFFSET $1 LIMIT $2", skip, take)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +111,7 @@ func (r *postgresRepository) ListAccounts(ctx context.Context, skip uint64, take
 
 	for rows.Next() {
 		a := &Account{}
-		if err = rows.Scan(&a.ID, &a.Name); err == nil {
+		if err = rows.Scan(&a.ID, &a.Name, &a.Email, &a.Role, &a.PreferredCurrency); err == nil {
 			accounts = append(accounts, *a)
 		}
 	}
@@ -86,3 +121,194 @@ func (r *postgresRepository) ListAccounts(ctx context.Context, skip uint64, take
 	}
 	return accounts, nil
 }
+
+// Returns the accounts that come after afterID in the same id DESC order ListAccounts uses (keyset pagination).
+// KSUIDs sort by creation time, so this walks accounts from newest to oldest. An empty afterID starts at the top.
+// Unlike OFFSET, the database can jump straight to the position through the primary key index.
+func (r *postgresRepository) ListAccountsAfter(ctx context.Context, afterID string, take uint64) ([]Account, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, name, email, role, COALESCE(preferred_currency, '') FROM accounts WHERE deleted_at IS NULL AND ($1 = '' OR id < $1) ORDER BY id DESC LIMIT $2",
+		afterID, take,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	accounts := []Account{}
+	for rows.Next() {
+		a := &Account{}
+		if err = rows.Scan(&a.ID, &a.Name, &a.Email, &a.Role, &a.PreferredCurrency); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *a)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// Number of accounts that have not been deleted.
+func (r *postgresRepository) CountAccounts(ctx context.Context) (uint64, error) {
+	var n uint64
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM accounts WHERE deleted_at IS NULL").Scan(&n)
+	return n, err
+}
+
+// Renames an existing account. Returns ErrNotFound if the account does not exist or was deleted.
+func (r *postgresRepository) UpdateAccount(ctx context.Context, a Account) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE accounts SET name = $2 WHERE id = $1 AND deleted_at IS NULL", a.ID, a.Name)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, ErrNotFound)
+}
+
+// Soft-deletes an account by stamping deleted_at; the row is kept so existing orders still reference it.
+// Returns ErrNotFound if the account does not exist or was already deleted.
+func (r *postgresRepository) DeleteAccount(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE accounts SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, ErrNotFound)
+}
+
+// Changes the role of an account. Returns ErrNotFound if the account does not exist or was deleted.
+func (r *postgresRepository) UpdateAccountRole(ctx context.Context, id string, role string) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE accounts SET role = $2 WHERE id = $1 AND deleted_at IS NULL", id, role)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, ErrNotFound)
+}
+
+// Sets or (with "") clears the preferred currency of an account. Returns ErrNotFound if the account does not exist or was deleted.
+func (r *postgresRepository) UpdatePreferredCurrency(ctx context.Context, id string, currency string) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE accounts SET preferred_currency = NULLIF($2, '') WHERE id = $1 AND deleted_at IS NULL", id, currency)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, ErrNotFound)
+}
+
+// Turns a statement that touched no rows into notFound (ErrNotFound or ErrAddressNotFound).
+func expectAffected(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// Columns selected for an address, in the order scanAddress reads them.
+const addressColumns = "id, account_id, kind, name, line1, line2, city, region, postal_code, country, is_default"
+
+func scanAddress(row interface{ Scan(...interface{}) error }) (*Address, error) {
+	a := &Address{}
+	err := row.Scan(&a.ID, &a.AccountID, &a.Kind, &a.Name, &a.Line1, &a.Line2, &a.City, &a.Region, &a.PostalCode, &a.Country, &a.IsDefault)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Stores a new address, as the default of its kind if the account has none yet, and returns whether it is.
+// Two first addresses added at once both see no default; the one that loses the race on addresses_default_key
+// is stored as an ordinary address instead of failing.
+func (r *postgresRepository) PutAddress(ctx context.Context, a Address) (isDefault bool, err error) {
+	err = r.db.QueryRowContext(ctx,
+		`INSERT INTO addresses(`+addressColumns+`) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+			NOT EXISTS (SELECT 1 FROM addresses WHERE account_id = $2 AND kind = $3 AND is_default))
+		ON CONFLICT (account_id, kind) WHERE is_default DO NOTHING
+		RETURNING is_default`,
+		a.ID, a.AccountID, a.Kind, a.Name, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country,
+	).Scan(&isDefault)
+	if err != sql.ErrNoRows {
+		return isDefault, err
+	}
+	_, err = r.db.ExecContext(ctx,
+		"INSERT INTO addresses("+addressColumns+") VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, FALSE)",
+		a.ID, a.AccountID, a.Kind, a.Name, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country,
+	)
+	return false, err
+}
+
+// Returns all addresses of an account, defaults first, then oldest first.
+func (r *postgresRepository) ListAddresses(ctx context.Context, accountID string) ([]Address, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+addressColumns+" FROM addresses WHERE account_id = $1 ORDER BY is_default DESC, created_at, id",
+		accountID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	addresses := []Address{}
+	for rows.Next() {
+		a, err := scanAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, *a)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// Overwrites the editable fields of an address. The kind and default flag are left untouched.
+// Returns ErrAddressNotFound if the address does not exist or belongs to another account.
+func (r *postgresRepository) UpdateAddress(ctx context.Context, a Address) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE addresses SET name = $3, line1 = $4, line2 = $5, city = $6, region = $7, postal_code = $8, country = $9
+		WHERE id = $1 AND account_id = $2`,
+		a.ID, a.AccountID, a.Name, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, ErrAddressNotFound)
+}
+
+func (r *postgresRepository) DeleteAddress(ctx context.Context, accountID string, id string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM addresses WHERE id = $1 AND account_id = $2", id, accountID)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, ErrAddressNotFound)
+}
+
+// Makes an address the default of its kind. The previous default is cleared in the same transaction,
+// so the "one default per kind" unique index never sees two defaults.
+func (r *postgresRepository) SetDefaultAddress(ctx context.Context, accountID string, id string) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	var kind string
+	err = tx.QueryRowContext(ctx, "SELECT kind FROM addresses WHERE id = $1 AND account_id = $2 FOR UPDATE", id, accountID).Scan(&kind)
+	if err == sql.ErrNoRows {
+		err = ErrAddressNotFound
+	}
+	if err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE addresses SET is_default = FALSE WHERE account_id = $1 AND kind = $2 AND is_default", accountID, kind)
+	if err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE addresses SET is_default = TRUE WHERE id = $1", id)
+	return
+}
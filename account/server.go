@@ -1,6 +1,6 @@
 // talks to both the client and the service
 // calls functions from service and returns data to the client in gRPC format (gRPC protobuf data packets)
-// PostAccount, GetAccount and GetAccounts take protobuf request and return protobuf response
+// Every RPC takes a protobuf request and returns a protobuf response
 
 package account
 
@@ -10,68 +10,336 @@ import (
 	"net"
 
 	"Microservices-based-E-commerce-System/account/pb"
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/idempotency"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
 )
 
 // struct that implements the methods from .proto service (PostAccount, GetAccount and GetAccounts)
 type grpcServer struct {
-	pb.UnimplementedAccountServiceServer // safety default implementation provided by gRPC. If one forgets to implement a method, the gRPC server will panic with a clear error instead of silently failing.
-	service Service // talks to the repository
+	pb.UnimplementedAccountServiceServer                   // safety default implementation provided by gRPC. If one forgets to implement a method, the gRPC server will panic with a clear error instead of silently failing.
+	service                              Service           // talks to the repository
+	idempotency                          idempotency.Store // remembers PostAccount results by idempotency key
 }
 
-// starts gRPC server on a given port. Service is passed from main.go
-func ListenGRPC(s Service, port int) error {
+// Who may call what. Methods acting on a single account additionally check ownership with auth.AuthorizeAccount.
+var policy = auth.Policy{
+	pb.AccountService_GetAccount_FullMethodName:           auth.Authenticated,
+	pb.AccountService_GetAccounts_FullMethodName:          auth.AdminOnly,
+	pb.AccountService_ListAccounts_FullMethodName:         auth.AdminOnly,
+	pb.AccountService_UpdateAccount_FullMethodName:        auth.Authenticated,
+	pb.AccountService_DeleteAccount_FullMethodName:        auth.Authenticated,
+	pb.AccountService_SetAccountRole_FullMethodName:       auth.AdminOnly,
+	pb.AccountService_SetPreferredCurrency_FullMethodName: auth.Authenticated,
+
+	pb.AccountService_AddAddress_FullMethodName:        auth.Authenticated,
+	pb.AccountService_ListAddresses_FullMethodName:     auth.Authenticated,
+	pb.AccountService_UpdateAddress_FullMethodName:     auth.Authenticated,
+	pb.AccountService_DeleteAddress_FullMethodName:     auth.Authenticated,
+	pb.AccountService_SetDefaultAddress_FullMethodName: auth.Authenticated,
+}
+
+// starts gRPC server on a given port. Service, token manager and idempotency store are passed from main.go
+func ListenGRPC(s Service, tokens *auth.TokenManager, keys idempotency.Store, port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port)) // Starts a TCP listener on the given port
 	if err != nil {
 		return err
 	}
-	serv := grpc.NewServer() // creates a new instance of a gRPC server which will handle incoming RPC calls
+	serv := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(tokens, policy))) // creates a new instance of a gRPC server which will handle incoming RPC calls
 	pb.RegisterAccountServiceServer(serv, &grpcServer{
 		UnimplementedAccountServiceServer: pb.UnimplementedAccountServiceServer{},
 		service:                           s,
+		idempotency:                       keys,
 	}) // Registers AccountService with the gRPC server.
 	reflection.Register(serv) // for debugging; lets clients discover services and methods dynamically at runtime.
 	return serv.Serve(lis)    // starts the gRPC server and begins listening for requests on the TCP listener (lis).
 }
 
 // Receives PostAccountRequest, calls service.PostAccount, returns PostAccountResponse.
+// A retry carrying the same idempotency key gets the account created by the first attempt.
 func (s *grpcServer) PostAccount(ctx context.Context, r *pb.PostAccountRequest) (*pb.PostAccountResponse, error) {
-	a, err := s.service.PostAccount(ctx, r.Name)
+	// The stored request hash must not be derived from the plaintext password, so it is left out.
+	hashed := proto.Clone(r).(*pb.PostAccountRequest)
+	hashed.Password = ""
+	res := &pb.PostAccountResponse{}
+	err := idempotency.Do(ctx, s.idempotency, pb.AccountService_PostAccount_FullMethodName, r.IdempotencyKey, hashed, res, func() error {
+		a, err := s.service.PostAccount(ctx, r.Name, r.Email, r.Password)
+		if err != nil {
+			return err
+		}
+		res.Account = &pb.Account{
+			Id:                a.ID,
+			Name:              a.Name,
+			Email:             a.Email,
+			Role:              a.Role,
+			PreferredCurrency: a.PreferredCurrency,
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
-
-	return &pb.PostAccountResponse{Account: &pb.Account{
-		Id:   a.ID,
-		Name: a.Name,
-	}}, nil
+	return res, nil
 }
 
 func (s *grpcServer) GetAccount(ctx context.Context, r *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.Id); err != nil {
+		return nil, toStatus(err)
+	}
 	a, err := s.service.GetAccount(ctx, r.Id)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &pb.GetAccountResponse{Account: &pb.Account{
-		Id:   a.ID,
-		Name: a.Name,
+		Id:                a.ID,
+		Name:              a.Name,
+		Email:             a.Email,
+		Role:              a.Role,
+		PreferredCurrency: a.PreferredCurrency,
 	}}, nil
 }
 
 func (s *grpcServer) GetAccounts(ctx context.Context, r *pb.GetAccountsRequest) (*pb.GetAccountsResponse, error) {
 	res, err := s.service.GetAccounts(ctx, r.Skip, r.Take)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	accounts := []*pb.Account{}
 	for _, a := range res {
 		accounts = append(accounts, &pb.Account{
-			Id:   a.ID,
-			Name: a.Name,
+			Id:                a.ID,
+			Name:              a.Name,
+			Email:             a.Email,
+			Role:              a.Role,
+			PreferredCurrency: a.PreferredCurrency,
 		})
 	}
 
 	return &pb.GetAccountsResponse{Accounts: accounts}, nil
 }
+
+func (s *grpcServer) ListAccounts(ctx context.Context, r *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	page, err := s.service.ListAccounts(ctx, r.After, r.First)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	edges := []*pb.ListAccountsResponse_Edge{}
+	for _, e := range page.Edges {
+		edges = append(edges, &pb.ListAccountsResponse_Edge{
+			Cursor: e.Cursor,
+			Account: &pb.Account{
+				Id:                e.Account.ID,
+				Name:              e.Account.Name,
+				Email:             e.Account.Email,
+				Role:              e.Account.Role,
+				PreferredCurrency: e.Account.PreferredCurrency,
+			},
+		})
+	}
+	return &pb.ListAccountsResponse{
+		Edges:       edges,
+		HasNextPage: page.HasNextPage,
+		TotalCount:  page.TotalCount,
+	}, nil
+}
+
+func (s *grpcServer) UpdateAccount(ctx context.Context, r *pb.UpdateAccountRequest) (*pb.UpdateAccountResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	a, err := s.service.UpdateAccount(ctx, r.Id, r.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateAccountResponse{Account: &pb.Account{
+		Id:                a.ID,
+		Name:              a.Name,
+		Email:             a.Email,
+		Role:              a.Role,
+		PreferredCurrency: a.PreferredCurrency,
+	}}, nil
+}
+
+func (s *grpcServer) DeleteAccount(ctx context.Context, r *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.service.DeleteAccount(ctx, r.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func (s *grpcServer) SetAccountRole(ctx context.Context, r *pb.SetAccountRoleRequest) (*pb.SetAccountRoleResponse, error) {
+	a, err := s.service.SetAccountRole(ctx, r.Id, r.Role)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetAccountRoleResponse{Account: &pb.Account{
+		Id:                a.ID,
+		Name:              a.Name,
+		Email:             a.Email,
+		Role:              a.Role,
+		PreferredCurrency: a.PreferredCurrency,
+	}}, nil
+}
+
+func (s *grpcServer) SetPreferredCurrency(ctx context.Context, r *pb.SetPreferredCurrencyRequest) (*pb.SetPreferredCurrencyResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	a, err := s.service.SetPreferredCurrency(ctx, r.Id, r.Currency)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetPreferredCurrencyResponse{Account: &pb.Account{
+		Id:                a.ID,
+		Name:              a.Name,
+		Email:             a.Email,
+		Role:              a.Role,
+		PreferredCurrency: a.PreferredCurrency,
+	}}, nil
+}
+
+func (s *grpcServer) AddAddress(ctx context.Context, r *pb.AddAddressRequest) (*pb.AddAddressResponse, error) {
+	if r.Address == nil {
+		return nil, toStatus(ErrInvalidAddress)
+	}
+	if err := auth.AuthorizeAccount(ctx, r.Address.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	a, err := s.service.AddAddress(ctx, addressFromProto(r.Address))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.AddAddressResponse{Address: addressToProto(*a)}, nil
+}
+
+func (s *grpcServer) ListAddresses(ctx context.Context, r *pb.ListAddressesRequest) (*pb.ListAddressesResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	res, err := s.service.ListAddresses(ctx, r.AccountId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	addresses := []*pb.Address{}
+	for _, a := range res {
+		addresses = append(addresses, addressToProto(a))
+	}
+	return &pb.ListAddressesResponse{Addresses: addresses}, nil
+}
+
+func (s *grpcServer) UpdateAddress(ctx context.Context, r *pb.UpdateAddressRequest) (*pb.UpdateAddressResponse, error) {
+	if r.Address == nil {
+		return nil, toStatus(ErrInvalidAddress)
+	}
+	if err := auth.AuthorizeAccount(ctx, r.Address.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	a, err := s.service.UpdateAddress(ctx, addressFromProto(r.Address))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateAddressResponse{Address: addressToProto(*a)}, nil
+}
+
+func (s *grpcServer) DeleteAddress(ctx context.Context, r *pb.DeleteAddressRequest) (*pb.DeleteAddressResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.service.DeleteAddress(ctx, r.AccountId, r.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteAddressResponse{}, nil
+}
+
+func (s *grpcServer) SetDefaultAddress(ctx context.Context, r *pb.SetDefaultAddressRequest) (*pb.SetDefaultAddressResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	a, err := s.service.SetDefaultAddress(ctx, r.AccountId, r.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetDefaultAddressResponse{Address: addressToProto(*a)}, nil
+}
+
+// Checks credentials and returns a freshly signed access + refresh token pair.
+func (s *grpcServer) Login(ctx context.Context, r *pb.LoginRequest) (*pb.LoginResponse, error) {
+	a, tokens, err := s.service.Login(ctx, r.Email, r.Password)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.LoginResponse{
+		Account: &pb.Account{
+			Id:                a.ID,
+			Name:              a.Name,
+			Email:             a.Email,
+			Role:              a.Role,
+			PreferredCurrency: a.PreferredCurrency,
+		},
+		Tokens: tokensToProto(tokens),
+	}, nil
+}
+
+func (s *grpcServer) RefreshToken(ctx context.Context, r *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	a, tokens, err := s.service.RefreshToken(ctx, r.RefreshToken)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RefreshTokenResponse{
+		Account: &pb.Account{
+			Id:                a.ID,
+			Name:              a.Name,
+			Email:             a.Email,
+			Role:              a.Role,
+			PreferredCurrency: a.PreferredCurrency,
+		},
+		Tokens: tokensToProto(tokens),
+	}, nil
+}
+
+func tokensToProto(t *auth.Tokens) *pb.AuthTokens {
+	tokens := &pb.AuthTokens{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+	}
+	tokens.AccessTokenExpiresAt, _ = t.AccessTokenExpiresAt.MarshalBinary() // same time encoding the order service uses
+	return tokens
+}
+
+func addressToProto(a Address) *pb.Address {
+	return &pb.Address{
+		Id:         a.ID,
+		AccountId:  a.AccountID,
+		Kind:       a.Kind,
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		IsDefault:  a.IsDefault,
+	}
+}
+
+func addressFromProto(a *pb.Address) Address {
+	return Address{
+		ID:         a.Id,
+		AccountID:  a.AccountId,
+		Kind:       a.Kind,
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		IsDefault:  a.IsDefault,
+	}
+}
@@ -4,35 +4,129 @@ package account
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/money"
 
 	"github.com/segmentio/ksuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minPasswordLength = 8
+	maxNameLength     = 24
+)
+
+// Longest address fields, in characters, as sized in up.sql.
+const (
+	maxAddressNameLength = 64
+	maxAddressLineLength = 128
+	maxCityLength        = 64
+	maxRegionLength      = 64
+	maxPostalCodeLength  = 16
+)
+
+// Kinds of address an account can have.
+const (
+	AddressShipping = "shipping"
+	AddressBilling  = "billing"
 )
 
 type Service interface {
-	PostAccount(ctx context.Context, name string) (*Account, error)
+	PostAccount(ctx context.Context, name, email, password string) (*Account, error)
 	GetAccount(ctx context.Context, id string) (*Account, error)
 	GetAccounts(ctx context.Context, skip uint64, take uint64) ([]Account, error)
+	ListAccounts(ctx context.Context, after string, first uint64) (*AccountPage, error)
+	UpdateAccount(ctx context.Context, id string, name string) (*Account, error)
+	DeleteAccount(ctx context.Context, id string) error
+	SetAccountRole(ctx context.Context, id string, role string) (*Account, error)
+	SetPreferredCurrency(ctx context.Context, id string, currency string) (*Account, error)
+	AddAddress(ctx context.Context, a Address) (*Address, error)
+	ListAddresses(ctx context.Context, accountID string) ([]Address, error)
+	UpdateAddress(ctx context.Context, a Address) (*Address, error)
+	DeleteAddress(ctx context.Context, accountID string, id string) error
+	SetDefaultAddress(ctx context.Context, accountID string, id string) (*Address, error)
+	Login(ctx context.Context, email, password string) (*Account, *auth.Tokens, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*Account, *auth.Tokens, error)
 }
 
 type Account struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Email             string `json:"email"`
+	Role              string `json:"role"`              // auth.RoleCustomer or auth.RoleAdmin
+	PreferredCurrency string `json:"preferredCurrency"` // ISO 4217 code orders are priced in by default, "" if not set
+	PasswordHash      string `json:"-"`                 // bcrypt hash, never sent back to clients
 }
 
-// Holds the Repository
+// One page of accounts returned by ListAccounts.
+type AccountPage struct {
+	Edges       []AccountEdge
+	HasNextPage bool
+	TotalCount  uint64
+}
+
+// An account together with the opaque cursor pointing at it.
+type AccountEdge struct {
+	Cursor  string
+	Account Account
+}
+
+// A shipping or billing address belonging to an account.
+type Address struct {
+	ID         string `json:"id"`
+	AccountID  string `json:"accountId"`
+	Kind       string `json:"kind"` // AddressShipping or AddressBilling
+	Name       string `json:"name"` // recipient
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"` // state / province, if the country has one
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"` // ISO 3166-1 alpha-2, e.g. "DE"
+	IsDefault  bool   `json:"isDefault"`
+}
+
+// Holds the Repository and the token manager used to sign login tokens
 type accountService struct {
 	repository Repository
+	tokens     *auth.TokenManager
 }
 
 // Returns a new Service
-func NewService(r Repository) Service {
-	return &accountService{r}
+func NewService(r Repository, tokens *auth.TokenManager) Service {
+	return &accountService{r, tokens}
 }
 
-func (s *accountService) PostAccount(ctx context.Context, name string) (*Account, error) {
+func (s *accountService) PostAccount(ctx context.Context, name, email, password string) (*Account, error) {
+	name = strings.TrimSpace(name)
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) < minPasswordLength {
+		return nil, ErrWeakPassword
+	}
+	// bcrypt salts the hash itself, so the same password never produces the same hash twice.
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
 	a := &Account{
-		Name: name,
-		ID:   ksuid.New().String(), // Generates a globally unique, timestamp-ordered ID for the account
+		Name:         name,
+		ID:           ksuid.New().String(), // Generates a globally unique, timestamp-ordered ID for the account
+		Email:        email,
+		Role:         auth.RoleCustomer, // admins are promoted later through SetAccountRole
+		PasswordHash: string(hash),
 	}
 	if err := s.repository.PutAccount(ctx, *a); err != nil {
 		return nil, err
@@ -52,3 +146,241 @@ func (s *accountService) GetAccounts(ctx context.Context, skip uint64, take uint
 	}
 	return s.repository.ListAccounts(ctx, skip, take)
 }
+
+// Cursor-based alternative to GetAccounts. The cursor is the base64-encoded account id,
+// kept opaque so clients don't build their own.
+func (s *accountService) ListAccounts(ctx context.Context, after string, first uint64) (*AccountPage, error) {
+	if first > 100 || first == 0 {
+		first = 100
+	}
+	afterID := ""
+	if after != "" {
+		id, err := base64.RawURLEncoding.DecodeString(after)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		afterID = string(id)
+	}
+	// Fetch one extra row to find out whether there is a next page without a second query.
+	accounts, err := s.repository.ListAccountsAfter(ctx, afterID, first+1)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repository.CountAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page := &AccountPage{
+		Edges:       []AccountEdge{},
+		HasNextPage: uint64(len(accounts)) > first,
+		TotalCount:  total,
+	}
+	for i, a := range accounts {
+		if uint64(i) == first {
+			break
+		}
+		page.Edges = append(page.Edges, AccountEdge{
+			Cursor:  base64.RawURLEncoding.EncodeToString([]byte(a.ID)),
+			Account: a,
+		})
+	}
+	return page, nil
+}
+
+func (s *accountService) UpdateAccount(ctx context.Context, id string, name string) (*Account, error) {
+	name = strings.TrimSpace(name)
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	a := &Account{
+		ID:   id,
+		Name: name,
+	}
+	if err := s.repository.UpdateAccount(ctx, *a); err != nil {
+		return nil, err
+	}
+	return s.repository.GetAccountByID(ctx, id)
+}
+
+// Soft delete: the account disappears from GetAccount/GetAccounts and can no longer place orders.
+func (s *accountService) DeleteAccount(ctx context.Context, id string) error {
+	return s.repository.DeleteAccount(ctx, id)
+}
+
+// Promotes or demotes an account. Only auth.RoleCustomer and auth.RoleAdmin are accepted.
+// The new role shows up in the account's tokens from its next login or refresh.
+func (s *accountService) SetAccountRole(ctx context.Context, id string, role string) (*Account, error) {
+	if role != auth.RoleCustomer && role != auth.RoleAdmin {
+		return nil, ErrInvalidRole
+	}
+	if err := s.repository.UpdateAccountRole(ctx, id, role); err != nil {
+		return nil, err
+	}
+	return s.repository.GetAccountByID(ctx, id)
+}
+
+// Sets the currency the account's orders are priced in when an order doesn't ask for one.
+// An empty currency clears the preference.
+func (s *accountService) SetPreferredCurrency(ctx context.Context, id string, currency string) (*Account, error) {
+	if currency != "" {
+		var err error
+		if currency, err = money.NormalizeCurrency(currency); err != nil {
+			return nil, ErrInvalidCurrency
+		}
+	}
+	if err := s.repository.UpdatePreferredCurrency(ctx, id, currency); err != nil {
+		return nil, err
+	}
+	return s.repository.GetAccountByID(ctx, id)
+}
+
+// Adds an address to an account. The first address of each kind becomes the default.
+func (s *accountService) AddAddress(ctx context.Context, a Address) (*Address, error) {
+	if err := validateAddress(&a); err != nil {
+		return nil, err
+	}
+	if _, err := s.repository.GetAccountByID(ctx, a.AccountID); err != nil {
+		return nil, err
+	}
+	a.ID = ksuid.New().String()
+	isDefault, err := s.repository.PutAddress(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	a.IsDefault = isDefault
+	return &a, nil
+}
+
+func (s *accountService) ListAddresses(ctx context.Context, accountID string) ([]Address, error) {
+	return s.repository.ListAddresses(ctx, accountID)
+}
+
+// Edits an address in place. Its kind cannot change; add a new address instead.
+func (s *accountService) UpdateAddress(ctx context.Context, a Address) (*Address, error) {
+	if err := validateAddress(&a); err != nil {
+		return nil, err
+	}
+	if err := s.repository.UpdateAddress(ctx, a); err != nil {
+		return nil, err
+	}
+	return s.findAddress(ctx, a.AccountID, a.ID)
+}
+
+// Removes an address. Orders keep their own copy of the address, so past orders are unaffected.
+func (s *accountService) DeleteAddress(ctx context.Context, accountID string, id string) error {
+	return s.repository.DeleteAddress(ctx, accountID, id)
+}
+
+func (s *accountService) SetDefaultAddress(ctx context.Context, accountID string, id string) (*Address, error) {
+	if err := s.repository.SetDefaultAddress(ctx, accountID, id); err != nil {
+		return nil, err
+	}
+	return s.findAddress(ctx, accountID, id)
+}
+
+func (s *accountService) findAddress(ctx context.Context, accountID string, id string) (*Address, error) {
+	addresses, err := s.repository.ListAddresses(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range addresses {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	return nil, ErrAddressNotFound
+}
+
+// Checks the required fields and their lengths, and normalizes the kind and country code.
+func validateAddress(a *Address) error {
+	a.Kind = strings.ToLower(strings.TrimSpace(a.Kind))
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	if a.Kind != AddressShipping && a.Kind != AddressBilling {
+		return ErrInvalidAddress
+	}
+	if strings.TrimSpace(a.Line1) == "" || strings.TrimSpace(a.City) == "" || strings.TrimSpace(a.PostalCode) == "" || len(a.Country) != 2 {
+		return ErrInvalidAddress
+	}
+	fields := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"name", a.Name, maxAddressNameLength},
+		{"line1", a.Line1, maxAddressLineLength},
+		{"line2", a.Line2, maxAddressLineLength},
+		{"city", a.City, maxCityLength},
+		{"region", a.Region, maxRegionLength},
+		{"postal code", a.PostalCode, maxPostalCodeLength},
+	}
+	for _, f := range fields {
+		if utf8.RuneCountInString(f.value) > f.max {
+			return fmt.Errorf("%w: %s must be at most %d characters long", ErrAddressTooLong, f.name, f.max)
+		}
+	}
+	return nil
+}
+
+// Checks the email/password pair and issues a new access + refresh token pair.
+// Unknown emails and wrong passwords return the same error so callers cannot probe which emails are registered.
+func (s *accountService) Login(ctx context.Context, email, password string) (*Account, *auth.Tokens, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+	a, err := s.repository.GetAccountByEmail(ctx, email)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(a.PasswordHash), []byte(password)); err != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+	tokens, err := s.tokens.IssueTokens(a.ID, a.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+	a.PasswordHash = ""
+	return a, tokens, nil
+}
+
+// Exchanges a valid refresh token for a new token pair, as long as the account still exists.
+// The role is re-read from the database, so role changes take effect on refresh.
+func (s *accountService) RefreshToken(ctx context.Context, refreshToken string) (*Account, *auth.Tokens, error) {
+	claims, err := s.tokens.Verify(refreshToken, auth.RefreshToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := s.repository.GetAccountByID(ctx, claims.AccountID())
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil, auth.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	tokens, err := s.tokens.IssueTokens(a.ID, a.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, tokens, nil
+}
+
+// Expects the name trimmed already, as it is stored.
+func validateName(name string) error {
+	if n := len([]rune(name)); n == 0 || n > maxNameLength {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+// Emails are compared case-insensitively, so they are stored lower-cased.
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
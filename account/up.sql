@@ -1,4 +1,42 @@
 CREATE TABLE IF NOT EXISTS accounts (
     id CHAR(27) PRIMARY KEY,
-    name VARCHAR(24) NOT NULL
-);
\ No newline at end of file
+    name VARCHAR(24) NOT NULL,
+    email VARCHAR(254) NOT NULL,
+    password_hash VARCHAR(72) NOT NULL,
+    role VARCHAR(16) NOT NULL DEFAULT 'customer',
+    preferred_currency CHAR(3), -- ISO 4217 code orders are priced in by default, NULL if not set
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- An email can be reused once the account holding it has been deleted.
+CREATE UNIQUE INDEX IF NOT EXISTS accounts_email_key ON accounts (email) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS addresses (
+    id CHAR(27) PRIMARY KEY,
+    account_id CHAR(27) NOT NULL REFERENCES accounts(id),
+    kind VARCHAR(16) NOT NULL, -- 'shipping' or 'billing'
+    name VARCHAR(64) NOT NULL,
+    line1 VARCHAR(128) NOT NULL,
+    line2 VARCHAR(128) NOT NULL DEFAULT '',
+    city VARCHAR(64) NOT NULL,
+    region VARCHAR(64) NOT NULL DEFAULT '',
+    postal_code VARCHAR(16) NOT NULL,
+    country CHAR(2) NOT NULL, -- ISO 3166-1 alpha-2
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS addresses_account_id_idx ON addresses (account_id);
+
+-- At most one default shipping and one default billing address per account.
+CREATE UNIQUE INDEX IF NOT EXISTS addresses_default_key ON addresses (account_id, kind) WHERE is_default;
+
+-- Idempotency keys of create requests, see the idempotency package.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(400) PRIMARY KEY, -- "<method>|<caller>|<client key>"
+    request_hash CHAR(64) NOT NULL,
+    token CHAR(32) NOT NULL, -- identifies the attempt holding the key
+    response BYTEA, -- NULL while the first attempt is running
+    failure BYTEA, -- gRPC status of an attempt that failed after making changes that stand
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,40 @@
+// Carries the authenticated caller (the "principal") through a request's context.
+
+package auth
+
+import "context"
+
+type contextKey struct{}
+
+// The verified claims plus the raw token they came from, so the token can be forwarded to downstream services.
+type principal struct {
+	claims *Claims
+	token  string
+}
+
+// Returns a copy of ctx that carries the caller's raw access token and its verified claims.
+func NewContext(ctx context.Context, token string, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal{claims, token})
+}
+
+// Returns the claims of the caller, or false if the request was not authenticated.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	p, ok := ctx.Value(contextKey{}).(principal)
+	return p.claims, ok
+}
+
+// Returns the caller's raw access token, or false if the request was not authenticated.
+func tokenFromContext(ctx context.Context) (string, bool) {
+	p, ok := ctx.Value(contextKey{}).(principal)
+	return p.token, ok
+}
+
+// Reports whether the caller may act on the given account: either it is their own account,
+// or they are an admin or another service.
+func CanAccessAccount(ctx context.Context, accountID string) bool {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return false
+	}
+	return claims.IsAdmin() || claims.IsService() || claims.AccountID() == accountID
+}
@@ -0,0 +1,90 @@
+// gRPC plumbing for authentication: the gateway forwards the caller's access token as "authorization" metadata,
+// and each service verifies it in a unary interceptor before the request reaches the grpcServer methods.
+
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const metadataKey = "authorization"
+
+// Access level required to call a gRPC method.
+type Rule int
+
+const (
+	Public        Rule = iota // anyone, with or without a token
+	Authenticated             // any caller with a valid token; ownership is checked by the method itself
+	AdminOnly                 // callers whose token carries the admin role, and other services
+	ServiceOnly               // other services, with a token from TokenManager.ServiceContext
+)
+
+// Maps full gRPC method names (e.g. "/catalog.CatalogService/PostProduct") to the rule guarding them.
+// Methods that are not listed are Public.
+type Policy map[string]Rule
+
+// Attaches the caller's token (if any) to outgoing calls, so identity follows the request across services.
+// Pass it to grpc.Dial via grpc.WithUnaryInterceptor.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if token, ok := tokenFromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, metadataKey, "Bearer "+token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// Verifies the token sent in the "authorization" metadata, stores the caller in the context,
+// and rejects calls that don't satisfy the method's rule in policy.
+// Pass it to grpc.NewServer via grpc.UnaryInterceptor.
+func UnaryServerInterceptor(tokens *TokenManager, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(metadataKey)) > 0 {
+			token := strings.TrimPrefix(md.Get(metadataKey)[0], "Bearer ")
+			claims, err := tokens.Verify(token, AccessToken)
+			if err != nil {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			ctx = NewContext(ctx, token, claims)
+		}
+
+		switch policy[info.FullMethod] {
+		case Authenticated:
+			if _, ok := FromContext(ctx); !ok {
+				return nil, status.Error(codes.Unauthenticated, "authentication required")
+			}
+		case AdminOnly:
+			claims, ok := FromContext(ctx)
+			if !ok {
+				return nil, status.Error(codes.Unauthenticated, "authentication required")
+			}
+			if !claims.IsAdmin() && !claims.IsService() {
+				return nil, status.Error(codes.PermissionDenied, "admin role required")
+			}
+		case ServiceOnly:
+			claims, ok := FromContext(ctx)
+			if !ok {
+				return nil, status.Error(codes.Unauthenticated, "authentication required")
+			}
+			if !claims.IsService() {
+				return nil, status.Error(codes.PermissionDenied, "internal method")
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Returns a PermissionDenied error unless the caller owns the account or is an admin.
+// grpcServer methods call this for requests that name an account id.
+func AuthorizeAccount(ctx context.Context, accountID string) error {
+	if !CanAccessAccount(ctx, accountID) {
+		return status.Error(codes.PermissionDenied, "not allowed to access this account")
+	}
+	return nil
+}
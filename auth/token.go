@@ -0,0 +1,142 @@
+// Issues and verifies the signed tokens (JWT, HMAC-SHA256) handed out by the account service.
+// Other services import this package to check a caller's token instead of trusting an account id sent in the request.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AccessTokenTTL  = 15 * time.Minute   // short-lived, sent with every request
+	RefreshTokenTTL = 7 * 24 * time.Hour // long-lived, only used to get a new access token
+
+	issuer = "account"
+)
+
+// Distinguishes access tokens from refresh tokens so one cannot be used in place of the other.
+type TokenType string
+
+const (
+	AccessToken  TokenType = "access"
+	RefreshToken TokenType = "refresh"
+)
+
+// Roles a token can carry. Customers may only touch their own data; admins may act on any account.
+// Services calling each other on their own behalf (not a customer's) use RoleService.
+const (
+	RoleCustomer = "customer"
+	RoleAdmin    = "admin"
+	RoleService  = "service"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Payload carried inside every token. The account id is stored in the standard "sub" claim.
+type Claims struct {
+	Type TokenType `json:"typ"`
+	Role string    `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Returns the id of the account the token was issued to; empty for service tokens, which act for no account.
+func (c *Claims) AccountID() string {
+	if c.IsService() {
+		return ""
+	}
+	return c.Subject
+}
+
+func (c *Claims) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
+
+func (c *Claims) IsService() bool {
+	return c.Role == RoleService
+}
+
+// Pair of tokens returned on login / refresh.
+type Tokens struct {
+	AccessToken          string
+	RefreshToken         string
+	AccessTokenExpiresAt time.Time
+}
+
+// Signs and verifies tokens with a shared secret. Every service that verifies tokens must be given the same secret.
+type TokenManager struct {
+	secret []byte
+}
+
+func NewTokenManager(secret string) *TokenManager {
+	return &TokenManager{[]byte(secret)}
+}
+
+// Creates a new access + refresh token pair for the given account and role.
+func (m *TokenManager) IssueTokens(accountID, role string) (*Tokens, error) {
+	now := time.Now().UTC()
+	access, err := m.sign(accountID, role, AccessToken, now, now.Add(AccessTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := m.sign(accountID, role, RefreshToken, now, now.Add(RefreshTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{
+		AccessToken:          access,
+		RefreshToken:         refresh,
+		AccessTokenExpiresAt: now.Add(AccessTokenTTL),
+	}, nil
+}
+
+// Returns a copy of ctx carrying a freshly signed access token for the named service (subject "service:<name>"),
+// so calls made with it act as that service instead of the caller. Used for internal operations,
+// such as reserving stock, that customers must not be able to call directly.
+func (m *TokenManager) ServiceContext(ctx context.Context, service string) (context.Context, error) {
+	now := time.Now().UTC()
+	subject := "service:" + service
+	token, err := m.sign(subject, RoleService, AccessToken, now, now.Add(AccessTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+	claims, err := m.Verify(token, AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return NewContext(ctx, token, claims), nil
+}
+
+// Checks the signature, expiry and type of a token and returns its claims.
+// Any failure is reported as ErrInvalidToken so callers don't leak why a token was rejected.
+func (m *TokenManager) Verify(token string, typ TokenType) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.Type != typ || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (m *TokenManager) sign(accountID, role string, typ TokenType, issuedAt, expiresAt time.Time) (string, error) {
+	claims := Claims{
+		Type: typ,
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   accountID,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+}
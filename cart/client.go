@@ -0,0 +1,123 @@
+package cart
+
+import (
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/cart/pb"
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type Client struct {
+	conn    *grpc.ClientConn
+	service pb.CartServiceClient
+}
+
+func NewClient(url string) (*Client, error) {
+	conn, err := grpc.Dial(url, grpc.WithInsecure(), grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor()))
+	if err != nil {
+		return nil, err
+	}
+	c := pb.NewCartServiceClient(conn)
+	return &Client{conn, c}, nil
+}
+
+func (c *Client) Close() {
+	c.conn.Close()
+}
+
+func (c *Client) AddItem(ctx context.Context, accountID string, productID string, quantity uint32) (*PricedCart, error) {
+	r, err := c.service.AddItem(ctx, &pb.AddItemRequest{
+		AccountId: accountID,
+		ProductId: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+// sets the quantity of a product in the cart; 0 removes it.
+func (c *Client) UpdateQuantity(ctx context.Context, accountID string, productID string, quantity uint32) (*PricedCart, error) {
+	r, err := c.service.UpdateQuantity(ctx, &pb.UpdateQuantityRequest{
+		AccountId: accountID,
+		ProductId: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+func (c *Client) RemoveItem(ctx context.Context, accountID string, productID string) (*PricedCart, error) {
+	r, err := c.service.RemoveItem(ctx, &pb.RemoveItemRequest{
+		AccountId: accountID,
+		ProductId: productID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+// fetches the cart priced in currency ("" for the account's preferred currency).
+func (c *Client) GetCart(ctx context.Context, accountID string, currency string) (*PricedCart, error) {
+	r, err := c.service.GetCart(ctx, &pb.GetCartRequest{
+		AccountId: accountID,
+		Currency:  currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+func (c *Client) ClearCart(ctx context.Context, accountID string) error {
+	_, err := c.service.ClearCart(ctx, &pb.ClearCartRequest{AccountId: accountID})
+	return err
+}
+
+// places an order for the cart's items and returns its id. shippingMethodID, couponCodes and idempotencyKey are optional;
+// retries with the same key return the order placed first.
+func (c *Client) Checkout(ctx context.Context, accountID, addressID, shippingMethodID, currency string, couponCodes []string, idempotencyKey string) (string, error) {
+	r, err := c.service.Checkout(ctx, &pb.CheckoutRequest{
+		AccountId:        accountID,
+		AddressId:        addressID,
+		ShippingMethodId: shippingMethodID,
+		Currency:         currency,
+		CouponCodes:      couponCodes,
+		IdempotencyKey:   idempotencyKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	return r.OrderId, nil
+}
+
+func cartFromProto(c *pb.Cart) *PricedCart {
+	cart := &PricedCart{
+		AccountID: c.AccountId,
+		Items:     []PricedItem{},
+		Total:     moneyFromProto(c.Total),
+	}
+	for _, item := range c.Items {
+		pi := PricedItem{
+			Item: Item{
+				ProductID: item.ProductId,
+				Quantity:  item.Quantity,
+			},
+			Name:        item.Name,
+			Description: item.Description,
+			Available:   item.Available,
+		}
+		if item.Available {
+			pi.UnitPrice = moneyFromProto(item.UnitPrice)
+			pi.LineTotal = moneyFromProto(item.LineTotal)
+		}
+		pi.AddedAt.UnmarshalBinary(item.AddedAt)
+		cart.Items = append(cart.Items, pi)
+	}
+	return cart
+}
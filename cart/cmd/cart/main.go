@@ -0,0 +1,50 @@
+package main
+
+import (
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/cart"
+	"Microservices-based-E-commerce-System/idempotency"
+	"log"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/tinrab/retry"
+)
+
+type Config struct {
+	DatabaseURL string `envconfig:"DATABASE_URL"`
+	AccountURL  string `envconfig:"ACCOUNT_SERVICE_URL"`
+	CatalogURL  string `envconfig:"CATALOG_SERVICE_URL"`
+	OrderURL    string `envconfig:"ORDER_SERVICE_URL"`
+	JWTSecret   string `envconfig:"JWT_SECRET" required:"true"`
+
+	MaxItemQuantity uint32 `envconfig:"MAX_ITEM_QUANTITY" default:"100"` // units of one product; keep <= the order service's MAX_LINE_QUANTITY
+	MaxItems        int    `envconfig:"MAX_CART_ITEMS" default:"50"`     // distinct products per cart
+}
+
+func main() {
+	var cfg Config
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var r cart.Repository
+	retry.ForeverSleep(2*time.Millisecond, func(_ int) (err error) {
+		r, err = cart.NewPostgresRepository(cfg.DatabaseURL)
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	})
+	defer r.Close()
+	keys, err := idempotency.NewPostgresStore(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Listening on port 8080...")
+	s := cart.NewService(r, cart.Limits{
+		MaxItemQuantity: cfg.MaxItemQuantity,
+		MaxItems:        cfg.MaxItems,
+	})
+	log.Fatal(cart.ListenGRPC(s, auth.NewTokenManager(cfg.JWTSecret), keys, cfg.AccountURL, cfg.CatalogURL, cfg.OrderURL, 8080))
+}
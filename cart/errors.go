@@ -0,0 +1,76 @@
+// Domain errors of the cart service and their translation to gRPC status codes.
+
+package cart
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrItemNotFound    = errors.New("product is not in the cart")
+	ErrProductNotFound = errors.New("product not found")
+	ErrAccountNotFound = errors.New("account not found or closed")
+	ErrInvalidQuantity = errors.New("quantity must be at least 1 and at most the per-product limit")
+	ErrCartFull        = errors.New("cart holds the maximum number of products")
+	ErrEmptyCart       = errors.New("cart is empty")
+	ErrInvalidCurrency = errors.New("currency must be a 3-letter ISO 4217 code")
+	ErrNoExchangeRate  = errors.New("no exchange rate into the cart's currency")
+	ErrUnavailable     = errors.New("a required service is unavailable, try again later")
+)
+
+// Converts an error returned by the service or one of its dependencies into a gRPC status error.
+// Errors that already carry a status (e.g. an order rejected by the order service) are passed through
+// with their details; anything unexpected is logged and reported as Internal without leaking details.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, ErrItemNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrProductNotFound), errors.Is(err, ErrInvalidQuantity), errors.Is(err, ErrInvalidCurrency):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrAccountNotFound), errors.Is(err, ErrCartFull), errors.Is(err, ErrEmptyCart),
+		errors.Is(err, ErrNoExchangeRate):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrUnavailable):
+		log.Println(err)
+		return status.Error(codes.Unavailable, ErrUnavailable.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+		log.Println(err)
+		return status.Error(codes.Unavailable, "cart database unavailable")
+	}
+	log.Println(err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// Interprets an error from another service: NotFound becomes notFound (if given),
+// connection problems become ErrUnavailable, and anything else is kept as is.
+func upstreamError(err error, notFound error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		if notFound != nil {
+			return notFound
+		}
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return err
+}
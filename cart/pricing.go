@@ -0,0 +1,71 @@
+// Live pricing of a cart: the catalog's current prices, converted into the cart's currency.
+
+package cart
+
+import (
+	"Microservices-based-E-commerce-System/catalog"
+	"Microservices-based-E-commerce-System/money"
+	"fmt"
+)
+
+type PricedCart struct {
+	AccountID string
+	Items     []PricedItem
+	Total     money.Money // sum of the available lines
+}
+
+type PricedItem struct {
+	Item
+	Name        string
+	Description string
+	UnitPrice   money.Money
+	LineTotal   money.Money
+	Available   bool // false if the product is no longer in the catalog; such lines have no price
+}
+
+// Prices every item with its catalog product, converting prices in other currencies with rates.
+// Products missing from products are kept in the cart but marked unavailable and left out of the total.
+func priceCart(c Cart, products []catalog.Product, currency string, rates []money.Rate) (*PricedCart, error) {
+	known := map[string]catalog.Product{}
+	for _, p := range products {
+		known[p.ID] = p
+	}
+	priced := &PricedCart{
+		AccountID: c.AccountID,
+		Items:     []PricedItem{},
+		Total:     money.Zero(currency),
+	}
+	for _, item := range c.Items {
+		p, ok := known[item.ProductID]
+		if !ok {
+			priced.Items = append(priced.Items, PricedItem{Item: item})
+			continue
+		}
+		unit := p.Price
+		if unit.Currency != currency {
+			rate, err := money.FindRate(rates, unit.Currency, currency)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrNoExchangeRate, err)
+			}
+			if unit, err = rate.Convert(unit); err != nil {
+				return nil, err
+			}
+		}
+		line, err := unit.Mul(int64(item.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		if priced.Total, err = priced.Total.Add(line); err != nil {
+			return nil, err
+		}
+		priced.Items = append(priced.Items, PricedItem{
+			Item:        item,
+			Name:        p.Name,
+			Description: p.Description,
+			UnitPrice:   unit,
+			LineTotal:   line,
+			Available:   true,
+		})
+	}
+	return priced, nil
+}
@@ -0,0 +1,92 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+type Repository interface {
+	Close()
+	GetCart(ctx context.Context, accountID string) (*Cart, error)
+	PutItem(ctx context.Context, accountID string, item Item, updatedAt time.Time) error
+	DeleteItem(ctx context.Context, accountID string, productID string) error
+	ClearCart(ctx context.Context, accountID string) error
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(url string) (Repository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	return &postgresRepository{db}, nil
+}
+
+func (r *postgresRepository) Close() {
+	r.db.Close()
+}
+
+// Reads the items of an account's cart, oldest first. An account without items has an empty cart.
+func (r *postgresRepository) GetCart(ctx context.Context, accountID string) (*Cart, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT product_id, quantity, added_at
+		FROM cart_items
+		WHERE account_id = $1
+		ORDER BY added_at, product_id`,
+		accountID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	c := &Cart{AccountID: accountID, Items: []Item{}}
+	for rows.Next() {
+		item := Item{}
+		if err := rows.Scan(&item.ProductID, &item.Quantity, &item.AddedAt); err != nil {
+			return nil, err
+		}
+		c.Items = append(c.Items, item)
+	}
+	return c, rows.Err()
+}
+
+// Inserts the item or, if the product is already in the cart, replaces its quantity (keeping when it was first added).
+func (r *postgresRepository) PutItem(ctx context.Context, accountID string, item Item, updatedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO cart_items(account_id, product_id, quantity, added_at, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (account_id, product_id) DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = EXCLUDED.updated_at`,
+		accountID, item.ProductID, item.Quantity, item.AddedAt, updatedAt,
+	)
+	return err
+}
+
+// Removes one product from the cart. Returns ErrItemNotFound if it isn't in the cart.
+func (r *postgresRepository) DeleteItem(ctx context.Context, accountID string, productID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM cart_items WHERE account_id = $1 AND product_id = $2", accountID, productID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) ClearCart(ctx context.Context, accountID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM cart_items WHERE account_id = $1", accountID)
+	return err
+}
@@ -0,0 +1,271 @@
+package cart
+
+import (
+	"Microservices-based-E-commerce-System/account"
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/cart/pb"
+	"Microservices-based-E-commerce-System/catalog"
+	"Microservices-based-E-commerce-System/idempotency"
+	"Microservices-based-E-commerce-System/money"
+	"Microservices-based-E-commerce-System/order"
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type grpcServer struct {
+	pb.UnimplementedCartServiceServer
+	service       Service
+	accountClient *account.Client
+	catalogClient *catalog.Client
+	orderClient   *order.Client
+	idempotency   idempotency.Store // remembers Checkout results by idempotency key
+}
+
+// A cart belongs to an account; every method checks that the caller owns it (or is an admin).
+var policy = auth.Policy{
+	pb.CartService_AddItem_FullMethodName:        auth.Authenticated,
+	pb.CartService_UpdateQuantity_FullMethodName: auth.Authenticated,
+	pb.CartService_RemoveItem_FullMethodName:     auth.Authenticated,
+	pb.CartService_GetCart_FullMethodName:        auth.Authenticated,
+	pb.CartService_ClearCart_FullMethodName:      auth.Authenticated,
+	pb.CartService_Checkout_FullMethodName:       auth.Authenticated,
+}
+
+func ListenGRPC(s Service, tokens *auth.TokenManager, keys idempotency.Store, accountURL, catalogURL, orderURL string, port int) error {
+	accountClient, err := account.NewClient(accountURL)
+	if err != nil {
+		return err
+	}
+	catalogClient, err := catalog.NewClient(catalogURL)
+	if err != nil {
+		accountClient.Close()
+		return err
+	}
+	orderClient, err := order.NewClient(orderURL)
+	if err != nil {
+		accountClient.Close()
+		catalogClient.Close()
+		return err
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		accountClient.Close()
+		catalogClient.Close()
+		orderClient.Close()
+		return err
+	}
+	serv := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(tokens, policy)))
+	pb.RegisterCartServiceServer(serv, &grpcServer{
+		UnimplementedCartServiceServer: pb.UnimplementedCartServiceServer{},
+		service:                        s,
+		accountClient:                  accountClient,
+		catalogClient:                  catalogClient,
+		orderClient:                    orderClient,
+		idempotency:                    keys,
+	})
+	reflection.Register(serv)
+	return serv.Serve(lis)
+}
+
+// Only products the catalog knows can be added.
+func (s *grpcServer) AddItem(ctx context.Context, r *pb.AddItemRequest) (*pb.AddItemResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	if _, err := s.catalogClient.GetProduct(ctx, r.ProductId); err != nil {
+		return nil, toStatus(upstreamError(err, ErrProductNotFound))
+	}
+	c, err := s.service.AddItem(ctx, r.AccountId, r.ProductId, r.Quantity)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	cart, err := s.price(ctx, *c, "")
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.AddItemResponse{Cart: cart}, nil
+}
+
+func (s *grpcServer) UpdateQuantity(ctx context.Context, r *pb.UpdateQuantityRequest) (*pb.UpdateQuantityResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	c, err := s.service.UpdateQuantity(ctx, r.AccountId, r.ProductId, r.Quantity)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	cart, err := s.price(ctx, *c, "")
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateQuantityResponse{Cart: cart}, nil
+}
+
+func (s *grpcServer) RemoveItem(ctx context.Context, r *pb.RemoveItemRequest) (*pb.RemoveItemResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	c, err := s.service.RemoveItem(ctx, r.AccountId, r.ProductId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	cart, err := s.price(ctx, *c, "")
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RemoveItemResponse{Cart: cart}, nil
+}
+
+func (s *grpcServer) GetCart(ctx context.Context, r *pb.GetCartRequest) (*pb.GetCartResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	c, err := s.service.GetCart(ctx, r.AccountId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	cart, err := s.price(ctx, *c, r.Currency)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetCartResponse{Cart: cart}, nil
+}
+
+func (s *grpcServer) ClearCart(ctx context.Context, r *pb.ClearCartRequest) (*pb.ClearCartResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.service.ClearCart(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ClearCartResponse{}, nil
+}
+
+// Places an order for everything in the cart through the order service, which re-validates the lines
+// and prices them itself, then empties the cart. Order rejections (e.g. per-line violations) are passed
+// through unchanged. A retry with the same idempotency key returns the first order, even though the cart
+// is empty by then.
+func (s *grpcServer) Checkout(ctx context.Context, r *pb.CheckoutRequest) (*pb.CheckoutResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.CheckoutResponse{}
+	err := idempotency.Do(ctx, s.idempotency, pb.CartService_Checkout_FullMethodName, r.IdempotencyKey, r, res, func() error {
+		c, err := s.service.GetCart(ctx, r.AccountId)
+		if err != nil {
+			return err
+		}
+		if len(c.Items) == 0 {
+			return ErrEmptyCart
+		}
+		products := []order.OrderedProduct{}
+		for _, item := range c.Items {
+			products = append(products, order.OrderedProduct{ID: item.ProductID, Quantity: item.Quantity})
+		}
+		o, err := s.orderClient.PostOrder(ctx, r.AccountId, r.AddressId, r.ShippingMethodId, r.Currency, products, r.CouponCodes, r.IdempotencyKey)
+		if err != nil {
+			return upstreamError(err, nil)
+		}
+		// The order is placed at this point; a cart that couldn't be emptied must not fail the checkout.
+		if err := s.service.ClearCart(ctx, r.AccountId); err != nil {
+			log.Println("Order", o.ID, "placed but cart not cleared:", err)
+		}
+		res.OrderId = o.ID
+		return nil
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return res, nil
+}
+
+// Prices the cart with the catalog's current prices in the requested currency,
+// else the account's preferred one, else the store's default.
+func (s *grpcServer) price(ctx context.Context, c Cart, currency string) (*pb.Cart, error) {
+	if currency == "" {
+		acc, err := s.accountClient.GetAccount(ctx, c.AccountID)
+		if err != nil {
+			return nil, upstreamError(err, ErrAccountNotFound)
+		}
+		currency = acc.PreferredCurrency
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	currency, err := money.NormalizeCurrency(currency)
+	if err != nil {
+		return nil, ErrInvalidCurrency
+	}
+
+	// (an empty id list would make the catalog return its first page of products instead)
+	products := []catalog.Product{}
+	if len(c.Items) > 0 {
+		ids := []string{}
+		for _, item := range c.Items {
+			ids = append(ids, item.ProductID)
+		}
+		if products, err = s.catalogClient.GetProducts(ctx, ids, "", 0, 0); err != nil {
+			return nil, upstreamError(err, nil)
+		}
+	}
+	// Exchange rates are only needed when some product isn't priced in the cart's currency.
+	rates := []money.Rate{}
+	for _, p := range products {
+		if p.Price.Currency != currency {
+			catalogRates, err := s.catalogClient.ListExchangeRates(ctx)
+			if err != nil {
+				return nil, upstreamError(err, nil)
+			}
+			for _, r := range catalogRates {
+				rates = append(rates, r.Rate)
+			}
+			break
+		}
+	}
+	priced, err := priceCart(c, products, currency, rates)
+	if err != nil {
+		return nil, err
+	}
+	return cartToProto(priced), nil
+}
+
+func cartToProto(c *PricedCart) *pb.Cart {
+	cart := &pb.Cart{
+		AccountId: c.AccountID,
+		Items:     []*pb.Cart_Item{},
+		Total:     moneyToProto(c.Total),
+		Currency:  c.Total.Currency,
+	}
+	for _, item := range c.Items {
+		pi := &pb.Cart_Item{
+			ProductId:   item.ProductID,
+			Name:        item.Name,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			Available:   item.Available,
+		}
+		if item.Available {
+			pi.UnitPrice = moneyToProto(item.UnitPrice)
+			pi.LineTotal = moneyToProto(item.LineTotal)
+		}
+		pi.AddedAt, _ = item.AddedAt.MarshalBinary()
+		cart.Items = append(cart.Items, pi)
+	}
+	return cart
+}
+
+func moneyToProto(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func moneyFromProto(m *pb.Money) money.Money {
+	if m == nil {
+		return money.Zero(money.DefaultCurrency)
+	}
+	return money.New(m.Amount, m.Currency)
+}
@@ -0,0 +1,123 @@
+// Handles the business logic of the cart: which products, and how many of each, an account intends to buy.
+// Prices are not part of the cart itself; they are looked up live when the cart is read (see priceCart).
+package cart
+
+import (
+	"context"
+	"time"
+)
+
+type Service interface {
+	AddItem(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error)
+	UpdateQuantity(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error)
+	RemoveItem(ctx context.Context, accountID string, productID string) (*Cart, error)
+	GetCart(ctx context.Context, accountID string) (*Cart, error)
+	ClearCart(ctx context.Context, accountID string) error
+}
+
+type Cart struct {
+	AccountID string
+	Items     []Item // in the order they were added
+}
+
+type Item struct {
+	ProductID string
+	Quantity  uint32
+	AddedAt   time.Time
+}
+
+// Bounds on what a cart may hold, mirroring the order service's limits so a full cart can still be checked out.
+type Limits struct {
+	MaxItemQuantity uint32 // units of one product
+	MaxItems        int    // distinct products
+}
+
+var DefaultLimits = Limits{
+	MaxItemQuantity: 100,
+	MaxItems:        50,
+}
+
+type cartService struct {
+	repository Repository
+	limits     Limits
+}
+
+func NewService(r Repository, limits Limits) Service {
+	return &cartService{r, limits}
+}
+
+// Adds quantity units of a product, on top of any already in the cart.
+// The caller checks that the product exists; the cart only stores its id.
+func (s cartService) AddItem(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error) {
+	if productID == "" {
+		return nil, ErrProductNotFound
+	}
+	c, err := s.repository.GetCart(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	item := Item{ProductID: productID, AddedAt: now}
+	if existing, ok := c.find(productID); ok {
+		item = existing
+	} else if len(c.Items) >= s.limits.MaxItems {
+		return nil, ErrCartFull
+	}
+	total := uint64(item.Quantity) + uint64(quantity)
+	if quantity == 0 || total > uint64(s.limits.MaxItemQuantity) {
+		return nil, ErrInvalidQuantity
+	}
+	item.Quantity = uint32(total)
+	if err := s.repository.PutItem(ctx, accountID, item, now); err != nil {
+		return nil, err
+	}
+	return s.repository.GetCart(ctx, accountID)
+}
+
+// Sets the quantity of a product already in the cart. A quantity of 0 removes it.
+func (s cartService) UpdateQuantity(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error) {
+	if quantity == 0 {
+		return s.RemoveItem(ctx, accountID, productID)
+	}
+	if quantity > s.limits.MaxItemQuantity {
+		return nil, ErrInvalidQuantity
+	}
+	c, err := s.repository.GetCart(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := c.find(productID)
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+	item.Quantity = quantity
+	if err := s.repository.PutItem(ctx, accountID, item, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return s.repository.GetCart(ctx, accountID)
+}
+
+func (s cartService) RemoveItem(ctx context.Context, accountID string, productID string) (*Cart, error) {
+	if err := s.repository.DeleteItem(ctx, accountID, productID); err != nil {
+		return nil, err
+	}
+	return s.repository.GetCart(ctx, accountID)
+}
+
+// Every account has a cart; one that was never filled is simply empty.
+func (s cartService) GetCart(ctx context.Context, accountID string) (*Cart, error) {
+	return s.repository.GetCart(ctx, accountID)
+}
+
+func (s cartService) ClearCart(ctx context.Context, accountID string) error {
+	return s.repository.ClearCart(ctx, accountID)
+}
+
+func (c *Cart) find(productID string) (Item, bool) {
+	for _, item := range c.Items {
+		if item.ProductID == productID {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
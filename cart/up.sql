@@ -0,0 +1,19 @@
+-- One cart per account; a cart is just its items. Prices are not stored, they are read from the catalog.
+CREATE TABLE IF NOT EXISTS cart_items (
+    account_id CHAR(27) NOT NULL,
+    product_id CHAR(27) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY(account_id, product_id)
+);
+
+-- Idempotency keys of Checkout requests, see the idempotency package.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(400) PRIMARY KEY, -- "<method>|<caller>|<client key>"
+    request_hash CHAR(64) NOT NULL,
+    token CHAR(32) NOT NULL, -- identifies the attempt holding the key
+    response BYTEA, -- NULL while the first attempt is running
+    failure BYTEA, -- gRPC status of an attempt that failed after making changes that stand
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
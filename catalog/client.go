@@ -1,8 +1,11 @@
 package catalog
 
 import (
+	"Microservices-based-E-commerce-System/auth"
 	"Microservices-based-E-commerce-System/catalog/pb"
+	"Microservices-based-E-commerce-System/money"
 	"context"
+	"time"
 
 	"google.golang.org/grpc"
 )
@@ -13,7 +16,7 @@ type Client struct {
 }
 
 func NewClient(url string) (*Client, error) {
-	conn, err := grpc.Dial(url, grpc.WithInsecure())
+	conn, err := grpc.Dial(url, grpc.WithInsecure(), grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor()))
 	if err != nil {
 		return nil, err
 	}
@@ -25,21 +28,25 @@ func (c *Client) Close() {
 	c.conn.Close()
 }
 
-func (c *Client) PostProduct(ctx context.Context, name, description string, price float64) (*Product, error) {
+// creates a product with stock units in stock. taxClass defaults to DefaultTaxClass when empty;
+// weight (grams) and dimensions (millimetres) of a packed unit may be left zero.
+// idempotencyKey is optional; retries with the same key return the product created first.
+func (c *Client) PostProduct(ctx context.Context, name, description string, price money.Money, stock int64, taxClass string, weight uint32, dimensions Dimensions, idempotencyKey string) (*Product, error) {
 	r, err := c.service.PostProduct(ctx, &pb.PostProductRequest{
-		Name:        name,
-		Description: description,
-		Price:       price,
+		Name:           name,
+		Description:    description,
+		Price:          moneyToProto(price),
+		Stock:          stock,
+		TaxClass:       taxClass,
+		Weight:         weight,
+		Dimensions:     dimensionsToProto(dimensions),
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &Product{
-		ID:          r.Product.Id,
-		Name:        r.Product.Name,
-		Description: r.Product.Description,
-		Price:       r.Product.Price,
-	}, nil
+	p := productFromProto(r.Product)
+	return &p, nil
 }
 
 func (c *Client) GetProduct(ctx context.Context, id string) (*Product, error) {
@@ -49,12 +56,8 @@ func (c *Client) GetProduct(ctx context.Context, id string) (*Product, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Product{
-		ID:          r.Product.Id,
-		Name:        r.Product.Name,
-		Description: r.Product.Description,
-		Price:       r.Product.Price,
-	}, nil
+	p := productFromProto(r.Product)
+	return &p, nil
 }
 
 func (c *Client) GetProducts(ctx context.Context, ids []string, query string, skip uint64, take uint64) ([]Product, error) {
@@ -70,12 +73,116 @@ func (c *Client) GetProducts(ctx context.Context, ids []string, query string, sk
 	products := []Product{}
 
 	for _, p := range r.Products {
-		products = append(products, Product{
-			ID:          p.Id,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-		})
+		products = append(products, productFromProto(p))
 	}
 	return products, nil
 }
+
+// fetches a page of products after the given cursor ("" for the first page), optionally filtered by a search query.
+func (c *Client) ListProducts(ctx context.Context, query string, after string, first uint64) (*ProductPage, error) {
+	r, err := c.service.ListProducts(ctx, &pb.ListProductsRequest{
+		After: after,
+		First: first,
+		Query: query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := &ProductPage{
+		Edges:       []ProductEdge{},
+		HasNextPage: r.HasNextPage,
+		TotalCount:  r.TotalCount,
+	}
+	for _, e := range r.Edges {
+		page.Edges = append(page.Edges, ProductEdge{
+			Cursor:  e.Cursor,
+			Product: productFromProto(e.Product),
+		})
+	}
+	return page, nil
+}
+
+// sets the rate at which one currency converts into another (admin only), e.g. ("EUR", "USD", "1.0853").
+func (c *Client) SetExchangeRate(ctx context.Context, from, to, rate string) (*ExchangeRate, error) {
+	r, err := c.service.SetExchangeRate(ctx, &pb.SetExchangeRateRequest{
+		From: from,
+		To:   to,
+		Rate: rate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	e, err := exchangeRateFromProto(r.Rate)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (c *Client) ListExchangeRates(ctx context.Context) ([]ExchangeRate, error) {
+	r, err := c.service.ListExchangeRates(ctx, &pb.ListExchangeRatesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	rates := []ExchangeRate{}
+	for _, e := range r.Rates {
+		rate, err := exchangeRateFromProto(e)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// adds delta units to a product's stock, negative to remove units (admin only).
+func (c *Client) AdjustStock(ctx context.Context, productID string, delta int64) (*Product, error) {
+	r, err := c.service.AdjustStock(ctx, &pb.AdjustStockRequest{
+		ProductId: productID,
+		Delta:     delta,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p := productFromProto(r.Product)
+	return &p, nil
+}
+
+// holds stock for every line, or for none; the caller needs a service token (see auth.TokenManager.ServiceContext).
+// Products without enough stock are reported as an *InsufficientStockError. ttl of 0 uses DefaultReservationTTL.
+func (c *Client) ReserveStock(ctx context.Context, lines []ReservationLine, ttl time.Duration) (*Reservation, error) {
+	protoLines := []*pb.Reservation_Line{}
+	for _, l := range lines {
+		protoLines = append(protoLines, &pb.Reservation_Line{ProductId: l.ProductID, Quantity: l.Quantity})
+	}
+	r, err := c.service.ReserveStock(ctx, &pb.ReserveStockRequest{
+		Lines:      protoLines,
+		TtlSeconds: uint32(ttl / time.Second),
+	})
+	if stockErr := insufficientStockFromStatus(err); stockErr != nil {
+		return nil, stockErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	reservation := reservationFromProto(r.Reservation)
+	return &reservation, nil
+}
+
+func (c *Client) CommitReservation(ctx context.Context, id string) (*Reservation, error) {
+	r, err := c.service.CommitReservation(ctx, &pb.CommitReservationRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	reservation := reservationFromProto(r.Reservation)
+	return &reservation, nil
+}
+
+func (c *Client) ReleaseReservation(ctx context.Context, id string) (*Reservation, error) {
+	r, err := c.service.ReleaseReservation(ctx, &pb.ReleaseReservationRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	reservation := reservationFromProto(r.Reservation)
+	return &reservation, nil
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"Microservices-based-E-commerce-System/auth"
 	"Microservices-based-E-commerce-System/catalog"
+	"Microservices-based-E-commerce-System/idempotency"
+	"context"
 	"log"
 	"time"
 
@@ -11,6 +14,9 @@ import (
 
 type Config struct {
 	DatabaseURL string `envconfig:"DATABASE_URL"`
+	JWTSecret   string `envconfig:"JWT_SECRET" required:"true"`
+
+	ReservationSweepInterval time.Duration `envconfig:"RESERVATION_SWEEP_INTERVAL" default:"1m"` // how often expired reservations are released
 }
 
 func main() {
@@ -29,7 +35,26 @@ func main() {
 		return
 	})
 	defer r.Close()
+	keys, err := idempotency.NewElasticStore(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
 	log.Println("Listening on port 8080...")
 	s := catalog.NewService(r)
-	log.Fatal(catalog.ListenGRPC(s, 8080))
+	go sweepReservations(s, cfg.ReservationSweepInterval)
+	log.Fatal(catalog.ListenGRPC(s, auth.NewTokenManager(cfg.JWTSecret), keys, 8080))
+}
+
+// Puts the stock of expired reservations (e.g. orders that were never completed) back, until the process exits.
+func sweepReservations(s catalog.Service, interval time.Duration) {
+	for range time.Tick(interval) {
+		n, err := s.ReleaseExpiredReservations(context.Background())
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("released %d expired reservations", n)
+		}
+	}
 }
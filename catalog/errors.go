@@ -0,0 +1,104 @@
+// domain errors of the catalog service and their translation to gRPC status codes.
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	elastic "gopkg.in/olivere/elastic.v5"
+)
+
+var (
+	ErrNotFound        = errors.New("product not found")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrInvalidProduct  = errors.New("product requires a name and a non-negative price in a valid currency")
+	ErrInvalidRate     = errors.New("invalid exchange rate")
+	ErrInvalidTaxClass = errors.New("tax class must be 1 to 32 lower-case letters, digits, '_' or '-'")
+)
+
+// converts an error returned by the service into a gRPC status error.
+// Elasticsearch being unreachable is reported as Unavailable so callers know to retry;
+// anything unexpected is logged and reported as Internal.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var stockErr *InsufficientStockError
+	if errors.As(err, &stockErr) {
+		return insufficientStockStatus(stockErr)
+	}
+	switch {
+	case errors.Is(err, ErrReservationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrNotFound), elastic.IsNotFound(err):
+		return status.Error(codes.NotFound, ErrNotFound.Error())
+	case errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrInvalidProduct), errors.Is(err, ErrInvalidRate),
+		errors.Is(err, ErrInvalidStock), errors.Is(err, ErrInvalidReservation), errors.Is(err, ErrInvalidTaxClass):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrInsufficientStock), errors.Is(err, ErrReservationExpired),
+		errors.Is(err, ErrReservationReleased), errors.Is(err, ErrReservationCommitted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrReservationConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), elastic.IsTimeout(err):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case elastic.IsConnErr(err), errors.Is(err, elastic.ErrNoClient):
+		log.Println(err)
+		return status.Error(codes.Unavailable, "catalog index unavailable")
+	}
+	log.Println(err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// FailedPrecondition with a PreconditionFailure detail per short product, so callers can tell which lines to fix.
+// Client.ReserveStock turns it back into an *InsufficientStockError.
+func insufficientStockStatus(e *InsufficientStockError) error {
+	pf := &errdetails.PreconditionFailure{}
+	for _, id := range e.ProductIDs {
+		pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        stockViolation,
+			Subject:     id,
+			Description: ErrInsufficientStock.Error(),
+		})
+	}
+	st, err := status.New(codes.FailedPrecondition, e.Error()).WithDetails(pf)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, e.Error())
+	}
+	return st.Err()
+}
+
+// PreconditionFailure violation type for a product without enough stock; the subject is the product id.
+const stockViolation = "STOCK"
+
+// the inverse of insufficientStockStatus; nil if err isn't such a status.
+func insufficientStockFromStatus(err error) *InsufficientStockError {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		return nil
+	}
+	e := &InsufficientStockError{}
+	for _, d := range st.Details() {
+		if pf, ok := d.(*errdetails.PreconditionFailure); ok {
+			for _, v := range pf.Violations {
+				if v.Type == stockViolation {
+					e.ProductIDs = append(e.ProductIDs, v.Subject)
+				}
+			}
+		}
+	}
+	if len(e.ProductIDs) == 0 {
+		return nil
+	}
+	return e
+}
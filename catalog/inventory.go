@@ -0,0 +1,243 @@
+// stock levels and reservations.
+//
+// A product's stock is the number of units that can still be sold. Placing an order first reserves its lines:
+// the units are taken out of stock and held under the reservation until it is committed (the order was created,
+// the units are gone for good) or released (the order failed or was abandoned, the units go back into stock).
+// Reservations that are neither committed nor released before they expire are released by ReleaseExpiredReservations.
+//
+// Every change to a product's stock is a single scripted update of its document, so concurrent reservations
+// can't take stock below zero, and holding or releasing the same reservation twice has no further effect.
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	ReservationHeld      = "held"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+)
+
+const (
+	DefaultReservationTTL = 15 * time.Minute
+	MaxReservationTTL     = time.Hour
+)
+
+var (
+	ErrInvalidStock         = errors.New("stock cannot be negative")
+	ErrInvalidReservation   = errors.New("a reservation needs at least one line, each with a product and a quantity of at least 1")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationExpired   = errors.New("reservation has expired")
+	ErrReservationReleased  = errors.New("reservation was already released")
+	ErrReservationCommitted = errors.New("reservation was already committed")
+	ErrReservationConflict  = errors.New("reservation was changed concurrently, try again")
+)
+
+// units of stock held for (or taken by) an order.
+type Reservation struct {
+	ID        string
+	Lines     []ReservationLine
+	Status    string
+	CreatedAt time.Time
+	ExpiresAt time.Time // a held reservation is released after this
+}
+
+type ReservationLine struct {
+	ProductID string
+	Quantity  uint32
+}
+
+// lists the products a reservation couldn't get enough units of. errors.Is(err, ErrInsufficientStock) holds for it.
+type InsufficientStockError struct {
+	ProductIDs []string
+}
+
+func (e *InsufficientStockError) Error() string {
+	return ErrInsufficientStock.Error() + ": " + strings.Join(e.ProductIDs, ", ")
+}
+
+func (e *InsufficientStockError) Is(target error) bool {
+	return target == ErrInsufficientStock
+}
+
+// adds delta units to a product's stock (negative to remove units), refusing to go below zero.
+func (s *catalogService) AdjustStock(ctx context.Context, productID string, delta int64) (*Product, error) {
+	if delta != 0 {
+		if err := s.repository.AdjustStock(ctx, productID, delta); err != nil {
+			return nil, err
+		}
+	}
+	return s.repository.GetProductByID(ctx, productID)
+}
+
+// takes the lines out of stock and holds them until the reservation is committed, released or expires.
+// Either every line is reserved or none is: when a product is short, what was already held is put back and
+// an *InsufficientStockError naming every short product is returned. Lines for the same product are merged.
+// ttl is clamped to MaxReservationTTL; zero means DefaultReservationTTL.
+func (s *catalogService) ReserveStock(ctx context.Context, lines []ReservationLine, ttl time.Duration) (*Reservation, error) {
+	lines, err := mergeReservationLines(lines)
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+	if ttl > MaxReservationTTL {
+		ttl = MaxReservationTTL
+	}
+	now := time.Now().UTC()
+	r := &Reservation{
+		ID:        ksuid.New().String(),
+		Lines:     lines,
+		Status:    ReservationHeld,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	// The reservation is written before any stock is held, so units held by a reservation that fails halfway
+	// (e.g. the service crashes) are still found and put back once it expires.
+	if err := s.repository.PutReservation(ctx, *r); err != nil {
+		return nil, err
+	}
+	short := []string{}
+	for _, l := range lines {
+		ok, err := s.repository.HoldStock(ctx, l.ProductID, r.ID, l.Quantity)
+		if err != nil {
+			s.abandonReservation(ctx, r.ID)
+			return nil, err
+		}
+		if !ok {
+			short = append(short, l.ProductID)
+		}
+	}
+	if len(short) > 0 {
+		s.abandonReservation(ctx, r.ID)
+		return nil, &InsufficientStockError{short}
+	}
+	return r, nil
+}
+
+// releases a reservation that couldn't be completed. The caller reports the original error; if this fails too,
+// the reservation expires and the sweeper puts its units back.
+func (s *catalogService) abandonReservation(ctx context.Context, id string) {
+	if _, err := s.ReleaseReservation(ctx, id); err != nil {
+		log.Printf("releasing reservation %s: %v", id, err)
+	}
+}
+
+// makes a reservation final: its units stay out of stock. Committing twice is harmless.
+// A reservation that has expired is released instead, and ErrReservationExpired returned.
+func (s *catalogService) CommitReservation(ctx context.Context, id string) (*Reservation, error) {
+	r, err := s.transitionReservation(ctx, id, ReservationCommitted)
+	if errors.Is(err, ErrReservationExpired) {
+		s.abandonReservation(ctx, id)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The units were taken out of stock when they were held; only the bookkeeping on the products is left.
+	for _, l := range r.Lines {
+		if err := s.repository.ReleaseHeldStock(ctx, l.ProductID, r.ID, false); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// puts the units of a held reservation back into stock. Releasing twice is harmless; a committed
+// reservation can't be released.
+func (s *catalogService) ReleaseReservation(ctx context.Context, id string) (*Reservation, error) {
+	r, err := s.transitionReservation(ctx, id, ReservationReleased)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range r.Lines {
+		if err := s.repository.ReleaseHeldStock(ctx, l.ProductID, r.ID, true); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// releases held reservations past their expiry and returns how many were released.
+// Meant to be run periodically; one run handles at most 100 reservations.
+func (s *catalogService) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	expired, err := s.repository.ListExpiredReservations(ctx, time.Now().UTC(), 100)
+	if err != nil {
+		return 0, err
+	}
+	released := 0
+	for _, r := range expired {
+		if _, err := s.ReleaseReservation(ctx, r.ID); err != nil {
+			log.Printf("releasing expired reservation %s: %v", r.ID, err)
+			continue
+		}
+		released++
+	}
+	return released, nil
+}
+
+// moves a reservation from held to status. The status is changed before any stock is touched, guarded by the
+// document version, so a reservation can't be both committed and released. A reservation already in status is
+// returned as is, letting a retry finish the stock updates of an attempt that failed halfway.
+func (s *catalogService) transitionReservation(ctx context.Context, id string, status string) (*Reservation, error) {
+	for attempt := 0; attempt < 3; attempt++ {
+		r, version, err := s.repository.GetReservation(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case r.Status == status:
+			return r, nil
+		case r.Status == ReservationReleased:
+			return nil, ErrReservationReleased
+		case r.Status == ReservationCommitted:
+			return nil, ErrReservationCommitted
+		case status == ReservationCommitted && !time.Now().Before(r.ExpiresAt):
+			return nil, ErrReservationExpired
+		}
+		err = s.repository.UpdateReservationStatus(ctx, id, status, version)
+		if errors.Is(err, ErrReservationConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.Status = status
+		return r, nil
+	}
+	return nil, ErrReservationConflict
+}
+
+func mergeReservationLines(lines []ReservationLine) ([]ReservationLine, error) {
+	if len(lines) == 0 {
+		return nil, ErrInvalidReservation
+	}
+	merged := []ReservationLine{}
+	position := map[string]int{}
+	for _, l := range lines {
+		if l.ProductID == "" || l.Quantity == 0 {
+			return nil, ErrInvalidReservation
+		}
+		if i, ok := position[l.ProductID]; ok {
+			if merged[i].Quantity+l.Quantity < l.Quantity {
+				return nil, fmt.Errorf("%w: quantity too large", ErrInvalidReservation)
+			}
+			merged[i].Quantity += l.Quantity
+			continue
+		}
+		position[l.ProductID] = len(merged)
+		merged = append(merged, l)
+	}
+	return merged, nil
+}
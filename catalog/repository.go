@@ -3,15 +3,18 @@ package catalog
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
 
 	elastic "gopkg.in/olivere/elastic.v5"
 )
 
-var ErrNotFound = errors.New("Entity not found")
-
 // defines the interface that Elasticsearch must implement
 type Repository interface {
 	Close()
@@ -20,6 +23,16 @@ type Repository interface {
 	ListProducts(ctx context.Context, skip uint64, take uint64) ([]Product, error)
 	ListProductWithIDs(ctx context.Context, ids []string) ([]Product, error)
 	SearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]Product, error)
+	ListProductsAfter(ctx context.Context, query string, after string, take uint64) (*ProductPage, error)
+	PutExchangeRate(ctx context.Context, r ExchangeRate) error
+	ListExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	AdjustStock(ctx context.Context, productID string, delta int64) error
+	HoldStock(ctx context.Context, productID, reservationID string, quantity uint32) (bool, error)
+	ReleaseHeldStock(ctx context.Context, productID, reservationID string, restock bool) error
+	PutReservation(ctx context.Context, r Reservation) error
+	GetReservation(ctx context.Context, id string) (*Reservation, int64, error)
+	UpdateReservationStatus(ctx context.Context, id string, status string, version int64) error
+	ListExpiredReservations(ctx context.Context, now time.Time, take int) ([]Reservation, error)
 }
 
 // implementation of the Repository interface using Elasticsearch
@@ -28,10 +41,61 @@ type elasticRepository struct {
 }
 
 // represents the shape of the document in Elasticsearch
+// The price is kept as integer minor units plus currency; "price" is only read from documents
+// written before that, when it was stored as a float.
+// "stock" is the number of units that can still be sold (missing on older documents, read as 0) and
+// "held" lists the units taken out of it by reservations that are not committed or released yet.
 type productDocument struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	PriceAmount   int64          `json:"price_amount"`
+	PriceCurrency string         `json:"price_currency"`
+	LegacyPrice   float64        `json:"price,omitempty"`
+	Stock         int64          `json:"stock"`
+	Held          []heldDocument `json:"held"`
+	TaxClass      string         `json:"tax_class,omitempty"`
+	Weight        uint32         `json:"weight"`     // grams
+	Dimensions    Dimensions     `json:"dimensions"` // millimetres
+}
+
+type heldDocument struct {
+	ReservationID string `json:"id"`
+	Quantity      uint32 `json:"quantity"`
+}
+
+func newProductDocument(p Product) productDocument {
+	return productDocument{
+		Name:          p.Name,
+		Description:   p.Description,
+		PriceAmount:   p.Price.Amount,
+		PriceCurrency: p.Price.Currency,
+		Stock:         p.Stock,
+		Held:          []heldDocument{},
+		TaxClass:      p.TaxClass,
+		Weight:        p.Weight,
+		Dimensions:    p.Dimensions,
+	}
+}
+
+func (d productDocument) product(id string) Product {
+	price := money.New(d.PriceAmount, d.PriceCurrency)
+	if d.PriceCurrency == "" {
+		price = money.FromFloat(d.LegacyPrice, money.DefaultCurrency)
+	}
+	taxClass := d.TaxClass
+	if taxClass == "" {
+		taxClass = DefaultTaxClass
+	}
+	return Product{
+		ID:          id,
+		Name:        d.Name,
+		Description: d.Description,
+		Price:       price,
+		Stock:       d.Stock,
+		TaxClass:    taxClass,
+		Weight:      d.Weight,
+		Dimensions:  d.Dimensions,
+	}
 }
 
 // creates a new repository backed by Elasticsearch
@@ -57,22 +121,30 @@ func (r *elasticRepository) PutProduct(ctx context.Context, p Product) error {
 		Index("catalog").
 		Type("product").
 		Id(p.ID).
-		BodyJson(productDocument{
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-		}).
+		BodyJson(newProductDocument(p)).
 		Do(ctx)
 	return err
 }
 
 // fetches a single product by ID from the index.
 func (r *elasticRepository) GetProductByID(ctx context.Context, id string) (*Product, error) {
+	d, err := r.getProductDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	product := d.product(id)
+	return &product, nil
+}
+
+func (r *elasticRepository) getProductDocument(ctx context.Context, id string) (*productDocument, error) {
 	res, err := r.client.Get().
 		Index("catalog").
 		Type("product").
 		Id(id).
 		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -83,12 +155,7 @@ func (r *elasticRepository) GetProductByID(ctx context.Context, id string) (*Pro
 	if err := json.Unmarshal(*res.Source, &p); err != nil {
 		return nil, err
 	}
-	return &Product{
-		ID:          id,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-	}, err
+	return &p, nil
 }
 
 // returns all products paginated using offset and limit.
@@ -110,12 +177,7 @@ func (r *elasticRepository) ListProducts(ctx context.Context, skip uint64, take
 	for _, hit := range res.Hits.Hits {
 		p := productDocument{}
 		if err := json.Unmarshal(*hit.Source, &p); err == nil {
-			products = append(products, Product{
-				ID:          hit.Id,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-			})
+			products = append(products, p.product(hit.Id))
 		}
 	}
 	return products, err
@@ -146,14 +208,12 @@ func (r *elasticRepository) ListProductWithIDs(ctx context.Context, ids []string
 	}
 	products := []Product{}
 	for _, doc := range res.Docs {
+		if !doc.Found { // unknown ids are skipped, callers compare the result against what they asked for
+			continue
+		}
 		p := productDocument{}
 		if err := json.Unmarshal(*doc.Source, &p); err == nil {
-			products = append(products, Product{
-				ID:          doc.Id,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-			})
+			products = append(products, p.product(doc.Id))
 		}
 	}
 	return products, err
@@ -176,13 +236,372 @@ func (r *elasticRepository) SearchProducts(ctx context.Context, query string, sk
 	for _, hit := range res.Hits.Hits {
 		p := productDocument{}
 		if err := json.Unmarshal(*hit.Source, &p); err == nil {
-			products = append(products, Product{
-				ID:          hit.Id,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-			})
+			products = append(products, p.product(hit.Id))
 		}
 	}
 	return products, err
 }
+
+// Cursor-based listing/search using search_after instead of From/Size.
+// Results need a total, stable order: by _uid (type#id) when listing, and by relevance then _uid when searching.
+// The cursor is the sort values of a hit, JSON + base64 encoded; passing it back as search_after
+// continues right after that hit. An empty query lists all products.
+func (r *elasticRepository) ListProductsAfter(ctx context.Context, query string, after string, take uint64) (*ProductPage, error) {
+	search := r.client.Search().
+		Index("catalog").
+		Type("product").
+		Size(int(take) + 1) // one extra hit tells us whether there is a next page
+	if query == "" {
+		search = search.Query(elastic.NewMatchAllQuery()).Sort("_uid", true)
+	} else {
+		search = search.Query(elastic.NewMultiMatchQuery(query, "name", "description")).
+			Sort("_score", false).
+			Sort("_uid", true)
+	}
+	if after != "" {
+		sortValues, err := decodeCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		search = search.SearchAfter(sortValues...)
+	}
+	res, err := search.Do(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	page := &ProductPage{
+		Edges:       []ProductEdge{},
+		HasNextPage: uint64(len(res.Hits.Hits)) > take,
+		TotalCount:  uint64(res.TotalHits()),
+	}
+	for i, hit := range res.Hits.Hits {
+		if uint64(i) == take {
+			break
+		}
+		p := productDocument{}
+		if err := json.Unmarshal(*hit.Source, &p); err != nil {
+			return nil, err
+		}
+		cursor, err := encodeCursor(hit.Sort)
+		if err != nil {
+			return nil, err
+		}
+		page.Edges = append(page.Edges, ProductEdge{
+			Cursor:  cursor,
+			Product: p.product(hit.Id),
+		})
+	}
+	return page, nil
+}
+
+// represents an exchange rate document in the `exchange_rates` index; the id is "<from>_<to>".
+type exchangeRateDocument struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Value     int64     `json:"value"` // rate × 10^money.RateDecimals
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// inserts or replaces the rate between two currencies.
+func (r *elasticRepository) PutExchangeRate(ctx context.Context, rate ExchangeRate) error {
+	_, err := r.client.Index().
+		Index("exchange_rates").
+		Type("rate").
+		Id(rate.From + "_" + rate.To).
+		BodyJson(exchangeRateDocument{
+			From:      rate.From,
+			To:        rate.To,
+			Value:     rate.Value,
+			UpdatedAt: rate.UpdatedAt,
+		}).
+		Refresh("true"). // orders placed right after a rate change must see it
+		Do(ctx)
+	return err
+}
+
+// returns every exchange rate. The table is small (one row per currency pair), so a single page is enough;
+// a missing index just means no rates were set yet.
+func (r *elasticRepository) ListExchangeRates(ctx context.Context) ([]ExchangeRate, error) {
+	res, err := r.client.Search().
+		Index("exchange_rates").
+		Type("rate").
+		Query(elastic.NewMatchAllQuery()).
+		Size(1000).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return []ExchangeRate{}, nil
+	}
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	rates := []ExchangeRate{}
+	for _, hit := range res.Hits.Hits {
+		d := exchangeRateDocument{}
+		if err := json.Unmarshal(*hit.Source, &d); err != nil {
+			return nil, err
+		}
+		rates = append(rates, ExchangeRate{
+			Rate:      money.Rate{From: d.From, To: d.To, Value: d.Value},
+			UpdatedAt: d.UpdatedAt,
+		})
+	}
+	return rates, nil
+}
+
+// Painless scripts changing a product's stock. Elasticsearch applies each one atomically to the current
+// version of the document (retrying on conflicts), and setting ctx.op to "noop" leaves the document untouched.
+const (
+	// adds params.delta to the stock unless that would take it below zero.
+	adjustStockScript = `
+long stock = ctx._source.stock == null ? 0L : ((Number) ctx._source.stock).longValue();
+long next = stock + params.delta;
+if (next < 0) {
+	ctx.op = 'noop';
+} else {
+	ctx._source.stock = next;
+}`
+
+	// moves params.quantity units from stock into the held list under params.id, unless there aren't
+	// enough units or the reservation already holds units of this product.
+	holdStockScript = `
+long stock = ctx._source.stock == null ? 0L : ((Number) ctx._source.stock).longValue();
+boolean held = false;
+if (ctx._source.held != null) {
+	for (def h : ctx._source.held) {
+		if (h.id == params.id) {
+			held = true;
+		}
+	}
+}
+if (held || stock < params.quantity) {
+	ctx.op = 'noop';
+} else {
+	if (ctx._source.held == null) {
+		ctx._source.held = [];
+	}
+	ctx._source.stock = stock - params.quantity;
+	ctx._source.held.add(['id': params.id, 'quantity': params.quantity]);
+}`
+
+	// drops the units held under params.id, putting them back into stock if params.restock is set.
+	releaseHeldStockScript = `
+boolean found = false;
+if (ctx._source.held != null) {
+	for (int i = ctx._source.held.size() - 1; i >= 0; i--) {
+		def h = ctx._source.held.get(i);
+		if (h.id == params.id) {
+			if (params.restock) {
+				long stock = ctx._source.stock == null ? 0L : ((Number) ctx._source.stock).longValue();
+				ctx._source.stock = stock + ((Number) h.quantity).longValue();
+			}
+			ctx._source.held.remove(i);
+			found = true;
+		}
+	}
+}
+if (!found) {
+	ctx.op = 'noop';
+}`
+)
+
+// retries of a scripted update when the product document was changed between reading and writing it.
+const stockRetries = 10
+
+func (r *elasticRepository) updateStock(ctx context.Context, productID string, script string, params map[string]interface{}) (*elastic.UpdateResponse, error) {
+	res, err := r.client.Update().
+		Index("catalog").
+		Type("product").
+		Id(productID).
+		Script(elastic.NewScript(script).Lang("painless").Params(params)).
+		RetryOnConflict(stockRetries).
+		Refresh("true"). // stock read right after a change (e.g. by the next order) must see it
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil, ErrNotFound
+	}
+	return res, err
+}
+
+// a change that would take the stock below zero is refused with ErrInsufficientStock.
+func (r *elasticRepository) AdjustStock(ctx context.Context, productID string, delta int64) error {
+	res, err := r.updateStock(ctx, productID, adjustStockScript, map[string]interface{}{"delta": delta})
+	if err != nil {
+		return err
+	}
+	if res.Result == "noop" {
+		return fmt.Errorf("%w: removing %d units would take the stock below zero", ErrInsufficientStock, -delta)
+	}
+	return nil
+}
+
+// reports false when the product doesn't have quantity units in stock. Holding units the reservation
+// already holds is a no-op that reports true.
+func (r *elasticRepository) HoldStock(ctx context.Context, productID, reservationID string, quantity uint32) (bool, error) {
+	res, err := r.updateStock(ctx, productID, holdStockScript, map[string]interface{}{"id": reservationID, "quantity": quantity})
+	if err != nil {
+		return false, err
+	}
+	if res.Result != "noop" {
+		return true, nil
+	}
+	// A noop either means not enough stock or that this reservation holds units already (a retry).
+	p, err := r.getProductDocument(ctx, productID)
+	if err != nil {
+		return false, err
+	}
+	for _, h := range p.Held {
+		if h.ReservationID == reservationID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// a product that no longer exists has nothing to put back.
+func (r *elasticRepository) ReleaseHeldStock(ctx context.Context, productID, reservationID string, restock bool) error {
+	_, err := r.updateStock(ctx, productID, releaseHeldStockScript, map[string]interface{}{"id": reservationID, "restock": restock})
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// represents a reservation document in the `reservations` index.
+type reservationDocument struct {
+	Lines     []reservationLineDocument `json:"lines"`
+	Status    string                    `json:"status"`
+	CreatedAt time.Time                 `json:"created_at"`
+	ExpiresAt time.Time                 `json:"expires_at"`
+}
+
+type reservationLineDocument struct {
+	ProductID string `json:"product_id"`
+	Quantity  uint32 `json:"quantity"`
+}
+
+func (d reservationDocument) reservation(id string) Reservation {
+	r := Reservation{
+		ID:        id,
+		Lines:     []ReservationLine{},
+		Status:    d.Status,
+		CreatedAt: d.CreatedAt,
+		ExpiresAt: d.ExpiresAt,
+	}
+	for _, l := range d.Lines {
+		r.Lines = append(r.Lines, ReservationLine{l.ProductID, l.Quantity})
+	}
+	return r
+}
+
+func (r *elasticRepository) PutReservation(ctx context.Context, res Reservation) error {
+	d := reservationDocument{
+		Lines:     []reservationLineDocument{},
+		Status:    res.Status,
+		CreatedAt: res.CreatedAt,
+		ExpiresAt: res.ExpiresAt,
+	}
+	for _, l := range res.Lines {
+		d.Lines = append(d.Lines, reservationLineDocument{l.ProductID, l.Quantity})
+	}
+	_, err := r.client.Index().
+		Index("reservations").
+		Type("reservation").
+		Id(res.ID).
+		OpType("create").
+		BodyJson(d).
+		Do(ctx)
+	return err
+}
+
+// returns the reservation together with its document version, for UpdateReservationStatus.
+func (r *elasticRepository) GetReservation(ctx context.Context, id string) (*Reservation, int64, error) {
+	res, err := r.client.Get().
+		Index("reservations").
+		Type("reservation").
+		Id(id).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil, 0, ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if !res.Found || res.Version == nil {
+		return nil, 0, ErrReservationNotFound
+	}
+	d := reservationDocument{}
+	if err := json.Unmarshal(*res.Source, &d); err != nil {
+		return nil, 0, err
+	}
+	reservation := d.reservation(id)
+	return &reservation, *res.Version, nil
+}
+
+// changes the status only if the document is still at version, else returns ErrReservationConflict.
+func (r *elasticRepository) UpdateReservationStatus(ctx context.Context, id string, status string, version int64) error {
+	_, err := r.client.Update().
+		Index("reservations").
+		Type("reservation").
+		Id(id).
+		Doc(map[string]interface{}{"status": status}).
+		Version(version).
+		Do(ctx)
+	if elastic.IsConflict(err) {
+		return ErrReservationConflict
+	}
+	if elastic.IsNotFound(err) {
+		return ErrReservationNotFound
+	}
+	return err
+}
+
+// held reservations that expired before now, oldest first. A missing index means no reservation was made yet.
+func (r *elasticRepository) ListExpiredReservations(ctx context.Context, now time.Time, take int) ([]Reservation, error) {
+	res, err := r.client.Search().
+		Index("reservations").
+		Type("reservation").
+		Query(elastic.NewBoolQuery().Filter(
+			elastic.NewTermQuery("status", ReservationHeld),
+			elastic.NewRangeQuery("expires_at").Lt(now),
+		)).
+		Sort("expires_at", true).
+		Size(take).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return []Reservation{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	reservations := []Reservation{}
+	for _, hit := range res.Hits.Hits {
+		d := reservationDocument{}
+		if err := json.Unmarshal(*hit.Source, &d); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, d.reservation(hit.Id))
+	}
+	return reservations, nil
+}
+
+func encodeCursor(sortValues []interface{}) (string, error) {
+	b, err := json.Marshal(sortValues)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(cursor string) ([]interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	sortValues := []interface{}{}
+	if err := json.Unmarshal(b, &sortValues); err != nil || len(sortValues) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return sortValues, nil
+}
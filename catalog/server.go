@@ -3,11 +3,14 @@
 package catalog
 
 import (
+	"Microservices-based-E-commerce-System/auth"
 	"Microservices-based-E-commerce-System/catalog/pb"
+	"Microservices-based-E-commerce-System/idempotency"
+	"Microservices-based-E-commerce-System/money"
 	"context"
 	"fmt"
-	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -15,49 +18,60 @@ import (
 
 type grpcServer struct {
 	pb.UnimplementedCatalogServiceServer
-	service Service
+	service     Service
+	idempotency idempotency.Store // remembers PostProduct results by idempotency key
 }
 
-func ListenGRPC(s Service, port int) error {
+// Only admins may add products, change stock and set exchange rates; browsing the catalog is public.
+// Reservations are made by the order service on its own behalf, never directly by customers.
+var policy = auth.Policy{
+	pb.CatalogService_PostProduct_FullMethodName:        auth.AdminOnly,
+	pb.CatalogService_SetExchangeRate_FullMethodName:    auth.AdminOnly,
+	pb.CatalogService_AdjustStock_FullMethodName:        auth.AdminOnly,
+	pb.CatalogService_ReserveStock_FullMethodName:       auth.ServiceOnly,
+	pb.CatalogService_CommitReservation_FullMethodName:  auth.ServiceOnly,
+	pb.CatalogService_ReleaseReservation_FullMethodName: auth.ServiceOnly,
+}
+
+func ListenGRPC(s Service, tokens *auth.TokenManager, keys idempotency.Store, port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return err
 	}
-	serv := grpc.NewServer()
+	serv := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(tokens, policy)))
 	pb.RegisterCatalogServiceServer(serv, &grpcServer{
 		UnimplementedCatalogServiceServer: pb.UnimplementedCatalogServiceServer{},
 		service:                           s,
+		idempotency:                       keys,
 	})
 	reflection.Register(serv)
 	return serv.Serve(lis)
 }
 
+// a retry carrying the same idempotency key gets the product created by the first attempt.
 func (s *grpcServer) PostProduct(ctx context.Context, r *pb.PostProductRequest) (*pb.PostProductResponse, error) {
-	p, err := s.service.PostProduct(ctx, r.Name, r.Description, r.Price)
+	res := &pb.PostProductResponse{}
+	err := idempotency.Do(ctx, s.idempotency, pb.CatalogService_PostProduct_FullMethodName, r.IdempotencyKey, r, res, func() error {
+		p, err := s.service.PostProduct(ctx, r.Name, r.Description, moneyFromProto(r.Price), r.Stock, r.TaxClass, r.Weight,
+			dimensionsFromProto(r.Dimensions))
+		if err != nil {
+			return err
+		}
+		res.Product = productToProto(*p)
+		return nil
+	})
 	if err != nil {
-		log.Println(err)
-		return nil, err
+		return nil, toStatus(err)
 	}
-	return &pb.PostProductResponse{Product: &pb.Product{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-	}}, nil
+	return res, nil
 }
 
 func (s *grpcServer) GetProduct(ctx context.Context, r *pb.GetProductRequest) (*pb.GetProductResponse, error) {
 	p, err := s.service.GetProduct(ctx, r.Id)
 	if err != nil {
-		log.Println(err)
-		return nil, err
+		return nil, toStatus(err)
 	}
-	return &pb.GetProductResponse{Product: &pb.Product{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-	}}, nil
+	return &pb.GetProductResponse{Product: productToProto(*p)}, nil
 }
 
 // Route to different service methods based on input
@@ -77,20 +91,188 @@ func (s *grpcServer) GetProducts(ctx context.Context, r *pb.GetProductsRequest)
 	}
 
 	if err != nil {
-		log.Println(err)
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	products := []*pb.Product{}
 
 	for _, p := range res {
-		products = append(products, &pb.Product{
-			Id:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-		})
+		products = append(products, productToProto(p))
 	}
 
 	return &pb.GetProductsResponse{Products: products}, nil
 }
+
+func (s *grpcServer) ListProducts(ctx context.Context, r *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	page, err := s.service.ListProducts(ctx, r.Query, r.After, r.First)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	edges := []*pb.ListProductsResponse_Edge{}
+	for _, e := range page.Edges {
+		edges = append(edges, &pb.ListProductsResponse_Edge{
+			Cursor:  e.Cursor,
+			Product: productToProto(e.Product),
+		})
+	}
+	return &pb.ListProductsResponse{
+		Edges:       edges,
+		HasNextPage: page.HasNextPage,
+		TotalCount:  page.TotalCount,
+	}, nil
+}
+
+func (s *grpcServer) SetExchangeRate(ctx context.Context, r *pb.SetExchangeRateRequest) (*pb.SetExchangeRateResponse, error) {
+	rate, err := s.service.SetExchangeRate(ctx, r.From, r.To, r.Rate)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetExchangeRateResponse{Rate: exchangeRateToProto(*rate)}, nil
+}
+
+func (s *grpcServer) ListExchangeRates(ctx context.Context, r *pb.ListExchangeRatesRequest) (*pb.ListExchangeRatesResponse, error) {
+	rates, err := s.service.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.ListExchangeRatesResponse{Rates: []*pb.ExchangeRate{}}
+	for _, rate := range rates {
+		res.Rates = append(res.Rates, exchangeRateToProto(rate))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) AdjustStock(ctx context.Context, r *pb.AdjustStockRequest) (*pb.AdjustStockResponse, error) {
+	p, err := s.service.AdjustStock(ctx, r.ProductId, r.Delta)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.AdjustStockResponse{Product: productToProto(*p)}, nil
+}
+
+func (s *grpcServer) ReserveStock(ctx context.Context, r *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	lines := []ReservationLine{}
+	for _, l := range r.Lines {
+		lines = append(lines, ReservationLine{l.ProductId, l.Quantity})
+	}
+	reservation, err := s.service.ReserveStock(ctx, lines, time.Duration(r.TtlSeconds)*time.Second)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ReserveStockResponse{Reservation: reservationToProto(*reservation)}, nil
+}
+
+func (s *grpcServer) CommitReservation(ctx context.Context, r *pb.CommitReservationRequest) (*pb.CommitReservationResponse, error) {
+	reservation, err := s.service.CommitReservation(ctx, r.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CommitReservationResponse{Reservation: reservationToProto(*reservation)}, nil
+}
+
+func (s *grpcServer) ReleaseReservation(ctx context.Context, r *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	reservation, err := s.service.ReleaseReservation(ctx, r.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ReleaseReservationResponse{Reservation: reservationToProto(*reservation)}, nil
+}
+
+func productToProto(p Product) *pb.Product {
+	return &pb.Product{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       moneyToProto(p.Price),
+		Stock:       p.Stock,
+		TaxClass:    p.TaxClass,
+		Weight:      p.Weight,
+		Dimensions:  dimensionsToProto(p.Dimensions),
+	}
+}
+
+func productFromProto(p *pb.Product) Product {
+	return Product{
+		ID:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       moneyFromProto(p.Price),
+		Stock:       p.Stock,
+		TaxClass:    p.TaxClass,
+		Weight:      p.Weight,
+		Dimensions:  dimensionsFromProto(p.Dimensions),
+	}
+}
+
+func dimensionsToProto(d Dimensions) *pb.Dimensions {
+	return &pb.Dimensions{Length: d.Length, Width: d.Width, Height: d.Height}
+}
+
+// nil means unknown.
+func dimensionsFromProto(d *pb.Dimensions) Dimensions {
+	if d == nil {
+		return Dimensions{}
+	}
+	return Dimensions{Length: d.Length, Width: d.Width, Height: d.Height}
+}
+
+func reservationToProto(r Reservation) *pb.Reservation {
+	res := &pb.Reservation{
+		Id:     r.ID,
+		Lines:  []*pb.Reservation_Line{},
+		Status: r.Status,
+	}
+	for _, l := range r.Lines {
+		res.Lines = append(res.Lines, &pb.Reservation_Line{ProductId: l.ProductID, Quantity: l.Quantity})
+	}
+	res.CreatedAt, _ = r.CreatedAt.MarshalBinary()
+	res.ExpiresAt, _ = r.ExpiresAt.MarshalBinary()
+	return res
+}
+
+func reservationFromProto(r *pb.Reservation) Reservation {
+	res := Reservation{
+		ID:     r.Id,
+		Lines:  []ReservationLine{},
+		Status: r.Status,
+	}
+	for _, l := range r.Lines {
+		res.Lines = append(res.Lines, ReservationLine{l.ProductId, l.Quantity})
+	}
+	res.CreatedAt.UnmarshalBinary(r.CreatedAt)
+	res.ExpiresAt.UnmarshalBinary(r.ExpiresAt)
+	return res
+}
+
+func exchangeRateToProto(r ExchangeRate) *pb.ExchangeRate {
+	e := &pb.ExchangeRate{
+		From: r.From,
+		To:   r.To,
+		Rate: r.Decimal(),
+	}
+	e.UpdatedAt, _ = r.UpdatedAt.MarshalBinary()
+	return e
+}
+
+// Rates come from the catalog's own table, so a malformed one is a bug rather than a client error.
+func exchangeRateFromProto(r *pb.ExchangeRate) (ExchangeRate, error) {
+	rate, err := money.ParseRate(r.From, r.To, r.Rate)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	e := ExchangeRate{Rate: rate}
+	e.UpdatedAt.UnmarshalBinary(r.UpdatedAt)
+	return e, nil
+}
+
+func moneyToProto(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+// A missing price reads as zero in the default currency.
+func moneyFromProto(m *pb.Money) money.Money {
+	if m == nil {
+		return money.Zero(money.DefaultCurrency)
+	}
+	return money.New(m.Amount, m.Currency)
+}
@@ -3,23 +3,69 @@ package catalog
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
 
 	"github.com/segmentio/ksuid"
 )
 
 type Service interface {
-	PostProduct(ctx context.Context, name, description string, price float64) (*Product, error)
+	PostProduct(ctx context.Context, name, description string, price money.Money, stock int64, taxClass string, weight uint32, dimensions Dimensions) (*Product, error)
 	GetProduct(ctx context.Context, id string) (*Product, error)
 	GetProducts(ctx context.Context, skip uint64, take uint64) ([]Product, error)
 	GetProductsByIDs(ctx context.Context, ids []string) ([]Product, error)
 	SearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]Product, error)
+	ListProducts(ctx context.Context, query string, after string, first uint64) (*ProductPage, error)
+	SetExchangeRate(ctx context.Context, from, to, rate string) (*ExchangeRate, error)
+	ListExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	AdjustStock(ctx context.Context, productID string, delta int64) (*Product, error)
+	ReserveStock(ctx context.Context, lines []ReservationLine, ttl time.Duration) (*Reservation, error)
+	CommitReservation(ctx context.Context, id string) (*Reservation, error)
+	ReleaseReservation(ctx context.Context, id string) (*Reservation, error)
+	ReleaseExpiredReservations(ctx context.Context) (int, error)
 }
 
 type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Stock       int64       `json:"stock"`      // units that can still be sold; units held by reservations aren't counted
+	TaxClass    string      `json:"taxClass"`   // picks the tax rules that apply to the product, e.g. "standard" or "reduced"
+	Weight      uint32      `json:"weight"`     // grams per unit, packaging included; 0 if unknown
+	Dimensions  Dimensions  `json:"dimensions"` // of one packed unit
+}
+
+// tax class of products created without one, and of products created before tax classes existed.
+const DefaultTaxClass = "standard"
+
+// size of a packed unit in millimetres; all zero if unknown.
+type Dimensions struct {
+	Length uint32 `json:"length"`
+	Width  uint32 `json:"width"`
+	Height uint32 `json:"height"`
+}
+
+// an entry of the exchange-rate table used to price orders in another currency than the product's.
+type ExchangeRate struct {
+	money.Rate
+	UpdatedAt time.Time
+}
+
+// one page of products returned by ListProducts.
+type ProductPage struct {
+	Edges       []ProductEdge
+	HasNextPage bool
+	TotalCount  uint64
+}
+
+// a product together with the opaque cursor pointing at it.
+type ProductEdge struct {
+	Cursor  string
+	Product Product
 }
 
 // implementation of the Service interface, using a Repository under the hood for data access.
@@ -32,12 +78,32 @@ func NewService(r Repository) Service {
 	return &catalogService{r}
 }
 
-func (s *catalogService) PostProduct(ctx context.Context, name, description string, price float64) (*Product, error) {
+// an empty taxClass means DefaultTaxClass. weight (grams) and dimensions (millimetres) are used to price shipping.
+func (s *catalogService) PostProduct(ctx context.Context, name, description string, price money.Money, stock int64, taxClass string, weight uint32, dimensions Dimensions) (*Product, error) {
+	currency, err := money.NormalizeCurrency(price.Currency)
+	if strings.TrimSpace(name) == "" || price.IsNegative() || err != nil {
+		return nil, ErrInvalidProduct
+	}
+	if stock < 0 {
+		return nil, ErrInvalidStock
+	}
+	taxClass = strings.ToLower(strings.TrimSpace(taxClass))
+	if taxClass == "" {
+		taxClass = DefaultTaxClass
+	}
+	if !validTaxClass(taxClass) {
+		return nil, ErrInvalidTaxClass
+	}
+	price.Currency = currency
 	p := &Product{
 		ID:          ksuid.New().String(),
 		Name:        name,
 		Description: description,
 		Price:       price,
+		Stock:       stock,
+		TaxClass:    taxClass,
+		Weight:      weight,
+		Dimensions:  dimensions,
 	}
 	if err := s.repository.PutProduct(ctx, *p); err != nil {
 		return nil, err
@@ -45,6 +111,19 @@ func (s *catalogService) PostProduct(ctx context.Context, name, description stri
 	return p, nil
 }
 
+// tax classes are short lower-case names: letters, digits, '_' and '-'.
+func validTaxClass(c string) bool {
+	if len(c) == 0 || len(c) > 32 {
+		return false
+	}
+	for _, r := range c {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *catalogService) GetProduct(ctx context.Context, id string) (*Product, error) {
 	return s.repository.GetProductByID(ctx, id)
 }
@@ -66,3 +145,29 @@ func (s *catalogService) SearchProducts(ctx context.Context, query string, skip
 	}
 	return s.repository.SearchProducts(ctx, query, skip, take)
 }
+
+// cursor-based alternative to GetProducts/SearchProducts.
+func (s *catalogService) ListProducts(ctx context.Context, query string, after string, first uint64) (*ProductPage, error) {
+	if first > 100 || first == 0 {
+		first = 100
+	}
+	return s.repository.ListProductsAfter(ctx, query, after, first)
+}
+
+// sets (or replaces) the rate at which one currency converts into another.
+// Rates are directional: EUR -> USD and USD -> EUR are separate entries.
+func (s *catalogService) SetExchangeRate(ctx context.Context, from, to, rate string) (*ExchangeRate, error) {
+	r, err := money.ParseRate(from, to, rate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRate, err)
+	}
+	e := &ExchangeRate{r, time.Now().UTC()}
+	if err := s.repository.PutExchangeRate(ctx, *e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *catalogService) ListExchangeRates(ctx context.Context) ([]ExchangeRate, error) {
+	return s.repository.ListExchangeRates(ctx)
+}
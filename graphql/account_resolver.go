@@ -1,8 +1,13 @@
 package main
 
 import (
+	"Microservices-based-E-commerce-System/account"
+	"Microservices-based-E-commerce-System/money"
+	"Microservices-based-E-commerce-System/order"
 	"context"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -10,11 +15,20 @@ type accountResolver struct {
 	server *Server
 }
 
-func (r *accountResolver) Orders(ctx context.Context, obj *Account) ([]*Order, error) {
+func (r *accountResolver) Orders(ctx context.Context, obj *Account, pagination *PaginationInput, filter *OrderFilterInput) ([]*Order, error) {
+	// Order history is private: only the account owner or an admin may see it.
+	if err := authorizeAccount(ctx, obj.ID); err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	orderList, err := r.server.orderClient.GetOrdersForAccount(ctx, obj.ID)
+	skip, take := uint64(0), uint64(0)
+	if pagination != nil {
+		skip, take = pagination.bounds()
+	}
+	orderList, err := r.server.orderClient.GetOrdersForAccount(ctx, obj.ID, filter.toOrderFilter(), skip, take)
 	if err != nil {
 		log.Println(err)
 		return nil, err
@@ -22,22 +36,254 @@ func (r *accountResolver) Orders(ctx context.Context, obj *Account) ([]*Order, e
 
 	var orders []*Order
 	for _, o := range orderList {
-		var products []*OrderedProduct
-		for _, p := range o.Products {
-			products = append(products, &OrderedProduct{
-				ID:          p.ID,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-				Quantity:    int(p.Quantity),
+		orders = append(orders, toOrder(o))
+	}
+	return orders, nil
+}
+
+func (r *accountResolver) OrdersConnection(ctx context.Context, obj *Account, first *int, after *string, filter *OrderFilterInput) (*OrderConnection, error) {
+	if err := authorizeAccount(ctx, obj.ID); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	page, err := r.server.orderClient.ListOrdersForAccount(ctx, obj.ID, filter.toOrderFilter(), deref(after), firstOrDefault(first))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	conn := &OrderConnection{
+		Edges:      []*OrderEdge{},
+		PageInfo:   &PageInfo{HasNextPage: page.HasNextPage},
+		TotalCount: int(page.TotalCount),
+	}
+	for _, e := range page.Edges {
+		conn.Edges = append(conn.Edges, &OrderEdge{Cursor: e.Cursor, Node: toOrder(e.Order)})
+	}
+	if n := len(page.Edges); n > 0 {
+		conn.PageInfo.EndCursor = &page.Edges[n-1].Cursor
+	}
+	return conn, nil
+}
+
+func (r *accountResolver) Addresses(ctx context.Context, obj *Account) ([]*Address, error) {
+	if err := authorizeAccount(ctx, obj.ID); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	addressList, err := r.server.accountClient.ListAddresses(ctx, obj.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	var addresses []*Address
+	for _, a := range addressList {
+		addresses = append(addresses, toAddress(a))
+	}
+	return addresses, nil
+}
+
+// Store credit the account got from refunds; customers see their own, admins anyone's.
+func (r *accountResolver) StoreCredit(ctx context.Context, obj *Account) ([]*Money, error) {
+	if err := authorizeAccount(ctx, obj.ID); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	credit, err := r.server.orderClient.GetStoreCredit(ctx, obj.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*Money{}
+	for _, m := range credit {
+		res = append(res, toMoney(m))
+	}
+	return res, nil
+}
+
+// A nil filter (or nil bound) means no restriction.
+func (f *OrderFilterInput) toOrderFilter() order.OrderFilter {
+	filter := order.OrderFilter{}
+	if f == nil {
+		return filter
+	}
+	if f.CreatedAfter != nil {
+		filter.CreatedAfter = *f.CreatedAfter
+	}
+	if f.CreatedBefore != nil {
+		filter.CreatedBefore = *f.CreatedBefore
+	}
+	for _, s := range f.Status {
+		filter.Statuses = append(filter.Statuses, fromOrderStatus(s))
+	}
+	return filter
+}
+
+func toAddress(a account.Address) *Address {
+	return &Address{
+		ID:         a.ID,
+		Kind:       AddressKind(strings.ToUpper(a.Kind)),
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		IsDefault:  a.IsDefault,
+	}
+}
+
+func toOrderAddress(a *order.Address) *OrderAddress {
+	if a == nil {
+		return nil
+	}
+	return &OrderAddress{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func toOrder(o order.Order) *Order {
+	var products []*OrderedProduct
+	for _, p := range o.Products {
+		discounts := []*OrderLineDiscount{}
+		for _, d := range p.Discounts {
+			discounts = append(discounts, &OrderLineDiscount{
+				PromotionID: d.PromotionID,
+				Code:        d.Code,
+				Description: d.Description,
+				Amount:      toMoney(d.Amount),
 			})
 		}
-		orders = append(orders, &Order{
-			ID:         o.ID,
-			CreatedAt:  o.CreatedAt,
-			TotalPrice: o.TotalPrice,
-			Products:   products,
+		products = append(products, &OrderedProduct{
+			ID:          p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			Price:       toMoney(p.Price),
+			Quantity:    int(p.Quantity),
+			Discounts:   discounts,
+			Total:       toMoney(p.Total()),
+			TaxClass:    p.TaxClass,
+			TaxRate:     p.TaxRate.Percent(),
+			Tax:         toMoney(p.Tax),
+			Weight:      int(p.Weight),
 		})
 	}
-	return orders, nil
+	history := []*OrderStatusChange{}
+	for _, c := range o.StatusHistory {
+		change := &OrderStatusChange{
+			To:        toOrderStatus(c.To),
+			ChangedAt: c.ChangedAt,
+			Note:      c.Note,
+		}
+		if c.From != "" {
+			from := toOrderStatus(c.From)
+			change.From = &from
+		}
+		if c.ChangedBy != "" {
+			changedBy := c.ChangedBy
+			change.ChangedBy = &changedBy
+		}
+		history = append(history, change)
+	}
+	rates := []*ExchangeRate{}
+	for _, r := range o.ExchangeRates {
+		rates = append(rates, toExchangeRate(r, nil))
+	}
+	shipments := []*Shipment{}
+	for _, s := range o.Shipments {
+		shipments = append(shipments, toShipment(s))
+	}
+	returns := []*Return{}
+	for _, r := range o.Returns {
+		returns = append(returns, toReturn(r))
+	}
+	refunds := []*OrderRefund{}
+	for _, r := range o.Refunds {
+		refunds = append(refunds, toOrderRefund(r))
+	}
+	return &Order{
+		ID:               o.ID,
+		CreatedAt:        o.CreatedAt,
+		Subtotal:         toMoney(o.Subtotal),
+		DiscountTotal:    toMoney(o.Discount),
+		TaxTotal:         toMoney(o.Tax),
+		ShippingTotal:    toMoney(o.Shipping),
+		TotalPrice:       toMoney(o.TotalPrice),
+		PricesIncludeTax: o.PricesIncludeTax,
+		Products:         products,
+		ShippingAddress:  toOrderAddress(o.ShippingAddress),
+		ShippingMethod:   optional(o.ShippingMethodName),
+		Shipments:        shipments,
+		Returns:          returns,
+		Refunds:          refunds,
+		Status:           toOrderStatus(o.Status),
+		StatusHistory:    history,
+		Cancellation:     toOrderCancellation(o.Cancellation),
+		ExchangeRates:    rates,
+	}
+}
+
+func toOrderCancellation(c *order.Cancellation) *OrderCancellation {
+	if c == nil {
+		return nil
+	}
+	oc := &OrderCancellation{
+		Reason:      c.Reason,
+		RefundDue:   toMoney(c.RefundDue),
+		CancelledAt: c.CancelledAt,
+	}
+	if c.CancelledBy != "" {
+		cancelledBy := c.CancelledBy
+		oc.CancelledBy = &cancelledBy
+	}
+	return oc
+}
+
+func toMoney(m money.Money) *Money {
+	return &Money{
+		Amount:   m.Decimal(),
+		Currency: m.Currency,
+	}
+}
+
+func toExchangeRate(r money.Rate, updatedAt *time.Time) *ExchangeRate {
+	return &ExchangeRate{
+		From:      r.From,
+		To:        r.To,
+		Rate:      r.Decimal(),
+		UpdatedAt: updatedAt,
+	}
+}
+
+// Parses the decimal amount exactly; malformed amounts and currencies are invalid parameters.
+func (in MoneyInput) toMoney() (money.Money, error) {
+	m, err := money.Parse(in.Amount, in.Currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	return m, nil
+}
+
+// Converts an order status as stored by the order service ("paid") to the GraphQL enum (PAID).
+func toOrderStatus(status string) OrderStatus {
+	return OrderStatus(strings.ToUpper(status))
+}
+
+func fromOrderStatus(status OrderStatus) string {
+	return strings.ToLower(string(status))
 }
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"Microservices-based-E-commerce-System/auth"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("not allowed to access this resource")
+)
+
+// HTTP middleware that authenticates requests carrying an "Authorization: Bearer <token>" header.
+// Requests without the header go through anonymously (e.g. createAccount, login, products);
+// resolvers that need a caller check for one with viewer(). A header with a bad token is rejected outright.
+func authMiddleware(tokens *auth.TokenManager, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		header := req.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			http.Error(w, "malformed Authorization header", http.StatusUnauthorized)
+			return
+		}
+		token = strings.TrimSpace(token)
+		claims, err := tokens.Verify(token, auth.AccessToken)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		// The raw token travels with the context so the gRPC clients can forward it to the services.
+		next.ServeHTTP(w, req.WithContext(auth.NewContext(req.Context(), token, claims)))
+	})
+}
+
+// Returns the authenticated caller or ErrUnauthenticated.
+func viewer(ctx context.Context) (*auth.Claims, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	return claims, nil
+}
+
+// Allows the call only if the caller owns the account or is an admin.
+func authorizeAccount(ctx context.Context, accountID string) error {
+	if _, err := viewer(ctx); err != nil {
+		return err
+	}
+	if !auth.CanAccessAccount(ctx, accountID) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Implements the @hasRole schema directive. Admins pass every role check.
+func hasRoleDirective(ctx context.Context, obj interface{}, next graphql.Resolver, role Role) (interface{}, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.IsAdmin() && toRole(claims.Role) != role {
+		return nil, ErrForbidden
+	}
+	return next(ctx)
+}
+
+// Converts a role as stored by the account service ("admin") to the GraphQL enum (ADMIN).
+func toRole(role string) Role {
+	return Role(strings.ToUpper(role))
+}
+
+// Converts the GraphQL enum back to the account service's representation.
+func fromRole(role Role) string {
+	return strings.ToLower(string(role))
+}
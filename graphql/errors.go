@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors raised by the gateway itself, keyed to the code clients see in extensions.code.
+var gatewayErrorCodes = map[error]codes.Code{
+	ErrUnauthenticated:  codes.Unauthenticated,
+	ErrForbidden:        codes.PermissionDenied,
+	ErrInvalidParameter: codes.InvalidArgument,
+	ErrAddressNotFound:  codes.NotFound,
+}
+
+// Formats every resolver error with a stable, machine-readable extensions.code
+// (NOT_FOUND, INVALID_ARGUMENT, FAILED_PRECONDITION, UNAVAILABLE, ...) taken from the gRPC status
+// the services returned, so clients can branch on the code instead of parsing messages.
+// The message is the status description without the "rpc error: code = ..." prefix.
+// Unexpected errors are logged and reported as INTERNAL without their details.
+func errorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Extensions["code"] != nil {
+		return gqlErr // already classified, e.g. query validation errors
+	}
+	code, message := classifyError(err)
+	violations := fieldViolations(err)
+	if code == codes.Internal {
+		log.Println(err)
+		message = "internal error"
+	}
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+	presented.Message = message
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]interface{}{}
+	}
+	presented.Extensions["code"] = errorCode(code)
+	if len(violations) > 0 {
+		presented.Extensions["violations"] = violations
+	}
+	return presented
+}
+
+// Collects the per-field problems a service attached to an InvalidArgument status
+// (e.g. the offending lines of an order) as [{field, description}].
+func fieldViolations(err error) []map[string]string {
+	s, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	violations := []map[string]string{}
+	for _, d := range s.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				violations = append(violations, map[string]string{"field": v.Field, "description": v.Description})
+			}
+		}
+	}
+	return violations
+}
+
+func classifyError(err error) (codes.Code, string) {
+	for target, code := range gatewayErrorCodes {
+		if errors.Is(err, target) {
+			return code, err.Error()
+		}
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code(), s.Message()
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded, err.Error()
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled, err.Error()
+	}
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) {
+		return codes.InvalidArgument, gqlErr.Message // e.g. a variable that failed to unmarshal
+	}
+	return codes.Internal, err.Error()
+}
+
+// Spells a gRPC code the way GraphQL clients conventionally expect it: NotFound -> NOT_FOUND.
+func errorCode(code codes.Code) string {
+	if code == codes.OK || code == codes.Unknown {
+		code = codes.Internal
+	}
+	var b strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
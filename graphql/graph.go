@@ -2,8 +2,10 @@ package main
 
 import (
 	"Microservices-based-E-commerce-System/account"
+	"Microservices-based-E-commerce-System/cart"
 	"Microservices-based-E-commerce-System/catalog"
 	"Microservices-based-E-commerce-System/order"
+	"Microservices-based-E-commerce-System/payment"
 
 	"github.com/99designs/gqlgen/graphql"
 )
@@ -12,9 +14,11 @@ type Server struct {
 	accountClient *account.Client
 	catalogClient *catalog.Client
 	orderClient   *order.Client
+	cartClient    *cart.Client
+	paymentClient *payment.Client
 }
 
-func NewGraphQLServer(accountUrl, catalogUrl, orderUrl string) (*Server, error) {
+func NewGraphQLServer(accountUrl, catalogUrl, orderUrl, cartUrl, paymentUrl string) (*Server, error) {
 	accountClient, err := account.NewClient(accountUrl)
 	if err != nil {
 		return nil, err
@@ -33,10 +37,29 @@ func NewGraphQLServer(accountUrl, catalogUrl, orderUrl string) (*Server, error)
 		return nil, err
 	}
 
+	cartClient, err := cart.NewClient(cartUrl)
+	if err != nil {
+		accountClient.Close()
+		catalogClient.Close()
+		orderClient.Close()
+		return nil, err
+	}
+
+	paymentClient, err := payment.NewClient(paymentUrl)
+	if err != nil {
+		accountClient.Close()
+		catalogClient.Close()
+		orderClient.Close()
+		cartClient.Close()
+		return nil, err
+	}
+
 	return &Server{
 		accountClient,
 		catalogClient,
 		orderClient,
+		cartClient,
+		paymentClient,
 	}, nil
 }
 
@@ -58,8 +81,17 @@ func (s *Server) Account() AccountResolver {
 	}
 }
 
+func (s *Server) Order() OrderResolver {
+	return &orderResolver{
+		server: s,
+	}
+}
+
 func (s *Server) ToExecutableSchema() graphql.ExecutableSchema {
 	return NewExecutableSchema(Config{
 		Resolvers: s,
+		Directives: DirectiveRoot{
+			HasRole: hasRoleDirective,
+		},
 	})
 }
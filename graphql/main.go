@@ -1,6 +1,7 @@
 package main
 
 import (
+	"Microservices-based-E-commerce-System/auth"
 	"log"
 	"net/http"
 
@@ -12,6 +13,9 @@ type AppConfig struct {
 	AccountURL string `envconfig:"ACCOUNT_SERVICE_URL"`
 	CatalogURL string `envconfig:"CATALOG_SERVICE_URL"`
 	OrderURL   string `envconfig:"ORDER_SERVICE_URL"`
+	CartURL    string `envconfig:"CART_SERVICE_URL"`
+	PaymentURL string `envconfig:"PAYMENT_SERVICE_URL"`
+	JWTSecret  string `envconfig:"JWT_SECRET" required:"true"`
 }
 
 func main() {
@@ -20,11 +24,12 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	s, err := NewGraphQLServer(cfg.AccountURL, cfg.CatalogURL, cfg.OrderURL)
+	s, err := NewGraphQLServer(cfg.AccountURL, cfg.CatalogURL, cfg.OrderURL, cfg.CartURL, cfg.PaymentURL)
 	if err != nil {
 		log.Fatal(err)
 	}
-	http.Handle("/graphql", handler.GraphQL(s.ToExecutableSchema()))
+	tokens := auth.NewTokenManager(cfg.JWTSecret)
+	http.Handle("/graphql", authMiddleware(tokens, handler.GraphQL(s.ToExecutableSchema(), handler.ErrorPresenter(errorPresenter))))
 	http.Handle("/playground", handler.Playground("videh", "/graphql"))
 
 	log.Fatal(http.ListenAndServe(":8080", nil))
@@ -1,7 +1,10 @@
 package main
 
 type Account struct {
-	ID     string  `json:"id"`
-	Name   string  `json:"name"`
-	Orders []Order `json:"orders"`
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	Email             string  `json:"email"`
+	Role              Role    `json:"role"`
+	PreferredCurrency *string `json:"preferredCurrency"`
+	Orders            []Order `json:"orders"`
 }
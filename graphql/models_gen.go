@@ -3,26 +3,210 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
 	"time"
 )
 
+type AccountConnection struct {
+	Edges      []*AccountEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int            `json:"totalCount"`
+}
+
+type AccountEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Account `json:"node"`
+}
+
 type AccountInput struct {
-	Name string `json:"name"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type Address struct {
+	ID         string      `json:"id"`
+	Kind       AddressKind `json:"kind"`
+	Name       string      `json:"name"`
+	Line1      string      `json:"line1"`
+	Line2      string      `json:"line2"`
+	City       string      `json:"city"`
+	Region     string      `json:"region"`
+	PostalCode string      `json:"postalCode"`
+	Country    string      `json:"country"`
+	IsDefault  bool        `json:"isDefault"`
+}
+
+type AddressInput struct {
+	Name       string  `json:"name"`
+	Line1      string  `json:"line1"`
+	Line2      *string `json:"line2,omitempty"`
+	City       string  `json:"city"`
+	Region     *string `json:"region,omitempty"`
+	PostalCode string  `json:"postalCode"`
+	Country    string  `json:"country"`
+}
+
+type AuthPayload struct {
+	Account      *Account  `json:"account"`
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+type Cart struct {
+	Items []*CartItem `json:"items"`
+	Total *Money      `json:"total"`
+}
+
+type CartItem struct {
+	ProductID   string    `json:"productId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   *Money    `json:"unitPrice,omitempty"`
+	LineTotal   *Money    `json:"lineTotal,omitempty"`
+	Available   bool      `json:"available"`
+	AddedAt     time.Time `json:"addedAt"`
+}
+
+type Dimensions struct {
+	Length int `json:"length"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type DimensionsInput struct {
+	Length int `json:"length"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type ExchangeRate struct {
+	From      string     `json:"from"`
+	To        string     `json:"to"`
+	Rate      string     `json:"rate"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+type LedgerEntry struct {
+	ID          string           `json:"id"`
+	Kind        LedgerEntryKind  `json:"kind"`
+	OrderID     string           `json:"orderId"`
+	AccountID   string           `json:"accountId"`
+	RefundID    *string          `json:"refundId,omitempty"`
+	Description string           `json:"description"`
+	Postings    []*LedgerPosting `json:"postings"`
+	CreatedAt   time.Time        `json:"createdAt"`
+}
+
+type LedgerMismatch struct {
+	OrderID  string        `json:"orderId"`
+	Account  LedgerAccount `json:"account"`
+	Expected *Money        `json:"expected"`
+	Actual   *Money        `json:"actual"`
+}
+
+type LedgerPosting struct {
+	Account LedgerAccount `json:"account"`
+	Amount  *Money        `json:"amount"`
+}
+
+type LedgerReconciliation struct {
+	From              *time.Time        `json:"from,omitempty"`
+	To                *time.Time        `json:"to,omitempty"`
+	Orders            int               `json:"orders"`
+	Balanced          bool              `json:"balanced"`
+	Mismatches        []*LedgerMismatch `json:"mismatches"`
+	UnbalancedEntries []string          `json:"unbalancedEntries"`
+}
+
+type Money struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type MoneyInput struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
 }
 
 type Mutation struct {
 }
 
 type Order struct {
-	ID         string            `json:"id"`
-	CreatedAt  time.Time         `json:"createdAt"`
-	TotalPrice float64           `json:"totalPrice"`
-	Products   []*OrderedProduct `json:"products"`
+	ID               string               `json:"id"`
+	CreatedAt        time.Time            `json:"createdAt"`
+	Subtotal         *Money               `json:"subtotal"`
+	DiscountTotal    *Money               `json:"discountTotal"`
+	TaxTotal         *Money               `json:"taxTotal"`
+	ShippingTotal    *Money               `json:"shippingTotal"`
+	TotalPrice       *Money               `json:"totalPrice"`
+	PricesIncludeTax bool                 `json:"pricesIncludeTax"`
+	Products         []*OrderedProduct    `json:"products"`
+	ShippingAddress  *OrderAddress        `json:"shippingAddress,omitempty"`
+	ShippingMethod   *string              `json:"shippingMethod,omitempty"`
+	Shipments        []*Shipment          `json:"shipments"`
+	Returns          []*Return            `json:"returns"`
+	Refunds          []*OrderRefund       `json:"refunds"`
+	Status           OrderStatus          `json:"status"`
+	StatusHistory    []*OrderStatusChange `json:"statusHistory"`
+	Cancellation     *OrderCancellation   `json:"cancellation,omitempty"`
+	ExchangeRates    []*ExchangeRate      `json:"exchangeRates"`
+	Payments         []*Payment           `json:"payments"`
+}
+
+type OrderAddress struct {
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+}
+
+type OrderCancellation struct {
+	Reason      string    `json:"reason"`
+	RefundDue   *Money    `json:"refundDue"`
+	CancelledAt time.Time `json:"cancelledAt"`
+	CancelledBy *string   `json:"cancelledBy,omitempty"`
+}
+
+type OrderConnection struct {
+	Edges      []*OrderEdge `json:"edges"`
+	PageInfo   *PageInfo    `json:"pageInfo"`
+	TotalCount int          `json:"totalCount"`
+}
+
+type OrderEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Order `json:"node"`
+}
+
+type OrderFilterInput struct {
+	CreatedAfter  *time.Time    `json:"createdAfter,omitempty"`
+	CreatedBefore *time.Time    `json:"createdBefore,omitempty"`
+	Status        []OrderStatus `json:"status,omitempty"`
 }
 
 type OrderInput struct {
-	AccountID string               `json:"accountId"`
-	Products  []*OrderProductInput `json:"products"`
+	AccountID        *string              `json:"accountId,omitempty"`
+	AddressID        *string              `json:"addressId,omitempty"`
+	Currency         *string              `json:"currency,omitempty"`
+	ShippingMethodID *string              `json:"shippingMethodId,omitempty"`
+	Products         []*OrderProductInput `json:"products"`
+	CouponCodes      []string             `json:"couponCodes,omitempty"`
+}
+
+type OrderLineDiscount struct {
+	PromotionID string `json:"promotionId"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Amount      *Money `json:"amount"`
 }
 
 type OrderProductInput struct {
@@ -30,12 +214,42 @@ type OrderProductInput struct {
 	Quantity int    `json:"quantity"`
 }
 
+type OrderRefund struct {
+	ID          string            `json:"id"`
+	ReturnID    *string           `json:"returnId,omitempty"`
+	Amount      *Money            `json:"amount"`
+	Destination RefundDestination `json:"destination"`
+	Reason      string            `json:"reason"`
+	Lines       []*RefundLine     `json:"lines"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	CompletedAt *time.Time        `json:"completedAt,omitempty"`
+}
+
+type OrderStatusChange struct {
+	From      *OrderStatus `json:"from,omitempty"`
+	To        OrderStatus  `json:"to"`
+	ChangedAt time.Time    `json:"changedAt"`
+	ChangedBy *string      `json:"changedBy,omitempty"`
+	Note      string       `json:"note"`
+}
+
 type OrderedProduct struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int     `json:"quantity"`
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Price       *Money               `json:"price"`
+	Quantity    int                  `json:"quantity"`
+	Discounts   []*OrderLineDiscount `json:"discounts"`
+	Total       *Money               `json:"total"`
+	TaxClass    string               `json:"taxClass"`
+	TaxRate     string               `json:"taxRate"`
+	Tax         *Money               `json:"tax"`
+	Weight      int                  `json:"weight"`
+}
+
+type PageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor,omitempty"`
 }
 
 type PaginationInput struct {
@@ -43,18 +257,856 @@ type PaginationInput struct {
 	Take *int `json:"take,omitempty"`
 }
 
+type Payment struct {
+	ID            string           `json:"id"`
+	OrderID       string           `json:"orderId"`
+	Amount        *Money           `json:"amount"`
+	Refunded      *Money           `json:"refunded"`
+	Status        PaymentStatus    `json:"status"`
+	Provider      string           `json:"provider"`
+	FailureReason *string          `json:"failureReason,omitempty"`
+	Refunds       []*PaymentRefund `json:"refunds"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+}
+
+type PaymentRefund struct {
+	ID        string    `json:"id"`
+	Amount    *Money    `json:"amount"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       *Money      `json:"price"`
+	Stock       int         `json:"stock"`
+	TaxClass    string      `json:"taxClass"`
+	Weight      int         `json:"weight"`
+	Dimensions  *Dimensions `json:"dimensions,omitempty"`
+}
+
+type ProductConnection struct {
+	Edges      []*ProductEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int            `json:"totalCount"`
+}
+
+type ProductEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Product `json:"node"`
 }
 
 type ProductInput struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Price       *MoneyInput      `json:"price"`
+	Stock       *int             `json:"stock,omitempty"`
+	TaxClass    *string          `json:"taxClass,omitempty"`
+	Weight      *int             `json:"weight,omitempty"`
+	Dimensions  *DimensionsInput `json:"dimensions,omitempty"`
+}
+
+type Promotion struct {
+	ID                string        `json:"id"`
+	Code              string        `json:"code"`
+	Description       string        `json:"description"`
+	Kind              PromotionKind `json:"kind"`
+	PercentOff        *int          `json:"percentOff,omitempty"`
+	AmountOff         *Money        `json:"amountOff,omitempty"`
+	BuyQuantity       *int          `json:"buyQuantity,omitempty"`
+	GetQuantity       *int          `json:"getQuantity,omitempty"`
+	ProductIds        []string      `json:"productIds"`
+	MinSpend          *Money        `json:"minSpend,omitempty"`
+	MaxUsesPerAccount *int          `json:"maxUsesPerAccount,omitempty"`
+	StartsAt          *time.Time    `json:"startsAt,omitempty"`
+	EndsAt            *time.Time    `json:"endsAt,omitempty"`
+	Stackable         bool          `json:"stackable"`
+	Active            bool          `json:"active"`
+	CreatedAt         time.Time     `json:"createdAt"`
+}
+
+type PromotionInput struct {
+	Code              string        `json:"code"`
+	Description       *string       `json:"description,omitempty"`
+	Kind              PromotionKind `json:"kind"`
+	PercentOff        *int          `json:"percentOff,omitempty"`
+	AmountOff         *MoneyInput   `json:"amountOff,omitempty"`
+	BuyQuantity       *int          `json:"buyQuantity,omitempty"`
+	GetQuantity       *int          `json:"getQuantity,omitempty"`
+	ProductIds        []string      `json:"productIds,omitempty"`
+	MinSpend          *MoneyInput   `json:"minSpend,omitempty"`
+	MaxUsesPerAccount *int          `json:"maxUsesPerAccount,omitempty"`
+	StartsAt          *time.Time    `json:"startsAt,omitempty"`
+	EndsAt            *time.Time    `json:"endsAt,omitempty"`
+	Stackable         *bool         `json:"stackable,omitempty"`
 }
 
 type Query struct {
 }
+
+type RefundLine struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+	Amount    *Money `json:"amount"`
+}
+
+type RefundLineInput struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type Return struct {
+	ID         string         `json:"id"`
+	OrderID    string         `json:"orderId"`
+	Status     ReturnStatus   `json:"status"`
+	Reason     string         `json:"reason"`
+	Lines      []*ReturnLine  `json:"lines"`
+	Refund     *Money         `json:"refund"`
+	RefundedAt *time.Time     `json:"refundedAt,omitempty"`
+	Restocked  bool           `json:"restocked"`
+	History    []*ReturnEvent `json:"history"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+type ReturnEvent struct {
+	Status     ReturnStatus `json:"status"`
+	Note       string       `json:"note"`
+	OccurredAt time.Time    `json:"occurredAt"`
+	ChangedBy  *string      `json:"changedBy,omitempty"`
+}
+
+type ReturnLine struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+	Refund    *Money `json:"refund"`
+}
+
+type ReturnLineInput struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type Shipment struct {
+	ID             string           `json:"id"`
+	OrderID        string           `json:"orderId"`
+	Carrier        string           `json:"carrier"`
+	TrackingNumber string           `json:"trackingNumber"`
+	Status         ShipmentStatus   `json:"status"`
+	History        []*ShipmentEvent `json:"history"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	UpdatedAt      time.Time        `json:"updatedAt"`
+}
+
+type ShipmentEvent struct {
+	Status     ShipmentStatus `json:"status"`
+	Note       string         `json:"note"`
+	OccurredAt time.Time      `json:"occurredAt"`
+	ChangedBy  *string        `json:"changedBy,omitempty"`
+}
+
+type ShippingMethod struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Carrier     string             `json:"carrier"`
+	Kind        ShippingMethodKind `json:"kind"`
+	Price       *Money             `json:"price,omitempty"`
+	FreeOver    *Money             `json:"freeOver,omitempty"`
+	WeightRates []*WeightRate      `json:"weightRates"`
+	Countries   []string           `json:"countries"`
+	Active      bool               `json:"active"`
+	CreatedAt   time.Time          `json:"createdAt"`
+}
+
+type ShippingMethodInput struct {
+	Name        string             `json:"name"`
+	Carrier     *string            `json:"carrier,omitempty"`
+	Kind        ShippingMethodKind `json:"kind"`
+	Price       *MoneyInput        `json:"price,omitempty"`
+	FreeOver    *MoneyInput        `json:"freeOver,omitempty"`
+	WeightRates []*WeightRateInput `json:"weightRates,omitempty"`
+	Countries   []string           `json:"countries,omitempty"`
+}
+
+type TaxRule struct {
+	ID        string    `json:"id"`
+	Country   string    `json:"country"`
+	Region    *string   `json:"region,omitempty"`
+	TaxClass  string    `json:"taxClass"`
+	Rate      string    `json:"rate"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type UpdateAccountInput struct {
+	Name string `json:"name"`
+}
+
+type WeightRate struct {
+	MaxWeight int    `json:"maxWeight"`
+	Price     *Money `json:"price"`
+}
+
+type WeightRateInput struct {
+	MaxWeight int         `json:"maxWeight"`
+	Price     *MoneyInput `json:"price"`
+}
+
+type AddressKind string
+
+const (
+	AddressKindShipping AddressKind = "SHIPPING"
+	AddressKindBilling  AddressKind = "BILLING"
+)
+
+var AllAddressKind = []AddressKind{
+	AddressKindShipping,
+	AddressKindBilling,
+}
+
+func (e AddressKind) IsValid() bool {
+	switch e {
+	case AddressKindShipping, AddressKindBilling:
+		return true
+	}
+	return false
+}
+
+func (e AddressKind) String() string {
+	return string(e)
+}
+
+func (e *AddressKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AddressKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AddressKind", str)
+	}
+	return nil
+}
+
+func (e AddressKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *AddressKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e AddressKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type LedgerAccount string
+
+const (
+	LedgerAccountReceivable  LedgerAccount = "RECEIVABLE"
+	LedgerAccountSales       LedgerAccount = "SALES"
+	LedgerAccountDiscounts   LedgerAccount = "DISCOUNTS"
+	LedgerAccountTax         LedgerAccount = "TAX"
+	LedgerAccountShipping    LedgerAccount = "SHIPPING"
+	LedgerAccountPayments    LedgerAccount = "PAYMENTS"
+	LedgerAccountRefunds     LedgerAccount = "REFUNDS"
+	LedgerAccountStoreCredit LedgerAccount = "STORE_CREDIT"
+)
+
+var AllLedgerAccount = []LedgerAccount{
+	LedgerAccountReceivable,
+	LedgerAccountSales,
+	LedgerAccountDiscounts,
+	LedgerAccountTax,
+	LedgerAccountShipping,
+	LedgerAccountPayments,
+	LedgerAccountRefunds,
+	LedgerAccountStoreCredit,
+}
+
+func (e LedgerAccount) IsValid() bool {
+	switch e {
+	case LedgerAccountReceivable, LedgerAccountSales, LedgerAccountDiscounts, LedgerAccountTax, LedgerAccountShipping, LedgerAccountPayments, LedgerAccountRefunds, LedgerAccountStoreCredit:
+		return true
+	}
+	return false
+}
+
+func (e LedgerAccount) String() string {
+	return string(e)
+}
+
+func (e *LedgerAccount) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LedgerAccount(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LedgerAccount", str)
+	}
+	return nil
+}
+
+func (e LedgerAccount) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *LedgerAccount) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e LedgerAccount) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type LedgerEntryKind string
+
+const (
+	LedgerEntryKindOrderPlaced    LedgerEntryKind = "ORDER_PLACED"
+	LedgerEntryKindCharge         LedgerEntryKind = "CHARGE"
+	LedgerEntryKindOrderCancelled LedgerEntryKind = "ORDER_CANCELLED"
+	LedgerEntryKindRefund         LedgerEntryKind = "REFUND"
+)
+
+var AllLedgerEntryKind = []LedgerEntryKind{
+	LedgerEntryKindOrderPlaced,
+	LedgerEntryKindCharge,
+	LedgerEntryKindOrderCancelled,
+	LedgerEntryKindRefund,
+}
+
+func (e LedgerEntryKind) IsValid() bool {
+	switch e {
+	case LedgerEntryKindOrderPlaced, LedgerEntryKindCharge, LedgerEntryKindOrderCancelled, LedgerEntryKindRefund:
+		return true
+	}
+	return false
+}
+
+func (e LedgerEntryKind) String() string {
+	return string(e)
+}
+
+func (e *LedgerEntryKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LedgerEntryKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LedgerEntryKind", str)
+	}
+	return nil
+}
+
+func (e LedgerEntryKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *LedgerEntryKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e LedgerEntryKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "PENDING"
+	OrderStatusPaid      OrderStatus = "PAID"
+	OrderStatusFulfilled OrderStatus = "FULFILLED"
+	OrderStatusShipped   OrderStatus = "SHIPPED"
+	OrderStatusDelivered OrderStatus = "DELIVERED"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusRefunded  OrderStatus = "REFUNDED"
+)
+
+var AllOrderStatus = []OrderStatus{
+	OrderStatusPending,
+	OrderStatusPaid,
+	OrderStatusFulfilled,
+	OrderStatusShipped,
+	OrderStatusDelivered,
+	OrderStatusCancelled,
+	OrderStatusRefunded,
+}
+
+func (e OrderStatus) IsValid() bool {
+	switch e {
+	case OrderStatusPending, OrderStatusPaid, OrderStatusFulfilled, OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded:
+		return true
+	}
+	return false
+}
+
+func (e OrderStatus) String() string {
+	return string(e)
+}
+
+func (e *OrderStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderStatus", str)
+	}
+	return nil
+}
+
+func (e OrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PaymentStatus string
+
+const (
+	PaymentStatusPending           PaymentStatus = "PENDING"
+	PaymentStatusAuthorized        PaymentStatus = "AUTHORIZED"
+	PaymentStatusCaptured          PaymentStatus = "CAPTURED"
+	PaymentStatusPartiallyRefunded PaymentStatus = "PARTIALLY_REFUNDED"
+	PaymentStatusRefunded          PaymentStatus = "REFUNDED"
+	PaymentStatusDeclined          PaymentStatus = "DECLINED"
+	PaymentStatusFailed            PaymentStatus = "FAILED"
+	PaymentStatusVoided            PaymentStatus = "VOIDED"
+)
+
+var AllPaymentStatus = []PaymentStatus{
+	PaymentStatusPending,
+	PaymentStatusAuthorized,
+	PaymentStatusCaptured,
+	PaymentStatusPartiallyRefunded,
+	PaymentStatusRefunded,
+	PaymentStatusDeclined,
+	PaymentStatusFailed,
+	PaymentStatusVoided,
+}
+
+func (e PaymentStatus) IsValid() bool {
+	switch e {
+	case PaymentStatusPending, PaymentStatusAuthorized, PaymentStatusCaptured, PaymentStatusPartiallyRefunded, PaymentStatusRefunded, PaymentStatusDeclined, PaymentStatusFailed, PaymentStatusVoided:
+		return true
+	}
+	return false
+}
+
+func (e PaymentStatus) String() string {
+	return string(e)
+}
+
+func (e *PaymentStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PaymentStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PaymentStatus", str)
+	}
+	return nil
+}
+
+func (e PaymentStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PaymentStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PaymentStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PromotionKind string
+
+const (
+	PromotionKindPercentage  PromotionKind = "PERCENTAGE"
+	PromotionKindFixedAmount PromotionKind = "FIXED_AMOUNT"
+	PromotionKindBuyXGetY    PromotionKind = "BUY_X_GET_Y"
+)
+
+var AllPromotionKind = []PromotionKind{
+	PromotionKindPercentage,
+	PromotionKindFixedAmount,
+	PromotionKindBuyXGetY,
+}
+
+func (e PromotionKind) IsValid() bool {
+	switch e {
+	case PromotionKindPercentage, PromotionKindFixedAmount, PromotionKindBuyXGetY:
+		return true
+	}
+	return false
+}
+
+func (e PromotionKind) String() string {
+	return string(e)
+}
+
+func (e *PromotionKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PromotionKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PromotionKind", str)
+	}
+	return nil
+}
+
+func (e PromotionKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PromotionKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PromotionKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type RefundDestination string
+
+const (
+	RefundDestinationPayment     RefundDestination = "PAYMENT"
+	RefundDestinationStoreCredit RefundDestination = "STORE_CREDIT"
+)
+
+var AllRefundDestination = []RefundDestination{
+	RefundDestinationPayment,
+	RefundDestinationStoreCredit,
+}
+
+func (e RefundDestination) IsValid() bool {
+	switch e {
+	case RefundDestinationPayment, RefundDestinationStoreCredit:
+		return true
+	}
+	return false
+}
+
+func (e RefundDestination) String() string {
+	return string(e)
+}
+
+func (e *RefundDestination) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RefundDestination(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RefundDestination", str)
+	}
+	return nil
+}
+
+func (e RefundDestination) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *RefundDestination) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e RefundDestination) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "REQUESTED"
+	ReturnStatusApproved  ReturnStatus = "APPROVED"
+	ReturnStatusRejected  ReturnStatus = "REJECTED"
+	ReturnStatusReceived  ReturnStatus = "RECEIVED"
+)
+
+var AllReturnStatus = []ReturnStatus{
+	ReturnStatusRequested,
+	ReturnStatusApproved,
+	ReturnStatusRejected,
+	ReturnStatusReceived,
+}
+
+func (e ReturnStatus) IsValid() bool {
+	switch e {
+	case ReturnStatusRequested, ReturnStatusApproved, ReturnStatusRejected, ReturnStatusReceived:
+		return true
+	}
+	return false
+}
+
+func (e ReturnStatus) String() string {
+	return string(e)
+}
+
+func (e *ReturnStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReturnStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReturnStatus", str)
+	}
+	return nil
+}
+
+func (e ReturnStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReturnStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReturnStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Role string
+
+const (
+	RoleCustomer Role = "CUSTOMER"
+	RoleAdmin    Role = "ADMIN"
+)
+
+var AllRole = []Role{
+	RoleCustomer,
+	RoleAdmin,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleCustomer, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ShipmentStatus string
+
+const (
+	ShipmentStatusLabelCreated   ShipmentStatus = "LABEL_CREATED"
+	ShipmentStatusInTransit      ShipmentStatus = "IN_TRANSIT"
+	ShipmentStatusOutForDelivery ShipmentStatus = "OUT_FOR_DELIVERY"
+	ShipmentStatusDelivered      ShipmentStatus = "DELIVERED"
+	ShipmentStatusFailed         ShipmentStatus = "FAILED"
+)
+
+var AllShipmentStatus = []ShipmentStatus{
+	ShipmentStatusLabelCreated,
+	ShipmentStatusInTransit,
+	ShipmentStatusOutForDelivery,
+	ShipmentStatusDelivered,
+	ShipmentStatusFailed,
+}
+
+func (e ShipmentStatus) IsValid() bool {
+	switch e {
+	case ShipmentStatusLabelCreated, ShipmentStatusInTransit, ShipmentStatusOutForDelivery, ShipmentStatusDelivered, ShipmentStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e ShipmentStatus) String() string {
+	return string(e)
+}
+
+func (e *ShipmentStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ShipmentStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ShipmentStatus", str)
+	}
+	return nil
+}
+
+func (e ShipmentStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ShipmentStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ShipmentStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ShippingMethodKind string
+
+const (
+	ShippingMethodKindFlat              ShippingMethodKind = "FLAT"
+	ShippingMethodKindWeightBased       ShippingMethodKind = "WEIGHT_BASED"
+	ShippingMethodKindFreeOverThreshold ShippingMethodKind = "FREE_OVER_THRESHOLD"
+)
+
+var AllShippingMethodKind = []ShippingMethodKind{
+	ShippingMethodKindFlat,
+	ShippingMethodKindWeightBased,
+	ShippingMethodKindFreeOverThreshold,
+}
+
+func (e ShippingMethodKind) IsValid() bool {
+	switch e {
+	case ShippingMethodKindFlat, ShippingMethodKindWeightBased, ShippingMethodKindFreeOverThreshold:
+		return true
+	}
+	return false
+}
+
+func (e ShippingMethodKind) String() string {
+	return string(e)
+}
+
+func (e *ShippingMethodKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ShippingMethodKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ShippingMethodKind", str)
+	}
+	return nil
+}
+
+func (e ShippingMethodKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ShippingMethodKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ShippingMethodKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
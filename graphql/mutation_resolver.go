@@ -1,61 +1,297 @@
 package main
 
 import (
+	"Microservices-based-E-commerce-System/account"
+	"Microservices-based-E-commerce-System/catalog"
+	"Microservices-based-E-commerce-System/money"
 	"Microservices-based-E-commerce-System/order"
 	"context"
 	"errors"
 	"log"
+	"strings"
 	"time"
 )
 
 var (
 	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrAddressNotFound  = errors.New("address not found")
 )
 
 type mutationResolver struct {
 	server *Server
 }
 
-func (r *mutationResolver) CreateAccount(ctx context.Context, in AccountInput) (*Account, error) {
+func (r *mutationResolver) CreateAccount(ctx context.Context, in AccountInput, idempotencyKey *string) (*Account, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	a, err := r.server.accountClient.PostAccount(ctx, in.Name)
+	a, err := r.server.accountClient.PostAccount(ctx, in.Name, in.Email, in.Password, deref(idempotencyKey))
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	return &Account{
-		ID:   a.ID,
-		Name: a.Name,
+	return toAccount(a), nil
+}
+
+func (r *mutationResolver) UpdateAccount(ctx context.Context, id string, in UpdateAccountInput) (*Account, error) {
+	if err := authorizeAccount(ctx, id); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, err := r.server.accountClient.UpdateAccount(ctx, id, in.Name)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return toAccount(a), nil
+}
+
+func (r *mutationResolver) DeleteAccount(ctx context.Context, id string) (bool, error) {
+	if err := authorizeAccount(ctx, id); err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.accountClient.DeleteAccount(ctx, id); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) SetAccountRole(ctx context.Context, id string, role Role) (*Account, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, err := r.server.accountClient.SetAccountRole(ctx, id, fromRole(role))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toAccount(a), nil
+}
+
+// Sets the currency the caller's orders are priced in by default; null clears it.
+func (r *mutationResolver) SetPreferredCurrency(ctx context.Context, currency *string) (*Account, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, err := r.server.accountClient.SetPreferredCurrency(ctx, claims.AccountID(), deref(currency))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toAccount(a), nil
+}
+
+// Address mutations always act on the caller's own account.
+func (r *mutationResolver) AddAddress(ctx context.Context, kind AddressKind, in AddressInput) (*Address, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a := in.toAccountAddress(claims.AccountID())
+	a.Kind = strings.ToLower(string(kind))
+	res, err := r.server.accountClient.AddAddress(ctx, a)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toAddress(*res), nil
+}
+
+func (r *mutationResolver) UpdateAddress(ctx context.Context, id string, in AddressInput) (*Address, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	// The account service keeps the kind of an existing address, so look it up to pass validation.
+	existing, err := r.server.accountClient.ListAddresses(ctx, claims.AccountID())
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	a := in.toAccountAddress(claims.AccountID())
+	a.ID = id
+	for _, e := range existing {
+		if e.ID == id {
+			a.Kind = e.Kind
+		}
+	}
+	if a.Kind == "" {
+		return nil, ErrAddressNotFound
+	}
+	res, err := r.server.accountClient.UpdateAddress(ctx, a)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toAddress(*res), nil
+}
+
+func (r *mutationResolver) DeleteAddress(ctx context.Context, id string) (bool, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.accountClient.DeleteAddress(ctx, claims.AccountID(), id); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) SetDefaultAddress(ctx context.Context, id string) (*Address, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := r.server.accountClient.SetDefaultAddress(ctx, claims.AccountID(), id)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toAddress(*res), nil
+}
+
+func (r *mutationResolver) Login(ctx context.Context, email string, password string) (*AuthPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, tokens, err := r.server.accountClient.Login(ctx, email, password)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &AuthPayload{
+		Account:      toAccount(a),
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.AccessTokenExpiresAt,
 	}, nil
 }
 
-func (r *mutationResolver) CreateProduct(ctx context.Context, in ProductInput) (*Product, error) {
+func (r *mutationResolver) RefreshToken(ctx context.Context, refreshToken string) (*AuthPayload, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	p, err := r.server.catalogClient.PostProduct(ctx, in.Name, in.Description, in.Price)
+	a, tokens, err := r.server.accountClient.RefreshToken(ctx, refreshToken)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
-	return &Product{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
+	return &AuthPayload{
+		Account:      toAccount(a),
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.AccessTokenExpiresAt,
 	}, nil
 }
 
-func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Order, error) {
+func (r *mutationResolver) CreateProduct(ctx context.Context, in ProductInput, idempotencyKey *string) (*Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	price, err := in.Price.toMoney()
+	if err != nil {
+		return nil, err
+	}
+	stock := int64(0)
+	if in.Stock != nil {
+		stock = int64(*in.Stock)
+	}
+	weight, err := optionalCount(in.Weight)
+	if err != nil {
+		return nil, err
+	}
+	var dimensions catalog.Dimensions
+	if in.Dimensions != nil {
+		if dimensions, err = in.Dimensions.toDimensions(); err != nil {
+			return nil, err
+		}
+	}
+	p, err := r.server.catalogClient.PostProduct(ctx, in.Name, in.Description, price, stock, deref(in.TaxClass), weight, dimensions, deref(idempotencyKey))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toProduct(*p), nil
+}
+
+// Adds delta units to a product's stock, or removes them when delta is negative (admin only).
+func (r *mutationResolver) AdjustStock(ctx context.Context, productID string, delta int) (*Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	p, err := r.server.catalogClient.AdjustStock(ctx, productID, int64(delta))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toProduct(*p), nil
+}
+
+// Sets the rate at which one currency converts into another, e.g. EUR -> USD "1.0853" (admin only).
+func (r *mutationResolver) SetExchangeRate(ctx context.Context, from string, to string, rate string) (*ExchangeRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	e, err := r.server.catalogClient.SetExchangeRate(ctx, from, to, rate)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toExchangeRate(e.Rate, &e.UpdatedAt), nil
+}
+
+func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput, idempotencyKey *string) (*Order, error) {
+	// The order is placed for the caller unless an accountId is given, which only admins may set to someone else.
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accountID := claims.AccountID()
+	if in.AccountID != nil {
+		accountID = *in.AccountID
+	}
+	if err := authorizeAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	var products []order.OrderedProduct
 	for _, p := range in.Products {
-		if p.Quantity <= 0 {
+		if p.Quantity < 0 {
 			return nil, ErrInvalidParameter
 		}
 		products = append(products, order.OrderedProduct{
@@ -64,15 +300,485 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Ord
 		})
 	}
 
-	o, err := r.server.orderClient.PostOrder(ctx, in.AccountID, products)
+	addressID := ""
+	if in.AddressID != nil {
+		addressID = *in.AddressID
+	}
+	o, err := r.server.orderClient.PostOrder(ctx, accountID, addressID, deref(in.ShippingMethodID), deref(in.Currency), products, in.CouponCodes, deref(idempotencyKey))
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	return &Order{
-		ID:         o.ID,
-		CreatedAt:  o.CreatedAt,
-		TotalPrice: o.TotalPrice,
-	}, nil
+	return toOrder(*o), nil
+}
+
+// Cart mutations always act on the caller's own cart.
+func (r *mutationResolver) AddCartItem(ctx context.Context, productID string, quantity int) (*Cart, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if quantity < 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.cartClient.AddItem(ctx, claims.AccountID(), productID, uint32(quantity))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toCart(c), nil
+}
+
+// Sets the quantity of a product in the cart; 0 removes it.
+func (r *mutationResolver) UpdateCartItem(ctx context.Context, productID string, quantity int) (*Cart, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if quantity < 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.cartClient.UpdateQuantity(ctx, claims.AccountID(), productID, uint32(quantity))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toCart(c), nil
+}
+
+func (r *mutationResolver) RemoveCartItem(ctx context.Context, productID string) (*Cart, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.cartClient.RemoveItem(ctx, claims.AccountID(), productID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toCart(c), nil
+}
+
+func (r *mutationResolver) ClearCart(ctx context.Context) (bool, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.cartClient.ClearCart(ctx, claims.AccountID()); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// Turns the caller's cart into an order shipped with the given method, redeeming the given coupons, and returns the order.
+func (r *mutationResolver) Checkout(ctx context.Context, addressID *string, currency *string, shippingMethodID *string, couponCodes []string, idempotencyKey *string) (*Order, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	orderID, err := r.server.cartClient.Checkout(ctx, claims.AccountID(), deref(addressID), deref(shippingMethodID), deref(currency), couponCodes, deref(idempotencyKey))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	o, err := r.server.orderClient.GetOrder(ctx, orderID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toOrder(*o), nil
+}
+
+// Cancels one of the caller's orders before it ships. The order service checks ownership with the forwarded token.
+func (r *mutationResolver) CancelOrder(ctx context.Context, id string, reason *string) (*Order, error) {
+	if _, err := viewer(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	o, err := r.server.orderClient.CancelOrder(ctx, id, deref(reason))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toOrder(*o), nil
+}
+
+// Pays the whole total of one of the caller's pending orders; the order becomes paid.
+// A declined payment fails with FAILED_PRECONDITION and shows up in Order.payments.
+func (r *mutationResolver) PayOrder(ctx context.Context, orderID string, idempotencyKey *string) (*Payment, error) {
+	if _, err := viewer(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	p, err := r.server.paymentClient.PayOrder(ctx, orderID, deref(idempotencyKey))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toPayment(*p), nil
+}
+
+// Moves an order along its lifecycle (admin only). Illegal transitions fail with FAILED_PRECONDITION.
+func (r *mutationResolver) UpdateOrderStatus(ctx context.Context, id string, status OrderStatus, note *string) (*Order, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	o, err := r.server.orderClient.UpdateOrderStatus(ctx, id, fromOrderStatus(status), deref(note))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toOrder(*o), nil
+}
+
+// Creates a coupon code customers can redeem right away (admin only).
+func (r *mutationResolver) CreatePromotion(ctx context.Context, in PromotionInput) (*Promotion, error) {
+	p, err := in.toPromotion()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	created, err := r.server.orderClient.CreatePromotion(ctx, p)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toPromotion(*created), nil
+}
+
+// Switches a promotion on or off (admin only). Orders already placed keep their discounts.
+func (r *mutationResolver) SetPromotionActive(ctx context.Context, id string, active bool) (*Promotion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	p, err := r.server.orderClient.SetPromotionActive(ctx, id, active)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toPromotion(*p), nil
+}
+
+// Sets the tax rate for a tax class in a country, or in one of its regions when region is given (admin only).
+// Replaces the rule already there.
+func (r *mutationResolver) SetTaxRule(ctx context.Context, country string, region *string, taxClass string, rate string) (*TaxRule, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rule, err := r.server.orderClient.SetTaxRule(ctx, country, deref(region), taxClass, rate)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toTaxRule(*rule), nil
+}
+
+// Orders already placed keep the tax they were charged (admin only).
+func (r *mutationResolver) DeleteTaxRule(ctx context.Context, id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.orderClient.DeleteTaxRule(ctx, id); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// Creates a shipping method customers can pick right away (admin only).
+func (r *mutationResolver) CreateShippingMethod(ctx context.Context, in ShippingMethodInput) (*ShippingMethod, error) {
+	m, err := in.toShippingMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	created, err := r.server.orderClient.CreateShippingMethod(ctx, m)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toShippingMethod(*created), nil
+}
+
+// Switches a shipping method on or off (admin only). Orders already placed keep their shipping.
+func (r *mutationResolver) SetShippingMethodActive(ctx context.Context, id string, active bool) (*ShippingMethod, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	m, err := r.server.orderClient.SetShippingMethodActive(ctx, id, active)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toShippingMethod(*m), nil
+}
+
+// Records a parcel of a fulfilled or shipped order (admin only).
+func (r *mutationResolver) CreateShipment(ctx context.Context, orderID string, carrier *string, trackingNumber string) (*Shipment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	s, err := r.server.orderClient.CreateShipment(ctx, orderID, deref(carrier), trackingNumber)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toShipment(*s), nil
+}
+
+// Records what the carrier reported about a shipment (admin only). Delivered and failed shipments can't change.
+func (r *mutationResolver) UpdateShipmentStatus(ctx context.Context, id string, status ShipmentStatus, note *string) (*Shipment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	s, err := r.server.orderClient.UpdateShipmentStatus(ctx, id, strings.ToLower(string(status)), deref(note))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toShipment(*s), nil
+}
+
+// Asks to send back units of one of the caller's delivered orders, within the return window.
+func (r *mutationResolver) RequestReturn(ctx context.Context, orderID string, lines []*ReturnLineInput, reason *string) (*Return, error) {
+	if _, err := viewer(ctx); err != nil {
+		return nil, err
+	}
+
+	var returnLines []order.ReturnLine
+	for _, l := range lines {
+		if l.Quantity < 0 {
+			return nil, ErrInvalidParameter
+		}
+		returnLines = append(returnLines, order.ReturnLine{ProductID: l.ProductID, Quantity: uint32(l.Quantity)})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	ret, err := r.server.orderClient.RequestReturn(ctx, orderID, returnLines, deref(reason))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toReturn(*ret), nil
+}
+
+// Approves a requested return and refunds it (admin only). If the refund fails, approving again retries it.
+func (r *mutationResolver) ApproveReturn(ctx context.Context, id string, note *string) (*Return, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	ret, err := r.server.orderClient.ApproveReturn(ctx, id, deref(note))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toReturn(*ret), nil
+}
+
+func (r *mutationResolver) RejectReturn(ctx context.Context, id string, note *string) (*Return, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	ret, err := r.server.orderClient.RejectReturn(ctx, id, deref(note))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toReturn(*ret), nil
+}
+
+// Records that an approved return arrived, optionally putting its units back into stock (admin only).
+func (r *mutationResolver) ReceiveReturn(ctx context.Context, id string, restock *bool, note *string) (*Return, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	ret, err := r.server.orderClient.ReceiveReturn(ctx, id, restock != nil && *restock, deref(note))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toReturn(*ret), nil
+}
+
+// Refunds units of an order's lines or, without lines, an amount, to the customer's payment unless destination
+// says otherwise (admin only).
+func (r *mutationResolver) RefundOrder(ctx context.Context, orderID string, lines []*RefundLineInput, amount *MoneyInput, destination *RefundDestination, reason *string) (*OrderRefund, error) {
+	var refundLines []order.RefundLine
+	for _, l := range lines {
+		if l.Quantity < 0 {
+			return nil, ErrInvalidParameter
+		}
+		refundLines = append(refundLines, order.RefundLine{ProductID: l.ProductID, Quantity: uint32(l.Quantity)})
+	}
+	var refund *money.Money
+	if amount != nil {
+		m, err := amount.toMoney()
+		if err != nil {
+			return nil, err
+		}
+		refund = &m
+	}
+	to := order.RefundToPayment
+	if destination != nil {
+		to = strings.ToLower(string(*destination))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	ref, err := r.server.orderClient.RefundOrder(ctx, orderID, refundLines, refund, to, deref(reason))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toOrderRefund(*ref), nil
+}
+
+// Converts the GraphQL input into a promotion for the order service, which validates it.
+func (in PromotionInput) toPromotion() (order.Promotion, error) {
+	p := order.Promotion{
+		Code:        in.Code,
+		Description: deref(in.Description),
+		Kind:        strings.ToLower(string(in.Kind)),
+		ProductIDs:  in.ProductIds,
+	}
+	var err error
+	if p.PercentOff, err = optionalCount(in.PercentOff); err != nil {
+		return order.Promotion{}, err
+	}
+	if p.BuyQuantity, err = optionalCount(in.BuyQuantity); err != nil {
+		return order.Promotion{}, err
+	}
+	if p.GetQuantity, err = optionalCount(in.GetQuantity); err != nil {
+		return order.Promotion{}, err
+	}
+	if p.MaxUsesPerAccount, err = optionalCount(in.MaxUsesPerAccount); err != nil {
+		return order.Promotion{}, err
+	}
+	if in.AmountOff != nil {
+		if p.AmountOff, err = in.AmountOff.toMoney(); err != nil {
+			return order.Promotion{}, err
+		}
+	}
+	if in.MinSpend != nil {
+		if p.MinSpend, err = in.MinSpend.toMoney(); err != nil {
+			return order.Promotion{}, err
+		}
+	}
+	if in.StartsAt != nil {
+		p.StartsAt = *in.StartsAt
+	}
+	if in.EndsAt != nil {
+		p.EndsAt = *in.EndsAt
+	}
+	if in.Stackable != nil {
+		p.Stackable = *in.Stackable
+	}
+	return p, nil
+}
+
+// Converts the GraphQL input into a shipping method for the order service, which validates it.
+func (in ShippingMethodInput) toShippingMethod() (order.ShippingMethod, error) {
+	m := order.ShippingMethod{
+		Name:      in.Name,
+		Carrier:   deref(in.Carrier),
+		Kind:      strings.ToLower(string(in.Kind)),
+		Countries: in.Countries,
+	}
+	var err error
+	if in.Price != nil {
+		if m.Price, err = in.Price.toMoney(); err != nil {
+			return order.ShippingMethod{}, err
+		}
+	}
+	if in.FreeOver != nil {
+		if m.FreeOver, err = in.FreeOver.toMoney(); err != nil {
+			return order.ShippingMethod{}, err
+		}
+	}
+	for _, rate := range in.WeightRates {
+		if rate.MaxWeight < 0 {
+			return order.ShippingMethod{}, ErrInvalidParameter
+		}
+		price, err := rate.Price.toMoney()
+		if err != nil {
+			return order.ShippingMethod{}, err
+		}
+		m.WeightRates = append(m.WeightRates, order.WeightRate{MaxWeight: uint32(rate.MaxWeight), Price: price})
+	}
+	return m, nil
+}
+
+// Negative sizes are invalid.
+func (in DimensionsInput) toDimensions() (catalog.Dimensions, error) {
+	if in.Length < 0 || in.Width < 0 || in.Height < 0 {
+		return catalog.Dimensions{}, ErrInvalidParameter
+	}
+	return catalog.Dimensions{Length: uint32(in.Length), Width: uint32(in.Width), Height: uint32(in.Height)}, nil
+}
+
+// Reads an optional count; null means 0, negative counts are invalid.
+func optionalCount(n *int) (uint32, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if *n < 0 {
+		return 0, ErrInvalidParameter
+	}
+	return uint32(*n), nil
+}
+
+// Converts the GraphQL input into an account address owned by accountID. Optional fields default to "".
+func (in AddressInput) toAccountAddress(accountID string) account.Address {
+	a := account.Address{
+		AccountID:  accountID,
+		Name:       in.Name,
+		Line1:      in.Line1,
+		City:       in.City,
+		PostalCode: in.PostalCode,
+		Country:    in.Country,
+	}
+	if in.Line2 != nil {
+		a.Line2 = *in.Line2
+	}
+	if in.Region != nil {
+		a.Region = *in.Region
+	}
+	return a
 }
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"Microservices-based-E-commerce-System/order"
+	"Microservices-based-E-commerce-System/payment"
+	"context"
+	"log"
+	"strings"
+	"time"
+)
+
+type orderResolver struct {
+	server *Server
+}
+
+// Payments of an order are read with the caller's token, so the payment service checks they may see the order.
+func (r *orderResolver) Payments(ctx context.Context, obj *Order) ([]*Payment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	payments, err := r.server.paymentClient.GetPaymentsForOrder(ctx, obj.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*Payment{}
+	for _, p := range payments {
+		res = append(res, toPayment(p))
+	}
+	return res, nil
+}
+
+func toPayment(p payment.Payment) *Payment {
+	res := &Payment{
+		ID:            p.ID,
+		OrderID:       p.OrderID,
+		Amount:        toMoney(p.Amount),
+		Refunded:      toMoney(p.Refunded),
+		Status:        PaymentStatus(strings.ToUpper(p.Status)),
+		Provider:      p.Provider,
+		FailureReason: optional(p.FailureReason),
+		Refunds:       []*PaymentRefund{},
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	for _, refund := range p.Refunds {
+		res.Refunds = append(res.Refunds, &PaymentRefund{
+			ID:        refund.ID,
+			Amount:    toMoney(refund.Amount),
+			Reason:    refund.Reason,
+			CreatedAt: refund.CreatedAt,
+		})
+	}
+	return res
+}
+
+// Settings the promotion's kind doesn't use are left null.
+func toPromotion(p order.Promotion) *Promotion {
+	res := &Promotion{
+		ID:          p.ID,
+		Code:        p.Code,
+		Description: p.Description,
+		Kind:        PromotionKind(strings.ToUpper(p.Kind)),
+		ProductIds:  p.ProductIDs,
+		Stackable:   p.Stackable,
+		Active:      p.Active,
+		CreatedAt:   p.CreatedAt,
+	}
+	if res.ProductIds == nil {
+		res.ProductIds = []string{}
+	}
+	switch p.Kind {
+	case order.PromotionPercentage:
+		percentOff := int(p.PercentOff)
+		res.PercentOff = &percentOff
+	case order.PromotionFixedAmount:
+		res.AmountOff = toMoney(p.AmountOff)
+	case order.PromotionBuyXGetY:
+		buy, get := int(p.BuyQuantity), int(p.GetQuantity)
+		res.BuyQuantity, res.GetQuantity = &buy, &get
+	}
+	if !p.MinSpend.IsZero() {
+		res.MinSpend = toMoney(p.MinSpend)
+	}
+	if p.MaxUsesPerAccount > 0 {
+		maxUses := int(p.MaxUsesPerAccount)
+		res.MaxUsesPerAccount = &maxUses
+	}
+	if !p.StartsAt.IsZero() {
+		res.StartsAt = &p.StartsAt
+	}
+	if !p.EndsAt.IsZero() {
+		res.EndsAt = &p.EndsAt
+	}
+	return res
+}
+
+func toTaxRule(r order.TaxRule) *TaxRule {
+	return &TaxRule{
+		ID:        r.ID,
+		Country:   r.Country,
+		Region:    optional(r.Region),
+		TaxClass:  r.TaxClass,
+		Rate:      r.Rate.Percent(),
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// Amounts the method's kind doesn't use are left null.
+func toShippingMethod(m order.ShippingMethod) *ShippingMethod {
+	res := &ShippingMethod{
+		ID:          m.ID,
+		Name:        m.Name,
+		Carrier:     m.Carrier,
+		Kind:        ShippingMethodKind(strings.ToUpper(m.Kind)),
+		WeightRates: []*WeightRate{},
+		Countries:   m.Countries,
+		Active:      m.Active,
+		CreatedAt:   m.CreatedAt,
+	}
+	if res.Countries == nil {
+		res.Countries = []string{}
+	}
+	switch m.Kind {
+	case order.ShippingFlat:
+		res.Price = toMoney(m.Price)
+	case order.ShippingFreeOverThreshold:
+		res.Price = toMoney(m.Price)
+		res.FreeOver = toMoney(m.FreeOver)
+	}
+	for _, rate := range m.WeightRates {
+		res.WeightRates = append(res.WeightRates, &WeightRate{
+			MaxWeight: int(rate.MaxWeight),
+			Price:     toMoney(rate.Price),
+		})
+	}
+	return res
+}
+
+func toShipment(s order.Shipment) *Shipment {
+	res := &Shipment{
+		ID:             s.ID,
+		OrderID:        s.OrderID,
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Status:         ShipmentStatus(strings.ToUpper(s.Status)),
+		History:        []*ShipmentEvent{},
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	for _, e := range s.History {
+		res.History = append(res.History, &ShipmentEvent{
+			Status:     ShipmentStatus(strings.ToUpper(e.Status)),
+			Note:       e.Note,
+			OccurredAt: e.OccurredAt,
+			ChangedBy:  optional(e.ChangedBy),
+		})
+	}
+	return res
+}
+
+func toReturn(r order.Return) *Return {
+	res := &Return{
+		ID:        r.ID,
+		OrderID:   r.OrderID,
+		Status:    ReturnStatus(strings.ToUpper(r.Status)),
+		Reason:    r.Reason,
+		Lines:     []*ReturnLine{},
+		Refund:    toMoney(r.Refund),
+		Restocked: r.Restocked,
+		History:   []*ReturnEvent{},
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if !r.RefundedAt.IsZero() {
+		res.RefundedAt = &r.RefundedAt
+	}
+	for _, l := range r.Lines {
+		res.Lines = append(res.Lines, &ReturnLine{
+			ProductID: l.ProductID,
+			Quantity:  int(l.Quantity),
+			Refund:    toMoney(l.Refund),
+		})
+	}
+	for _, e := range r.History {
+		res.History = append(res.History, &ReturnEvent{
+			Status:     ReturnStatus(strings.ToUpper(e.Status)),
+			Note:       e.Note,
+			OccurredAt: e.OccurredAt,
+			ChangedBy:  optional(e.ChangedBy),
+		})
+	}
+	return res
+}
+
+func toOrderRefund(r order.OrderRefund) *OrderRefund {
+	res := &OrderRefund{
+		ID:          r.ID,
+		ReturnID:    optional(r.ReturnID),
+		Amount:      toMoney(r.Amount),
+		Destination: RefundDestination(strings.ToUpper(r.Destination)),
+		Reason:      r.Reason,
+		Lines:       []*RefundLine{},
+		CreatedAt:   r.CreatedAt,
+	}
+	if !r.CompletedAt.IsZero() {
+		res.CompletedAt = &r.CompletedAt
+	}
+	for _, l := range r.Lines {
+		res.Lines = append(res.Lines, &RefundLine{
+			ProductID: l.ProductID,
+			Quantity:  int(l.Quantity),
+			Amount:    toMoney(l.Amount),
+		})
+	}
+	return res
+}
+
+func toLedgerEntry(e order.LedgerEntry) *LedgerEntry {
+	res := &LedgerEntry{
+		ID:          e.ID,
+		Kind:        LedgerEntryKind(strings.ToUpper(e.Kind)),
+		OrderID:     e.OrderID,
+		AccountID:   e.AccountID,
+		RefundID:    optional(e.RefundID),
+		Description: e.Description,
+		Postings:    []*LedgerPosting{},
+		CreatedAt:   e.CreatedAt,
+	}
+	for _, p := range e.Postings {
+		res.Postings = append(res.Postings, &LedgerPosting{
+			Account: LedgerAccount(strings.ToUpper(p.Account)),
+			Amount:  toMoney(p.Amount),
+		})
+	}
+	return res
+}
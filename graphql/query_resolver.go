@@ -1,8 +1,12 @@
 package main
 
 import (
+	"Microservices-based-E-commerce-System/account"
+	"Microservices-based-E-commerce-System/cart"
+	"Microservices-based-E-commerce-System/catalog"
 	"context"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -10,6 +14,24 @@ type queryResolver struct {
 	server *Server
 }
 
+// Returns the account of the authenticated caller.
+func (r *queryResolver) Me(ctx context.Context) (*Account, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, err := r.server.accountClient.GetAccount(ctx, claims.AccountID())
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toAccount(a), nil
+}
+
 func (r *queryResolver) Accounts(ctx context.Context, pagination *PaginationInput, id *string) ([]*Account, error) {
 	// Creates a child context with a 3 second timeout. If the operation takes longer, it will be canceled.
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
@@ -20,10 +42,7 @@ func (r *queryResolver) Accounts(ctx context.Context, pagination *PaginationInpu
 			log.Println(err)
 			return nil, err
 		}
-		return []*Account{{
-			ID:   r.ID,
-			Name: r.Name,
-		}}, nil
+		return []*Account{toAccount(r)}, nil
 	}
 	skip, take := uint64(0), uint64(0)
 	if pagination != nil {
@@ -36,11 +55,7 @@ func (r *queryResolver) Accounts(ctx context.Context, pagination *PaginationInpu
 	}
 	var accounts []*Account
 	for _, a := range accountList {
-		account := &Account{
-			ID:   a.ID,
-			Name: a.Name,
-		}
-		accounts = append(accounts, account)
+		accounts = append(accounts, toAccount(&a))
 	}
 	return accounts, nil
 }
@@ -55,12 +70,7 @@ func (r *queryResolver) Products(ctx context.Context, pagination *PaginationInpu
 			log.Println(err)
 			return nil, err
 		}
-		return []*Product{{
-			ID:          r.ID,
-			Name:        r.Name,
-			Description: r.Description,
-			Price:       r.Price,
-		}}, nil
+		return []*Product{toProduct(*r)}, nil
 	}
 
 	skip, take := uint64(0), uint64(0)
@@ -79,16 +89,84 @@ func (r *queryResolver) Products(ctx context.Context, pagination *PaginationInpu
 	}
 	var products []*Product
 	for _, p := range productList {
-		products = append(products, &Product{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-		})
+		products = append(products, toProduct(p))
 	}
 	return products, nil
 }
 
+func (r *queryResolver) AccountsConnection(ctx context.Context, first *int, after *string) (*AccountConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	page, err := r.server.accountClient.ListAccounts(ctx, deref(after), firstOrDefault(first))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	conn := &AccountConnection{
+		Edges:      []*AccountEdge{},
+		PageInfo:   &PageInfo{HasNextPage: page.HasNextPage},
+		TotalCount: int(page.TotalCount),
+	}
+	for _, e := range page.Edges {
+		conn.Edges = append(conn.Edges, &AccountEdge{
+			Cursor: e.Cursor,
+			Node:   toAccount(&e.Account),
+		})
+	}
+	if n := len(page.Edges); n > 0 {
+		conn.PageInfo.EndCursor = &page.Edges[n-1].Cursor
+	}
+	return conn, nil
+}
+
+func (r *queryResolver) ProductsConnection(ctx context.Context, first *int, after *string, query *string) (*ProductConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	page, err := r.server.catalogClient.ListProducts(ctx, deref(query), deref(after), firstOrDefault(first))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	conn := &ProductConnection{
+		Edges:      []*ProductEdge{},
+		PageInfo:   &PageInfo{HasNextPage: page.HasNextPage},
+		TotalCount: int(page.TotalCount),
+	}
+	for _, e := range page.Edges {
+		conn.Edges = append(conn.Edges, &ProductEdge{
+			Cursor: e.Cursor,
+			Node:   toProduct(e.Product),
+		})
+	}
+	if n := len(page.Edges); n > 0 {
+		conn.PageInfo.EndCursor = &page.Edges[n-1].Cursor
+	}
+	return conn, nil
+}
+
+// Returns a single order with its product lines. Customers can only see their own orders;
+// the order service enforces the same rule with the forwarded token.
+func (r *queryResolver) Order(ctx context.Context, id string) (*Order, error) {
+	if _, err := viewer(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	o, err := r.server.orderClient.GetOrder(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	if err := authorizeAccount(ctx, o.AccountID); err != nil {
+		return nil, err
+	}
+	return toOrder(*o), nil
+}
+
 // Converts optional *int fields into uint64 with defaults.
 // Many APIs or DB calls require concrete numbers, not pointers. Also ensures we don't accidentally return all data.
 func (p PaginationInput) bounds() (uint64, uint64) {
@@ -102,3 +180,243 @@ func (p PaginationInput) bounds() (uint64, uint64) {
 	}
 	return skipValue, takeValue
 }
+
+// Page size for connection fields: "first" if given, otherwise the services' default (100).
+func firstOrDefault(first *int) uint64 {
+	if first == nil || *first < 0 {
+		return 0
+	}
+	return uint64(*first)
+}
+
+// The caller's cart, priced in currency or, if null, the account's preferred currency.
+func (r *queryResolver) Cart(ctx context.Context, currency *string) (*Cart, error) {
+	claims, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.cartClient.GetCart(ctx, claims.AccountID(), deref(currency))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return toCart(c), nil
+}
+
+func toCart(c *cart.PricedCart) *Cart {
+	items := []*CartItem{}
+	for _, item := range c.Items {
+		ci := &CartItem{
+			ProductID:   item.ProductID,
+			Name:        item.Name,
+			Description: item.Description,
+			Quantity:    int(item.Quantity),
+			Available:   item.Available,
+			AddedAt:     item.AddedAt,
+		}
+		if item.Available {
+			ci.UnitPrice = toMoney(item.UnitPrice)
+			ci.LineTotal = toMoney(item.LineTotal)
+		}
+		items = append(items, ci)
+	}
+	return &Cart{
+		Items: items,
+		Total: toMoney(c.Total),
+	}
+}
+
+// Dimensions are null when none were given.
+func toProduct(p catalog.Product) *Product {
+	res := &Product{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       toMoney(p.Price),
+		Stock:       int(p.Stock),
+		TaxClass:    p.TaxClass,
+		Weight:      int(p.Weight),
+	}
+	if p.Dimensions != (catalog.Dimensions{}) {
+		res.Dimensions = &Dimensions{
+			Length: int(p.Dimensions.Length),
+			Width:  int(p.Dimensions.Width),
+			Height: int(p.Dimensions.Height),
+		}
+	}
+	return res
+}
+
+// Converts an account of the account service into its GraphQL form.
+func toAccount(a *account.Account) *Account {
+	return &Account{
+		ID:                a.ID,
+		Name:              a.Name,
+		Email:             a.Email,
+		Role:              toRole(a.Role),
+		PreferredCurrency: optional(a.PreferredCurrency),
+	}
+}
+
+// Turns "" (not set) into null.
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// The exchange-rate table orders are converted with. Public, like the catalog itself.
+func (r *queryResolver) ExchangeRates(ctx context.Context) ([]*ExchangeRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rates, err := r.server.catalogClient.ListExchangeRates(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*ExchangeRate{}
+	for _, rate := range rates {
+		res = append(res, toExchangeRate(rate.Rate, &rate.UpdatedAt))
+	}
+	return res, nil
+}
+
+// Every promotion, newest first (admin only).
+func (r *queryResolver) Promotions(ctx context.Context) ([]*Promotion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	promotions, err := r.server.orderClient.ListPromotions(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*Promotion{}
+	for _, p := range promotions {
+		res = append(res, toPromotion(p))
+	}
+	return res, nil
+}
+
+// Every tax rule by country, region and tax class (admin only).
+func (r *queryResolver) TaxRules(ctx context.Context) ([]*TaxRule, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rules, err := r.server.orderClient.ListTaxRules(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*TaxRule{}
+	for _, rule := range rules {
+		res = append(res, toTaxRule(rule))
+	}
+	return res, nil
+}
+
+// Active shipping methods that ship to country, or everywhere if country is null. Public, so customers can pick
+// one at checkout; only admins may include the inactive ones.
+func (r *queryResolver) ShippingMethods(ctx context.Context, country *string, includeInactive *bool) ([]*ShippingMethod, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	methods, err := r.server.orderClient.ListShippingMethods(ctx, deref(country), includeInactive != nil && *includeInactive)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*ShippingMethod{}
+	for _, m := range methods {
+		res = append(res, toShippingMethod(m))
+	}
+	return res, nil
+}
+
+// Ledger entries of an order, of an account's orders, or both, oldest first (admin only).
+func (r *queryResolver) LedgerEntries(ctx context.Context, orderID *string, accountID *string) ([]*LedgerEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	entries, err := r.server.orderClient.ListLedgerEntries(ctx, deref(orderID), deref(accountID))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*LedgerEntry{}
+	for _, e := range entries {
+		res = append(res, toLedgerEntry(e))
+	}
+	return res, nil
+}
+
+// Checks the ledger against the orders created in [from, to); a null bound leaves the range open (admin only).
+func (r *queryResolver) LedgerReconciliation(ctx context.Context, from *time.Time, to *time.Time) (*LedgerReconciliation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var after, before time.Time
+	if from != nil {
+		after = *from
+	}
+	if to != nil {
+		before = *to
+	}
+	rec, err := r.server.orderClient.ReconcileLedger(ctx, after, before)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := &LedgerReconciliation{
+		From:              from,
+		To:                to,
+		Orders:            rec.Orders,
+		Balanced:          rec.Balanced(),
+		Mismatches:        []*LedgerMismatch{},
+		UnbalancedEntries: rec.UnbalancedEntries,
+	}
+	for _, m := range rec.Mismatches {
+		res.Mismatches = append(res.Mismatches, &LedgerMismatch{
+			OrderID:  m.OrderID,
+			Account:  LedgerAccount(strings.ToUpper(m.Account)),
+			Expected: toMoney(m.Expected),
+			Actual:   toMoney(m.Actual),
+		})
+	}
+	return res, nil
+}
+
+// Every return in status, or all of them if status is null, oldest first (admin only).
+func (r *queryResolver) Returns(ctx context.Context, status *ReturnStatus) ([]*Return, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	s := ""
+	if status != nil {
+		s = strings.ToLower(string(*status))
+	}
+	returns, err := r.server.orderClient.ListReturns(ctx, s)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*Return{}
+	for _, ret := range returns {
+		res = append(res, toReturn(ret))
+	}
+	return res, nil
+}
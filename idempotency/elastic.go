@@ -0,0 +1,118 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	elastic "gopkg.in/olivere/elastic.v5"
+)
+
+const elasticIndex = "idempotency"
+
+// Stores records as documents in their own index, one per key. Index-with-create and versioned writes
+// give the same "first attempt wins" guarantee as the Postgres primary key.
+type elasticStore struct {
+	client *elastic.Client
+}
+
+type recordDocument struct {
+	RequestHash string    `json:"request_hash"`
+	Token       string    `json:"token"`
+	Response    []byte    `json:"response,omitempty"`
+	Failure     []byte    `json:"failure,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewElasticStore(url string) (Store, error) {
+	client, err := elastic.NewClient(
+		elastic.SetURL(url),
+		elastic.SetSniff(false),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &elasticStore{client}, nil
+}
+
+func (s *elasticStore) Reserve(ctx context.Context, key string, requestHash string, token string) (*Record, error) {
+	now := time.Now().UTC()
+	doc := recordDocument{RequestHash: requestHash, Token: token, CreatedAt: now}
+	_, err := s.client.Index().Index(elasticIndex).Type("key").Id(key).OpType("create").BodyJson(doc).Do(ctx)
+	if err == nil {
+		return nil, nil
+	}
+	if !elastic.IsConflict(err) {
+		return nil, err
+	}
+
+	res, err := s.client.Get().Index(elasticIndex).Type("key").Id(key).Do(ctx)
+	if elastic.IsNotFound(err) { // released in the meantime
+		return s.Reserve(ctx, key, requestHash, token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	stored := recordDocument{}
+	if err := json.Unmarshal(*res.Source, &stored); err != nil {
+		return nil, err
+	}
+	rec := &Record{RequestHash: stored.RequestHash, Response: stored.Response, Failure: stored.Failure}
+	if !expired(rec, stored.CreatedAt, now) {
+		return rec, nil
+	}
+	// Take the key over, unless another attempt got there first.
+	_, err = s.client.Index().Index(elasticIndex).Type("key").Id(key).Version(*res.Version).BodyJson(doc).Do(ctx)
+	if elastic.IsConflict(err) {
+		return &Record{RequestHash: requestHash}, nil // someone else is running it now
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *elasticStore) Complete(ctx context.Context, key string, token string, response []byte, failure []byte) error {
+	version, ok, err := s.heldBy(ctx, key, token)
+	if err != nil || !ok {
+		return err
+	}
+	_, err = s.client.Update().Index(elasticIndex).Type("key").Id(key).Version(version).
+		Doc(map[string]interface{}{"response": response, "failure": failure}).
+		Do(ctx)
+	if elastic.IsConflict(err) {
+		return nil // taken over in the meantime
+	}
+	return err
+}
+
+func (s *elasticStore) Release(ctx context.Context, key string, token string) error {
+	version, ok, err := s.heldBy(ctx, key, token)
+	if err != nil || !ok {
+		return err
+	}
+	_, err = s.client.Delete().Index(elasticIndex).Type("key").Id(key).Version(version).Do(ctx)
+	if elastic.IsConflict(err) || elastic.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Reports whether the key is still held, unfinished, by the attempt with token, and the version to write it at.
+func (s *elasticStore) heldBy(ctx context.Context, key string, token string) (int64, bool, error) {
+	res, err := s.client.Get().Index(elasticIndex).Type("key").Id(key).Do(ctx)
+	if elastic.IsNotFound(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	stored := recordDocument{}
+	if err := json.Unmarshal(*res.Source, &stored); err != nil {
+		return 0, false, err
+	}
+	if stored.Token != token || stored.Response != nil || stored.Failure != nil {
+		return 0, false, nil
+	}
+	return *res.Version, true, nil
+}
@@ -0,0 +1,157 @@
+// Makes create calls safe to retry. A client sends the same idempotency key with every attempt of one logical
+// request; the first attempt runs and its response is stored, later attempts get that response back instead of
+// creating a second order, account or product.
+
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"Microservices-based-E-commerce-System/auth"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	MaxKeyLength = 255
+	KeyTTL       = 24 * time.Hour  // how long a completed key is remembered
+	LockTimeout  = 1 * time.Minute // after this an unfinished attempt is considered dead and the key can be retried
+)
+
+// Returned as gRPC status errors, so services can hand them to clients unchanged.
+var (
+	ErrKeyReused  = status.Error(codes.AlreadyExists, "idempotency key was already used for a different request")
+	ErrInProgress = status.Error(codes.Aborted, "a request with this idempotency key is still in progress, retry later")
+	ErrInvalidKey = status.Error(codes.InvalidArgument, "idempotency key must be at most 255 characters long")
+)
+
+// What is remembered about a key.
+type Record struct {
+	RequestHash string
+	Response    []byte // marshalled response; nil while the first attempt is still running
+	Failure     []byte // marshalled status of a committed failure (see Committed); nil otherwise
+}
+
+func (r *Record) finished() bool {
+	return r.Response != nil || r.Failure != nil
+}
+
+// Persists idempotency records.
+type Store interface {
+	// Claims key for a request with the given hash on behalf of the attempt identified by token. Returns nil if
+	// the key is new (or expired, or its previous attempt died) and now belongs to the caller; otherwise returns
+	// what is stored for it.
+	Reserve(ctx context.Context, key string, requestHash string, token string) (*Record, error)
+	// Stores the outcome of the attempt: the response of a successful one, or the status of a committed failure.
+	// Does nothing if the key no longer belongs to the attempt.
+	Complete(ctx context.Context, key string, token string, response []byte, failure []byte) error
+	// Forgets a key whose attempt failed, so the request can be retried with the same key.
+	// Does nothing if the key no longer belongs to the attempt.
+	Release(ctx context.Context, key string, token string) error
+}
+
+type committedError struct {
+	err error
+}
+
+func (e committedError) Error() string { return e.err.Error() }
+func (e committedError) Unwrap() error { return e.err }
+
+// Marks the error of an attempt that failed after making changes that stand, such as a payment that was
+// taken but could not be given back. Instead of releasing the key, Do stores the error as the key's outcome,
+// so retries get it back rather than making those changes again. err should be a gRPC status error;
+// anything else is remembered as Unknown.
+func Committed(err error) error {
+	return committedError{err}
+}
+
+// Runs handle at most once per key. handle must fill in resp.
+// If the key was used before with the same request, the stored response is copied into resp and handle is not called;
+// a different request under the same key fails with ErrKeyReused. An empty key runs handle unconditionally.
+// A failed attempt releases the key, unless its error was marked with Committed.
+// Keys are scoped to the gRPC method and the calling account, so two callers can't collide on (or read) each other's keys.
+func Do(ctx context.Context, s Store, method string, key string, req proto.Message, resp proto.Message, handle func() error) error {
+	if key == "" {
+		return handle()
+	}
+	if len(key) > MaxKeyLength {
+		return ErrInvalidKey
+	}
+	caller := ""
+	if claims, ok := auth.FromContext(ctx); ok {
+		caller = claims.AccountID()
+	}
+	scopedKey := method + "|" + caller + "|" + key
+
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(b)
+	hash := hex.EncodeToString(sum[:])
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	rec, err := s.Reserve(ctx, scopedKey, hash, token)
+	if err != nil {
+		return err
+	}
+	if rec != nil {
+		if rec.RequestHash != hash {
+			return ErrKeyReused
+		}
+		if rec.Failure != nil {
+			st := &spb.Status{}
+			if err := proto.Unmarshal(rec.Failure, st); err != nil {
+				return err
+			}
+			return status.ErrorProto(st)
+		}
+		if rec.Response == nil {
+			return ErrInProgress
+		}
+		return proto.Unmarshal(rec.Response, resp)
+	}
+
+	// The attempt must finish bookkeeping even if the client gives up and cancels ctx.
+	bookkeeping := context.WithoutCancel(ctx)
+	if err := handle(); err != nil {
+		var committed committedError
+		if !errors.As(err, &committed) {
+			s.Release(bookkeeping, scopedKey, token)
+			return err
+		}
+		b, marshalErr := proto.Marshal(status.Convert(committed.err).Proto())
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if completeErr := s.Complete(bookkeeping, scopedKey, token, nil, b); completeErr != nil {
+			return completeErr
+		}
+		return committed.err
+	}
+	b, err = proto.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return s.Complete(bookkeeping, scopedKey, token, b, nil)
+}
+
+// A random token identifying one attempt.
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,104 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// Schema expected by the Postgres store (also part of each service's up.sql):
+//
+//	CREATE TABLE IF NOT EXISTS idempotency_keys (
+//	    key VARCHAR(400) PRIMARY KEY,
+//	    request_hash CHAR(64) NOT NULL,
+//	    token CHAR(32) NOT NULL,
+//	    response BYTEA,
+//	    failure BYTEA,
+//	    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+//	);
+type postgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(url string) (Store, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+	return &postgresStore{db}, nil
+}
+
+func (s *postgresStore) Reserve(ctx context.Context, key string, requestHash string, token string) (*Record, error) {
+	now := time.Now().UTC()
+	res, err := s.db.ExecContext(ctx,
+		"INSERT INTO idempotency_keys(key, request_hash, token, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (key) DO NOTHING",
+		key, requestHash, token, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 1 {
+		return nil, nil
+	}
+
+	rec := &Record{}
+	var createdAt time.Time
+	err = s.db.QueryRowContext(ctx,
+		"SELECT request_hash, response, failure, created_at FROM idempotency_keys WHERE key = $1",
+		key,
+	).Scan(&rec.RequestHash, &rec.Response, &rec.Failure, &createdAt)
+	if err == sql.ErrNoRows { // released in the meantime
+		return s.Reserve(ctx, key, requestHash, token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !expired(rec, createdAt, now) {
+		return rec, nil
+	}
+	// Take the key over, unless another attempt got there first.
+	res, err = s.db.ExecContext(ctx,
+		"UPDATE idempotency_keys SET request_hash = $2, token = $3, response = NULL, failure = NULL, created_at = $4 WHERE key = $1 AND created_at = $5",
+		key, requestHash, token, now, createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return &Record{RequestHash: requestHash}, nil // someone else is running it now
+	}
+	return nil, nil
+}
+
+func (s *postgresStore) Complete(ctx context.Context, key string, token string, response []byte, failure []byte) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE idempotency_keys SET response = $3, failure = $4 WHERE key = $1 AND token = $2",
+		key, token, response, failure,
+	)
+	return err
+}
+
+func (s *postgresStore) Release(ctx context.Context, key string, token string) error {
+	_, err := s.db.ExecContext(ctx,
+		"DELETE FROM idempotency_keys WHERE key = $1 AND token = $2 AND response IS NULL AND failure IS NULL",
+		key, token,
+	)
+	return err
+}
+
+// Finished keys are kept for KeyTTL; unfinished ones for LockTimeout.
+func expired(rec *Record, createdAt time.Time, now time.Time) bool {
+	if !rec.finished() {
+		return now.Sub(createdAt) > LockTimeout
+	}
+	return now.Sub(createdAt) > KeyTTL
+}
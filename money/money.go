@@ -0,0 +1,182 @@
+// Exact representation of amounts of money: an integer number of minor units (cents) plus an ISO 4217 currency code.
+// Prices and totals are never held in floats, so adding up an order can't pick up rounding errors.
+
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currency of amounts stored before currencies were recorded.
+const DefaultCurrency = "USD"
+
+var (
+	ErrInvalidCurrency  = errors.New("currency must be a 3-letter ISO 4217 code")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrCurrencyMismatch = errors.New("cannot combine amounts in different currencies")
+	ErrOverflow         = errors.New("amount out of range")
+)
+
+type Money struct {
+	Amount   int64  // minor units, e.g. 1999 for 19.99 EUR
+	Currency string // ISO 4217 code, e.g. "EUR"
+}
+
+// Number of minor-unit digits of currencies that don't use the usual two.
+var exponents = map[string]int{
+	"JPY": 0, "KRW": 0, "CLP": 0, "ISK": 0, "VND": 0,
+	"BHD": 3, "KWD": 3, "OMR": 3, "JOD": 3, "TND": 3,
+}
+
+// Returns how many digits the currency has after the decimal point.
+func Exponent(currency string) int {
+	if e, ok := exponents[currency]; ok {
+		return e
+	}
+	return 2
+}
+
+func ValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalizes a currency code to upper case and checks it.
+func NormalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !ValidCurrency(currency) {
+		return "", ErrInvalidCurrency
+	}
+	return currency, nil
+}
+
+func New(amount int64, currency string) Money {
+	return Money{amount, currency}
+}
+
+func Zero(currency string) Money {
+	return Money{0, currency}
+}
+
+// Parses a decimal amount in major units ("19.99", "-5", "0.5") exactly.
+// More fractional digits than the currency has are rejected rather than rounded.
+func Parse(amount string, currency string) (Money, error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+	whole, frac, _ := strings.Cut(amount, ".")
+	exp := Exponent(currency)
+	if whole == "" || len(frac) > exp || !digits(whole) || !digits(frac) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrOverflow, amount)
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{minor, currency}, nil
+}
+
+// Converts a float amount in major units, rounding to the nearest minor unit.
+// Only meant for reading old data that was stored as floats.
+func FromFloat(amount float64, currency string) Money {
+	return Money{int64(math.Round(amount * math.Pow10(Exponent(currency)))), currency}
+}
+
+// Formats the amount in major units with exactly the currency's number of decimals, e.g. "19.90".
+func (m Money) Decimal() string {
+	exp := Exponent(m.Currency)
+	sign := ""
+	abs := uint64(m.Amount)
+	if m.Amount < 0 {
+		sign = "-"
+		abs = uint64(-m.Amount)
+	}
+	s := strconv.FormatUint(abs, 10)
+	if exp == 0 {
+		return sign + s
+	}
+	if len(s) <= exp {
+		s = strings.Repeat("0", exp-len(s)+1) + s
+	}
+	return sign + s[:len(s)-exp] + "." + s[len(s)-exp:]
+}
+
+// e.g. "19.90 EUR"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Adds two amounts of the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	sum := m.Amount + o.Amount
+	if (o.Amount > 0 && sum < m.Amount) || (o.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{sum, m.Currency}, nil
+}
+
+// Subtracts an amount of the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{-o.Amount, o.Currency})
+}
+
+// Multiplies the amount by a whole quantity, e.g. a unit price by the number of units.
+func (m Money) Mul(quantity int64) (Money, error) {
+	if quantity != 0 && (m.Amount > math.MaxInt64/abs(quantity) || m.Amount < math.MinInt64/abs(quantity)) {
+		return Money{}, ErrOverflow
+	}
+	return Money{m.Amount * quantity, m.Currency}, nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
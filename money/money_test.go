@@ -0,0 +1,130 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Amounts are read exactly in the currency's minor units; digits the currency doesn't have are an error.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     Money
+		wantErr  error
+	}{
+		{"19.99", "USD", Money{1999, "USD"}, nil},
+		{"19.9", "USD", Money{1990, "USD"}, nil},
+		{" 0.5 ", "eur", Money{50, "EUR"}, nil},
+		{"-5", "USD", Money{-500, "USD"}, nil},
+		{"0", "USD", Money{0, "USD"}, nil},
+		{"19.999", "USD", Money{}, ErrInvalidAmount},
+		{"1500", "JPY", Money{1500, "JPY"}, nil},
+		{"1500.", "JPY", Money{1500, "JPY"}, nil},
+		{"1500.5", "JPY", Money{}, ErrInvalidAmount},
+		{"1.234", "KWD", Money{1234, "KWD"}, nil},
+		{"1.2", "KWD", Money{1200, "KWD"}, nil},
+		{"1.2345", "KWD", Money{}, ErrInvalidAmount},
+		{".5", "USD", Money{}, ErrInvalidAmount},
+		{"", "USD", Money{}, ErrInvalidAmount},
+		{"--1", "USD", Money{}, ErrInvalidAmount},
+		{"1e3", "USD", Money{}, ErrInvalidAmount},
+		{"1,00", "USD", Money{}, ErrInvalidAmount},
+		{"92233720368547758.07", "USD", Money{math.MaxInt64, "USD"}, nil},
+		{"92233720368547758.08", "USD", Money{}, ErrOverflow},
+		{"1", "US", Money{}, ErrInvalidCurrency},
+		{"1", "U$D", Money{}, ErrInvalidCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			got, err := Parse(tt.amount, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if err == nil {
+				if again, _ := Parse(got.Decimal(), got.Currency); again != got {
+					t.Errorf("%q parses as %+v, want it to round-trip", got.Decimal(), again)
+				}
+			}
+		})
+	}
+}
+
+// Conversions round half away from zero to the minor unit of the target currency, whatever its number of digits.
+func TestConvert(t *testing.T) {
+	rate := func(from, to, value string) Rate {
+		r, err := ParseRate(from, to, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	tests := []struct {
+		name    string
+		rate    Rate
+		amount  Money
+		want    Money
+		wantErr error
+	}{
+		{"rounded down", rate("EUR", "USD", "1.0853"), Money{1000, "EUR"}, Money{1085, "USD"}, nil},
+		{"rounded up", rate("EUR", "USD", "1.0856"), Money{1000, "EUR"}, Money{1086, "USD"}, nil},
+		{"half rounded up", rate("USD", "EUR", "1.5"), Money{3, "USD"}, Money{5, "EUR"}, nil},
+		{"negative half rounded down", rate("USD", "EUR", "1.5"), Money{-3, "USD"}, Money{-5, "EUR"}, nil},
+		{"just under a half", rate("USD", "EUR", "1.49999999"), Money{1, "USD"}, Money{1, "EUR"}, nil},
+		{"zero", rate("USD", "EUR", "0.9"), Money{0, "USD"}, Money{0, "EUR"}, nil},
+		{"into a currency without decimals", rate("USD", "JPY", "151.5"), Money{1999, "USD"}, Money{3028, "JPY"}, nil},
+		{"from a currency without decimals", rate("JPY", "USD", "0.0066"), Money{1000, "JPY"}, Money{660, "USD"}, nil},
+		{"into a currency with three decimals", rate("USD", "KWD", "0.3075"), Money{1001, "USD"}, Money{3078, "KWD"}, nil},
+		{"from a currency with three decimals", rate("KWD", "USD", "3.25"), Money{1, "KWD"}, Money{0, "USD"}, nil},
+		{"three decimals into none, under a half", rate("KWD", "JPY", "490.12345678"), Money{1, "KWD"}, Money{0, "JPY"}, nil},
+		{"three decimals into none, over a half", rate("KWD", "JPY", "490.12345678"), Money{2, "KWD"}, Money{1, "JPY"}, nil},
+		{"amount in another currency", rate("EUR", "USD", "1.1"), Money{100, "GBP"}, Money{}, ErrCurrencyMismatch},
+		{"out of range", rate("USD", "JPY", "151.5"), Money{math.MaxInt64, "USD"}, Money{}, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rate.Convert(tt.amount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%s of %s: got %+v, want %+v", tt.rate, tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+// Rates only go one way: the rate into a currency doesn't stand in for the one out of it.
+func TestFindRate(t *testing.T) {
+	eurToUSD := Rate{"EUR", "USD", 108530000}
+	usdToJPY := Rate{"USD", "JPY", 15150000000}
+	rates := []Rate{eurToUSD, usdToJPY}
+	tests := []struct {
+		name     string
+		rates    []Rate
+		from, to string
+		want     Rate
+		wantErr  error
+	}{
+		{"first", rates, "EUR", "USD", eurToUSD, nil},
+		{"second", rates, "USD", "JPY", usdToJPY, nil},
+		{"other way round", rates, "USD", "EUR", Rate{}, ErrNoRate},
+		{"not chained", rates, "EUR", "JPY", Rate{}, ErrNoRate},
+		{"no rates", nil, "EUR", "USD", Rate{}, ErrNoRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindRate(tt.rates, tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
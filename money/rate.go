@@ -0,0 +1,102 @@
+// Exchange rates between currencies, held as fixed-point decimals so conversions stay exact up to the final rounding.
+
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Number of decimals an exchange rate is kept with, e.g. 1.08512345.
+const RateDecimals = 8
+
+var (
+	ErrInvalidRate = errors.New("exchange rate must be a positive decimal with at most 8 decimals")
+	ErrNoRate      = errors.New("no exchange rate")
+)
+
+// One unit of From buys Value / 10^RateDecimals units of To.
+type Rate struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value int64  `json:"value"` // rate × 10^RateDecimals
+}
+
+// Parses a decimal rate such as "1.0853" between two currencies.
+func ParseRate(from, to, rate string) (Rate, error) {
+	from, err := NormalizeCurrency(from)
+	if err != nil {
+		return Rate{}, err
+	}
+	to, err = NormalizeCurrency(to)
+	if err != nil {
+		return Rate{}, err
+	}
+	if from == to {
+		return Rate{}, fmt.Errorf("%w: %s to itself", ErrInvalidRate, from)
+	}
+	whole, frac, _ := strings.Cut(strings.TrimSpace(rate), ".")
+	if whole == "" || len(frac) > RateDecimals || !digits(whole) || !digits(frac) {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+	frac += strings.Repeat("0", RateDecimals-len(frac))
+	value, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil || value <= 0 {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+	return Rate{from, to, value}, nil
+}
+
+// Formats the rate as a decimal without trailing zeros, e.g. "1.0853".
+func (r Rate) Decimal() string {
+	s := strconv.FormatInt(r.Value, 10)
+	if len(s) <= RateDecimals {
+		s = strings.Repeat("0", RateDecimals-len(s)+1) + s
+	}
+	whole, frac := s[:len(s)-RateDecimals], strings.TrimRight(s[len(s)-RateDecimals:], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+// e.g. "1 EUR = 1.0853 USD"
+func (r Rate) String() string {
+	return fmt.Sprintf("1 %s = %s %s", r.From, r.Decimal(), r.To)
+}
+
+// Converts an amount in r.From into r.To, rounding half away from zero to the nearest minor unit of r.To.
+func (r Rate) Convert(m Money) (Money, error) {
+	if m.Currency != r.From {
+		return Money{}, fmt.Errorf("%w: rate is for %s, amount is in %s", ErrCurrencyMismatch, r.From, m.Currency)
+	}
+	// minor(To) = minor(From) × value × 10^exp(To) / (10^RateDecimals × 10^exp(From))
+	num := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(r.Value))
+	num.Mul(num, pow10(Exponent(r.To)))
+	den := new(big.Int).Mul(pow10(RateDecimals), pow10(Exponent(r.From)))
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	if !q.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{q.Int64(), r.To}, nil
+}
+
+// Finds the rate from one currency into another in rates.
+func FindRate(rates []Rate, from, to string) (Rate, error) {
+	for _, r := range rates {
+		if r.From == from && r.To == to {
+			return r, nil
+		}
+	}
+	return Rate{}, fmt.Errorf("%w from %s to %s", ErrNoRate, from, to)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
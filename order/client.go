@@ -1,6 +1,8 @@
 package order
 
 import (
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/money"
 	"Microservices-based-E-commerce-System/order/pb"
 	"context"
 	"time"
@@ -14,7 +16,7 @@ type Client struct {
 }
 
 func NewClient(url string) (*Client, error) {
-	conn, err := grpc.Dial(url, grpc.WithInsecure())
+	conn, err := grpc.Dial(url, grpc.WithInsecure(), grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor()))
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +28,9 @@ func (c *Client) Close() {
 	c.conn.Close()
 }
 
-func (c *Client) PostOrder(ctx context.Context, accountID string, products []OrderedProduct) (*Order, error) {
+// Places an order. shippingMethodID, couponCodes and idempotencyKey are optional; retries with the same key return
+// the order placed first.
+func (c *Client) PostOrder(ctx context.Context, accountID, addressID, shippingMethodID, currency string, products []OrderedProduct, couponCodes []string, idempotencyKey string) (*Order, error) {
 	protoProducts := []*pb.PostOrderRequest_OrderProduct{}
 	for _, p := range products {
 		protoProducts = append(protoProducts, &pb.PostOrderRequest_OrderProduct{
@@ -35,54 +39,459 @@ func (c *Client) PostOrder(ctx context.Context, accountID string, products []Ord
 		})
 	}
 	r, err := c.service.PostOrder(ctx, &pb.PostOrderRequest{
-		AccountId: accountID,
-		Products:  protoProducts,
+		AccountId:        accountID,
+		Products:         protoProducts,
+		AddressId:        addressID,
+		ShippingMethodId: shippingMethodID,
+		Currency:         currency,
+		CouponCodes:      couponCodes,
+		IdempotencyKey:   idempotencyKey,
 	})
 	if err != nil {
 		return nil, err
 	}
-	newOrder := r.Order
-	newOrderCreatedAt := time.Time{}
-	newOrderCreatedAt.UnmarshalBinary(newOrder.CreatedAt)
+	o := orderFromProto(r.Order)
+	return &o, nil
+}
 
-	return &Order{
-		ID:         newOrder.Id,
-		CreatedAt:  newOrderCreatedAt,
-		AccountID:  newOrder.AccountId,
-		TotalPrice: newOrder.TotalPrice,
-		Products:   products,
-	}, nil
+func (c *Client) GetOrder(ctx context.Context, id string) (*Order, error) {
+	r, err := c.service.GetOrder(ctx, &pb.GetOrderRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	o := orderFromProto(r.Order)
+	return &o, nil
+}
+
+// Moves an order to a new status (admin only). note is stored in the order's status history.
+func (c *Client) UpdateOrderStatus(ctx context.Context, id string, status string, note string) (*Order, error) {
+	r, err := c.service.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{
+		Id:     id,
+		Status: status,
+		Note:   note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	o := orderFromProto(r.Order)
+	return &o, nil
 }
 
-func (c *Client) GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error) {
+// Cancels an order that hasn't shipped yet; reason is optional.
+func (c *Client) CancelOrder(ctx context.Context, id string, reason string) (*Order, error) {
+	r, err := c.service.CancelOrder(ctx, &pb.CancelOrderRequest{
+		Id:     id,
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	o := orderFromProto(r.Order)
+	return &o, nil
+}
+
+// Fetches a page of an account's orders, newest first.
+func (c *Client) GetOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter, skip uint64, take uint64) ([]Order, error) {
 	r, err := c.service.GetOrdersForAccount(ctx, &pb.GetOrdersForAccountRequest{
 		AccountId: accountID,
+		Skip:      skip,
+		Take:      take,
+		Filter:    filterToProto(filter),
 	})
 	if err != nil {
 		return nil, err
 	}
 	orders := []Order{}
 	for _, orderProto := range r.Orders {
-		newOrder := Order{
-			ID:         orderProto.Id,
-			AccountID:  orderProto.AccountId,
-			TotalPrice: orderProto.TotalPrice,
+		orders = append(orders, orderFromProto(orderProto))
+	}
+	return orders, nil
+}
+
+// Fetches a page of an account's orders after the given cursor ("" for the first page).
+func (c *Client) ListOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter, after string, first uint64) (*OrderPage, error) {
+	r, err := c.service.ListOrdersForAccount(ctx, &pb.ListOrdersForAccountRequest{
+		AccountId: accountID,
+		After:     after,
+		First:     first,
+		Filter:    filterToProto(filter),
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := &OrderPage{
+		Edges:       []OrderEdge{},
+		HasNextPage: r.HasNextPage,
+		TotalCount:  r.TotalCount,
+	}
+	for _, e := range r.Edges {
+		page.Edges = append(page.Edges, OrderEdge{
+			Cursor: e.Cursor,
+			Order:  orderFromProto(e.Order),
+		})
+	}
+	return page, nil
+}
+
+// Creates a promotion (admin only). It is active right away.
+func (c *Client) CreatePromotion(ctx context.Context, p Promotion) (*Promotion, error) {
+	r, err := c.service.CreatePromotion(ctx, &pb.CreatePromotionRequest{Promotion: promotionToProto(p)})
+	if err != nil {
+		return nil, err
+	}
+	return promotionFromClient(r.Promotion), nil
+}
+
+// Lists every promotion, newest first (admin only).
+func (c *Client) ListPromotions(ctx context.Context) ([]Promotion, error) {
+	r, err := c.service.ListPromotions(ctx, &pb.ListPromotionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	promotions := []Promotion{}
+	for _, p := range r.Promotions {
+		promotions = append(promotions, *promotionFromClient(p))
+	}
+	return promotions, nil
+}
+
+// Switches a promotion on or off (admin only).
+func (c *Client) SetPromotionActive(ctx context.Context, id string, active bool) (*Promotion, error) {
+	r, err := c.service.SetPromotionActive(ctx, &pb.SetPromotionActiveRequest{Id: id, Active: active})
+	if err != nil {
+		return nil, err
+	}
+	return promotionFromClient(r.Promotion), nil
+}
+
+// Promotions sent by the order service always decode.
+func promotionFromClient(pp *pb.Promotion) *Promotion {
+	p, _ := promotionFromProto(pp)
+	return &p
+}
+
+// Sets the tax rate for a tax class in a country, or in one region of it (admin only).
+// rate is a percentage, e.g. "19" or "8.875".
+func (c *Client) SetTaxRule(ctx context.Context, country, region, taxClass, rate string) (*TaxRule, error) {
+	r, err := c.service.SetTaxRule(ctx, &pb.SetTaxRuleRequest{
+		Country:  country,
+		Region:   region,
+		TaxClass: taxClass,
+		Rate:     rate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rule := taxRuleFromProto(r.Rule)
+	return &rule, nil
+}
+
+// Lists every tax rule by country, region and tax class (admin only).
+func (c *Client) ListTaxRules(ctx context.Context) ([]TaxRule, error) {
+	r, err := c.service.ListTaxRules(ctx, &pb.ListTaxRulesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	rules := []TaxRule{}
+	for _, rule := range r.Rules {
+		rules = append(rules, taxRuleFromProto(rule))
+	}
+	return rules, nil
+}
+
+// Deletes a tax rule (admin only).
+func (c *Client) DeleteTaxRule(ctx context.Context, id string) error {
+	_, err := c.service.DeleteTaxRule(ctx, &pb.DeleteTaxRuleRequest{Id: id})
+	return err
+}
+
+// Rates sent by the order service always parse.
+func taxRuleFromProto(pr *pb.TaxRule) TaxRule {
+	rule := TaxRule{
+		ID:       pr.Id,
+		Country:  pr.Country,
+		Region:   pr.Region,
+		TaxClass: pr.TaxClass,
+	}
+	rule.Rate, _ = ParseTaxRate(pr.Rate)
+	rule.UpdatedAt.UnmarshalBinary(pr.UpdatedAt)
+	return rule
+}
+
+// Creates a shipping method (admin only). It is active right away.
+func (c *Client) CreateShippingMethod(ctx context.Context, m ShippingMethod) (*ShippingMethod, error) {
+	r, err := c.service.CreateShippingMethod(ctx, &pb.CreateShippingMethodRequest{Method: shippingMethodToProto(m)})
+	if err != nil {
+		return nil, err
+	}
+	method := shippingMethodFromProto(r.Method)
+	return &method, nil
+}
+
+// Lists the active shipping methods that ship to country (any country if empty), by name.
+// includeInactive only has an effect for admins.
+func (c *Client) ListShippingMethods(ctx context.Context, country string, includeInactive bool) ([]ShippingMethod, error) {
+	r, err := c.service.ListShippingMethods(ctx, &pb.ListShippingMethodsRequest{Country: country, IncludeInactive: includeInactive})
+	if err != nil {
+		return nil, err
+	}
+	methods := []ShippingMethod{}
+	for _, m := range r.Methods {
+		methods = append(methods, shippingMethodFromProto(m))
+	}
+	return methods, nil
+}
+
+// Switches a shipping method on or off (admin only).
+func (c *Client) SetShippingMethodActive(ctx context.Context, id string, active bool) (*ShippingMethod, error) {
+	r, err := c.service.SetShippingMethodActive(ctx, &pb.SetShippingMethodActiveRequest{Id: id, Active: active})
+	if err != nil {
+		return nil, err
+	}
+	method := shippingMethodFromProto(r.Method)
+	return &method, nil
+}
+
+// Records a shipment of a fulfilled or shipped order (admin only).
+func (c *Client) CreateShipment(ctx context.Context, orderID, carrier, trackingNumber string) (*Shipment, error) {
+	r, err := c.service.CreateShipment(ctx, &pb.CreateShipmentRequest{
+		OrderId:        orderID,
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sh := shipmentFromProto(r.Shipment)
+	return &sh, nil
+}
+
+// Records a status reported by the carrier (admin only).
+func (c *Client) UpdateShipmentStatus(ctx context.Context, id, status, note string) (*Shipment, error) {
+	r, err := c.service.UpdateShipmentStatus(ctx, &pb.UpdateShipmentStatusRequest{Id: id, Status: status, Note: note})
+	if err != nil {
+		return nil, err
+	}
+	sh := shipmentFromProto(r.Shipment)
+	return &sh, nil
+}
+
+// Asks to send back units of a delivered order. Only Quantity and ProductID of the lines are used.
+func (c *Client) RequestReturn(ctx context.Context, orderID string, lines []ReturnLine, reason string) (*Return, error) {
+	req := &pb.RequestReturnRequest{OrderId: orderID, Lines: []*pb.Return_Line{}, Reason: reason}
+	for _, l := range lines {
+		req.Lines = append(req.Lines, &pb.Return_Line{ProductId: l.ProductID, Quantity: l.Quantity})
+	}
+	r, err := c.service.RequestReturn(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	ret := returnFromProto(r.Return)
+	return &ret, nil
+}
+
+// Lists every return in status (all of them if empty), oldest first (admin only).
+func (c *Client) ListReturns(ctx context.Context, status string) ([]Return, error) {
+	r, err := c.service.ListReturns(ctx, &pb.ListReturnsRequest{Status: status})
+	if err != nil {
+		return nil, err
+	}
+	returns := []Return{}
+	for _, ret := range r.Returns {
+		returns = append(returns, returnFromProto(ret))
+	}
+	return returns, nil
+}
+
+// Approves a requested return and refunds it (admin only).
+func (c *Client) ApproveReturn(ctx context.Context, id, note string) (*Return, error) {
+	r, err := c.service.ApproveReturn(ctx, &pb.ApproveReturnRequest{Id: id, Note: note})
+	if err != nil {
+		return nil, err
+	}
+	ret := returnFromProto(r.Return)
+	return &ret, nil
+}
+
+// Rejects a requested return (admin only).
+func (c *Client) RejectReturn(ctx context.Context, id, note string) (*Return, error) {
+	r, err := c.service.RejectReturn(ctx, &pb.RejectReturnRequest{Id: id, Note: note})
+	if err != nil {
+		return nil, err
+	}
+	ret := returnFromProto(r.Return)
+	return &ret, nil
+}
+
+// Records that an approved return arrived, putting its units back into stock with restock (admin only).
+func (c *Client) ReceiveReturn(ctx context.Context, id string, restock bool, note string) (*Return, error) {
+	r, err := c.service.ReceiveReturn(ctx, &pb.ReceiveReturnRequest{Id: id, Restock: restock, Note: note})
+	if err != nil {
+		return nil, err
+	}
+	ret := returnFromProto(r.Return)
+	return &ret, nil
+}
+
+// Refunds units of the order's lines (only their ProductID and Quantity are used) or, without lines, amount,
+// to destination: RefundToPayment or RefundToStoreCredit (admin only).
+func (c *Client) RefundOrder(ctx context.Context, orderID string, lines []RefundLine, amount *money.Money, destination, reason string) (*OrderRefund, error) {
+	req := &pb.RefundOrderRequest{OrderId: orderID, Lines: []*pb.OrderRefund_Line{}, Destination: destination, Reason: reason}
+	for _, l := range lines {
+		req.Lines = append(req.Lines, &pb.OrderRefund_Line{ProductId: l.ProductID, Quantity: l.Quantity})
+	}
+	if amount != nil {
+		req.Amount = moneyToProto(*amount)
+	}
+	r, err := c.service.RefundOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	ref := refundFromProto(r.Refund)
+	return &ref, nil
+}
+
+// Ledger entries of an order, or of every order of an account, oldest first (admin only).
+func (c *Client) ListLedgerEntries(ctx context.Context, orderID, accountID string) ([]LedgerEntry, error) {
+	r, err := c.service.ListLedgerEntries(ctx, &pb.ListLedgerEntriesRequest{OrderId: orderID, AccountId: accountID})
+	if err != nil {
+		return nil, err
+	}
+	entries := []LedgerEntry{}
+	for _, e := range r.Entries {
+		entries = append(entries, ledgerEntryFromProto(e))
+	}
+	return entries, nil
+}
+
+// Store credit of the account, one amount per currency.
+func (c *Client) GetStoreCredit(ctx context.Context, accountID string) ([]money.Money, error) {
+	r, err := c.service.GetStoreCredit(ctx, &pb.GetStoreCreditRequest{AccountId: accountID})
+	if err != nil {
+		return nil, err
+	}
+	credit := []money.Money{}
+	for _, m := range r.Credit {
+		credit = append(credit, moneyFromProto(m))
+	}
+	return credit, nil
+}
+
+// Checks the ledger against the orders created in [from, to); zero times leave the range open (admin only).
+func (c *Client) ReconcileLedger(ctx context.Context, from, to time.Time) (*Reconciliation, error) {
+	req := &pb.ReconcileLedgerRequest{}
+	if !from.IsZero() {
+		req.From, _ = from.MarshalBinary()
+	}
+	if !to.IsZero() {
+		req.To, _ = to.MarshalBinary()
+	}
+	r, err := c.service.ReconcileLedger(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rec := &Reconciliation{
+		From:              from,
+		To:                to,
+		Orders:            int(r.Orders),
+		Mismatches:        []LedgerMismatch{},
+		UnbalancedEntries: r.UnbalancedEntries,
+	}
+	if rec.UnbalancedEntries == nil {
+		rec.UnbalancedEntries = []string{}
+	}
+	for _, m := range r.Mismatches {
+		rec.Mismatches = append(rec.Mismatches, LedgerMismatch{
+			OrderID:  m.OrderId,
+			Account:  m.Account,
+			Expected: moneyFromProto(m.Expected),
+			Actual:   moneyFromProto(m.Actual),
+		})
+	}
+	return rec, nil
+}
+
+func orderFromProto(orderProto *pb.Order) Order {
+	newOrder := Order{
+		ID:                 orderProto.Id,
+		AccountID:          orderProto.AccountId,
+		Subtotal:           moneyFromProto(orderProto.Subtotal),
+		Discount:           moneyFromProto(orderProto.DiscountTotal),
+		Tax:                moneyFromProto(orderProto.TaxTotal),
+		Shipping:           moneyFromProto(orderProto.ShippingTotal),
+		TotalPrice:         moneyFromProto(orderProto.TotalPrice),
+		PricesIncludeTax:   orderProto.PricesIncludeTax,
+		ShippingAddress:    addressFromProto(orderProto.ShippingAddress),
+		ShippingMethodID:   orderProto.ShippingMethodId,
+		ShippingMethodName: orderProto.ShippingMethodName,
+		Shipments:          []Shipment{},
+		Returns:            []Return{},
+		Refunds:            []OrderRefund{},
+		Status:             orderProto.Status,
+		StatusHistory:      []StatusChange{},
+	}
+	for _, sh := range orderProto.Shipments {
+		newOrder.Shipments = append(newOrder.Shipments, shipmentFromProto(sh))
+	}
+	for _, ret := range orderProto.Returns {
+		newOrder.Returns = append(newOrder.Returns, returnFromProto(ret))
+	}
+	for _, ref := range orderProto.Refunds {
+		newOrder.Refunds = append(newOrder.Refunds, refundFromProto(ref))
+	}
+	newOrder.CreatedAt = time.Time{}
+	newOrder.CreatedAt.UnmarshalBinary(orderProto.CreatedAt)
+	for _, c := range orderProto.StatusHistory {
+		change := StatusChange{
+			From:      c.From,
+			To:        c.To,
+			ChangedBy: c.ChangedBy,
+			Note:      c.Note,
+		}
+		change.ChangedAt.UnmarshalBinary(c.ChangedAt)
+		newOrder.StatusHistory = append(newOrder.StatusHistory, change)
+	}
+	if c := orderProto.Cancellation; c != nil {
+		newOrder.Cancellation = &Cancellation{
+			Reason:      c.Reason,
+			RefundDue:   moneyFromProto(c.RefundDue),
+			CancelledBy: c.CancelledBy,
+		}
+		newOrder.Cancellation.CancelledAt.UnmarshalBinary(c.CancelledAt)
+	}
+
+	products := []OrderedProduct{}
+	for _, p := range orderProto.Products {
+		line := OrderedProduct{
+			ID:          p.Id,
+			Name:        p.Name,
+			Description: p.Description,
+			Price:       moneyFromProto(p.Price),
+			Quantity:    p.Quantity,
+			Discounts:   []LineDiscount{},
+			TaxClass:    p.TaxClass,
+			Tax:         moneyFromProto(p.Tax),
+			Weight:      p.Weight,
 		}
-		newOrder.CreatedAt = time.Time{}
-		newOrder.CreatedAt.UnmarshalBinary(orderProto.CreatedAt)
-
-		products := []OrderedProduct{}
-		for _, p := range orderProto.Products {
-			products = append(products, OrderedProduct{
-				ID:          p.Id,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-				Quantity:    p.Quantity,
+		line.TaxRate, _ = ParseTaxRate(p.TaxRate)
+		for _, d := range p.Discounts {
+			line.Discounts = append(line.Discounts, LineDiscount{
+				PromotionID: d.PromotionId,
+				Code:        d.Code,
+				Description: d.Description,
+				Amount:      moneyFromProto(d.Amount),
 			})
 		}
-		newOrder.Products = products
-		orders = append(orders, newOrder)
+		products = append(products, line)
 	}
-	return orders, nil
+	newOrder.Products = products
+	newOrder.ExchangeRates = []money.Rate{}
+	for _, r := range orderProto.ExchangeRates {
+		rate, err := money.ParseRate(r.From, r.To, r.Rate)
+		if err != nil {
+			continue // written by the order service itself, so this doesn't happen
+		}
+		newOrder.ExchangeRates = append(newOrder.ExchangeRates, rate)
+	}
+	return newOrder
 }
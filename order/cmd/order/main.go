@@ -1,7 +1,10 @@
 package main
 
 import (
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/idempotency"
 	"Microservices-based-E-commerce-System/order"
+	"context"
 	"log"
 	"time"
 
@@ -13,6 +16,17 @@ type Config struct {
 	DatabaseURL string `envconfig:"DATABASE_URL"`
 	AccountURL  string `envconfig:"ACCOUNT_SERVICE_URL"`
 	CatalogURL  string `envconfig:"CATALOG_SERVICE_URL"`
+	PaymentURL  string `envconfig:"PAYMENT_SERVICE_URL"` // optional; without it orders are placed unpaid
+	JWTSecret   string `envconfig:"JWT_SECRET" required:"true"`
+
+	MaxLineQuantity  uint32 `envconfig:"MAX_LINE_QUANTITY" default:"100"`    // units of one product per order
+	MaxOrderQuantity uint32 `envconfig:"MAX_ORDER_QUANTITY" default:"1000"`  // units across all lines of an order
+	PricesIncludeTax bool   `envconfig:"PRICES_INCLUDE_TAX" default:"false"` // catalog prices already contain tax
+
+	ReturnWindow time.Duration `envconfig:"RETURN_WINDOW" default:"720h"` // how long after delivery orders can be returned
+
+	SagaResumeInterval   time.Duration `envconfig:"SAGA_RESUME_INTERVAL" default:"30s"`
+	RefundResumeInterval time.Duration `envconfig:"REFUND_RESUME_INTERVAL" default:"1m"`
 }
 
 func main() {
@@ -30,7 +44,57 @@ func main() {
 		return
 	})
 	defer r.Close()
+	keys, err := idempotency.NewPostgresStore(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tokens := auth.NewTokenManager(cfg.JWTSecret)
+	inventory, err := order.NewCatalogInventory(cfg.CatalogURL, tokens)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var payments order.Payments
+	if cfg.PaymentURL != "" {
+		if payments, err = order.NewPaymentServicePayments(cfg.PaymentURL, tokens); err != nil {
+			log.Fatal(err)
+		}
+	}
 	log.Println("Listening on port 8080...")
-	s := order.NewService(r)
-	log.Fatal(order.ListenGRPC(s, cfg.AccountURL, cfg.CatalogURL, 8080))
+	s := order.NewService(r, order.Limits{
+		MaxLineQuantity:  cfg.MaxLineQuantity,
+		MaxOrderQuantity: cfg.MaxOrderQuantity,
+	}, cfg.PricesIncludeTax, cfg.ReturnWindow, inventory, payments)
+	go resumeSagas(s, cfg.SagaResumeInterval)
+	go resumeRefunds(s, cfg.RefundResumeInterval)
+	log.Fatal(order.ListenGRPC(s, tokens, keys, cfg.AccountURL, cfg.CatalogURL, 8080))
+}
+
+// Finishes the checkouts of orders that were left halfway (e.g. by a restart), until the process exits.
+// The first run happens right away.
+func resumeSagas(s order.Service, interval time.Duration) {
+	for ; ; time.Sleep(interval) {
+		n, err := s.ResumeSagas(context.Background())
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("finished %d interrupted checkouts", n)
+		}
+	}
+}
+
+// Finishes refunds to the payment that were left pending (e.g. the payment service was unavailable), until the
+// process exits.
+func resumeRefunds(s order.Service, interval time.Duration) {
+	for ; ; time.Sleep(interval) {
+		n, err := s.ResumeRefunds(context.Background())
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("finished %d pending refunds", n)
+		}
+	}
 }
@@ -0,0 +1,116 @@
+// Domain errors of the order service and their translation to gRPC status codes.
+
+package order
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrNotFound          = errors.New("order not found")
+	ErrAccountNotFound   = errors.New("account not found or closed")
+	ErrAddressNotFound   = errors.New("shipping address not found")
+	ErrProductsNotFound  = errors.New("products not found")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrInvalidFilter     = errors.New("invalid order filter")
+	ErrUnavailable       = errors.New("a required service is unavailable, try again later")
+	ErrMixedCurrencies   = errors.New("all products of an order must be priced in the same currency")
+	ErrInvalidCurrency   = errors.New("currency must be a 3-letter ISO 4217 code")
+	ErrNoExchangeRate    = errors.New("no exchange rate into the order's currency")
+	ErrReservationFailed = errors.New("stock for the order could not be secured, try again")
+)
+
+// Converts an error returned by the service or one of its dependencies into a gRPC status error.
+// Errors that already carry a status (e.g. PermissionDenied from auth) are passed through;
+// anything unexpected is logged and reported as Internal without leaking details.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var lineErr *LineError
+	if errors.As(err, &lineErr) {
+		return lineErrorStatus(lineErr)
+	}
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrPromotionNotFound), errors.Is(err, ErrTaxRuleNotFound),
+		errors.Is(err, ErrShippingMethodNotFound), errors.Is(err, ErrShipmentNotFound), errors.Is(err, ErrReturnNotFound),
+		errors.Is(err, ErrRefundNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrPromotionExists), errors.Is(err, ErrShipmentExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrAddressNotFound), errors.Is(err, ErrProductsNotFound), errors.Is(err, ErrInvalidCursor),
+		errors.Is(err, ErrInvalidFilter), errors.Is(err, ErrInvalidStatus),
+		errors.Is(err, ErrEmptyOrder), errors.Is(err, ErrInvalidOrderLines),
+		errors.Is(err, ErrMixedCurrencies), errors.Is(err, money.ErrOverflow), errors.Is(err, ErrInvalidCurrency),
+		errors.Is(err, ErrInvalidPromotion), errors.Is(err, ErrInvalidTaxRule), errors.Is(err, ErrInvalidShippingMethod),
+		errors.Is(err, ErrInvalidShipment), errors.Is(err, ErrInvalidShipmentStatus), errors.Is(err, ErrInvalidReturn),
+		errors.Is(err, ErrInvalidRefund):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrAccountNotFound), errors.Is(err, ErrInvalidTransition),
+		errors.Is(err, ErrAlreadyCancelled), errors.Is(err, ErrNotCancellable), errors.Is(err, ErrNoExchangeRate),
+		errors.Is(err, ErrPaymentDeclined), errors.Is(err, ErrInvalidCoupon), errors.Is(err, ErrShippingUnavailable),
+		errors.Is(err, ErrOrderNotReadyToShip), errors.Is(err, ErrShipmentFinal), errors.Is(err, ErrNotReturnable),
+		errors.Is(err, ErrReturnWindowClosed), errors.Is(err, ErrInvalidReturnTransition), errors.Is(err, ErrNothingToRefund),
+		errors.Is(err, ErrRefundRefused), errors.Is(err, ErrNotRefundable), errors.Is(err, ErrNoPayments):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrStatusConflict), errors.Is(err, ErrReservationFailed), errors.Is(err, ErrShipmentStatusConflict),
+		errors.Is(err, ErrReturnConflict), errors.Is(err, ErrCheckoutInProgress):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, ErrUnavailable):
+		log.Println(err)
+		return status.Error(codes.Unavailable, ErrUnavailable.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+		log.Println(err)
+		return status.Error(codes.Unavailable, "order database unavailable")
+	}
+	log.Println(err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// InvalidArgument with a BadRequest detail naming each offending line, so clients can point at the exact lines.
+func lineErrorStatus(e *LineError) error {
+	br := &errdetails.BadRequest{}
+	for _, v := range e.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fmt.Sprintf("products[%d]", v.Index),
+			Description: fmt.Sprintf("%s: %s", v.ProductID, v.Reason),
+		})
+	}
+	st, err := status.New(codes.InvalidArgument, e.Error()).WithDetails(br)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, e.Error())
+	}
+	return st.Err()
+}
+
+// Interprets an error from the account, catalog or payment service: NotFound becomes notFound,
+// connection problems become ErrUnavailable, and anything else (e.g. PermissionDenied) is kept as is.
+func upstreamError(err error, notFound error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return notFound
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return err
+}
@@ -0,0 +1,506 @@
+// In-memory stand-ins for the order service's dependencies, for tests that don't need a database or other services.
+
+package order
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Inventory for tests. Products listed in stock have that many units; products that aren't listed have
+// unlimited stock. With failCommit set, committing a reservation fails as if it had expired; the next failRestocks
+// restocks fail as if the catalog were down.
+type fakeInventory struct {
+	mu           sync.Mutex
+	stock        map[string]uint32
+	reservations map[string]string // reservation id -> "held", "committed" or "released"
+	lines        map[string][]OrderedProduct
+	failCommit   bool
+	failRestocks int
+}
+
+func newFakeInventory(stock map[string]uint32) *fakeInventory {
+	return &fakeInventory{stock: stock, reservations: map[string]string{}, lines: map[string][]OrderedProduct{}}
+}
+
+func (i *fakeInventory) Reserve(ctx context.Context, products []OrderedProduct) (string, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	short := []string{}
+	for _, p := range products {
+		if units, ok := i.stock[p.ID]; ok && units < p.Quantity {
+			short = append(short, p.ID)
+		}
+	}
+	if len(short) > 0 {
+		return "", &StockError{short}
+	}
+	for _, p := range products {
+		if units, ok := i.stock[p.ID]; ok {
+			i.stock[p.ID] = units - p.Quantity
+		}
+	}
+	id := ksuid.New().String()
+	i.reservations[id] = "held"
+	i.lines[id] = products
+	return id, nil
+}
+
+func (i *fakeInventory) Commit(ctx context.Context, reservationID string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.failCommit {
+		return fmt.Errorf("reservation %s expired", reservationID)
+	}
+	switch i.reservations[reservationID] {
+	case "held", "committed":
+		i.reservations[reservationID] = "committed"
+		return nil
+	}
+	return fmt.Errorf("reservation %s cannot be committed", reservationID)
+}
+
+func (i *fakeInventory) Release(ctx context.Context, reservationID string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	switch i.reservations[reservationID] {
+	case "held":
+		for _, p := range i.lines[reservationID] {
+			if units, ok := i.stock[p.ID]; ok {
+				i.stock[p.ID] = units + p.Quantity
+			}
+		}
+		i.reservations[reservationID] = "released"
+		return nil
+	case "released":
+		return nil
+	}
+	return fmt.Errorf("reservation %s cannot be released", reservationID)
+}
+
+func (i *fakeInventory) Restock(ctx context.Context, products []OrderedProduct) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.failRestocks > 0 {
+		i.failRestocks--
+		return fmt.Errorf("restocking: %w", ErrUnavailable)
+	}
+	for _, p := range products {
+		if units, ok := i.stock[p.ID]; ok {
+			i.stock[p.ID] = units + p.Quantity
+		}
+	}
+	return nil
+}
+
+// The status of every reservation made, in no particular order.
+func (i *fakeInventory) reservationStatuses() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	statuses := []string{}
+	for _, status := range i.reservations {
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Payments for tests, moving no money. Like the payment service's fake provider it decides by the amount alone:
+// totals whose minor units end in 02 are declined, everything else is authorized. The next failCaptures captures
+// fail as if the payment service were down. The next failRefunds refunds are made, but fail as if the answer got
+// lost; with refuseRefunds set, refunds are refused with it.
+type fakePayments struct {
+	mu            sync.Mutex
+	statuses      map[string]string      // payment id -> "authorized", "captured" or "voided"
+	refunds       map[string]money.Money // order id -> sum of its refunds
+	refundKeys    map[string]bool        // idempotency keys of the refunds made
+	failCaptures  int
+	failRefunds   int
+	refuseRefunds error
+}
+
+func newFakePayments() *fakePayments {
+	return &fakePayments{statuses: map[string]string{}, refunds: map[string]money.Money{}, refundKeys: map[string]bool{}}
+}
+
+func (p *fakePayments) Authorize(ctx context.Context, o Order, idempotencyKey string) (string, error) {
+	if o.TotalPrice.Amount%100 == 2 {
+		return "", fmt.Errorf("%w: insufficient funds", ErrPaymentDeclined)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	id := "fake_payment_" + idempotencyKey
+	if _, ok := p.statuses[id]; !ok {
+		p.statuses[id] = "authorized"
+	}
+	return id, nil
+}
+
+func (p *fakePayments) Capture(ctx context.Context, paymentID string) error {
+	p.mu.Lock()
+	if p.failCaptures > 0 {
+		p.failCaptures--
+		p.mu.Unlock()
+		return fmt.Errorf("capturing payment %s: %w", paymentID, ErrUnavailable)
+	}
+	p.mu.Unlock()
+	return p.transition(paymentID, "captured")
+}
+
+func (p *fakePayments) Void(ctx context.Context, paymentID string) error {
+	return p.transition(paymentID, "voided")
+}
+
+// Records the refund, once per idempotency key; the fake doesn't check it against what was captured.
+func (p *fakePayments) Refund(ctx context.Context, orderID string, amount money.Money, reason, idempotencyKey string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.refuseRefunds != nil {
+		return fmt.Errorf("%w: refused", p.refuseRefunds)
+	}
+	if !p.refundKeys[idempotencyKey] {
+		p.refundKeys[idempotencyKey] = true
+		refunded, ok := p.refunds[orderID]
+		if !ok {
+			refunded = money.Zero(amount.Currency)
+		}
+		refunded.Amount += amount.Amount
+		p.refunds[orderID] = refunded
+	}
+	if p.failRefunds > 0 {
+		p.failRefunds--
+		return fmt.Errorf("refunding order %s: %w", orderID, ErrUnavailable)
+	}
+	return nil
+}
+
+// Moves an authorized payment to status; repeating the same move is harmless.
+func (p *fakePayments) transition(paymentID string, status string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current, ok := p.statuses[paymentID]
+	if !ok {
+		return fmt.Errorf("unknown payment %s", paymentID)
+	}
+	if current != "authorized" && current != status {
+		return fmt.Errorf("payment %s is %s", paymentID, current)
+	}
+	p.statuses[paymentID] = status
+	return nil
+}
+
+func (p *fakePayments) status(paymentID string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.statuses[paymentID]
+}
+
+// Keeps orders, checkout sagas, refunds and promotions in memory. It only implements what placing, cancelling and
+// refunding orders needs; the other Repository methods are left to the embedded nil interface and panic if called.
+type memoryRepository struct {
+	Repository
+	mu          sync.Mutex
+	orders      map[string]Order
+	sagas       map[string]Saga
+	leases      map[string]time.Time // saga id -> locked until
+	refunds     map[string]OrderRefund
+	returns     map[string]Return
+	promotions  []Promotion
+	redemptions map[string]uint32 // "<promotion id>|<account id>" -> orders that used the promotion
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		orders:      map[string]Order{},
+		sagas:       map[string]Saga{},
+		leases:      map[string]time.Time{},
+		refunds:     map[string]OrderRefund{},
+		returns:     map[string]Return{},
+		redemptions: map[string]uint32{},
+	}
+}
+
+func (r *memoryRepository) GetPromotionsByCode(ctx context.Context, codes []string) ([]Promotion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := []Promotion{}
+	for _, p := range r.promotions {
+		for _, code := range codes {
+			if p.Code == code {
+				found = append(found, p)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryRepository) CountRedemptions(ctx context.Context, promotionID string, accountID string) (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.redemptions[promotionID+"|"+accountID], nil
+}
+
+func (r *memoryRepository) PutOrder(ctx context.Context, o Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.orders[o.ID]; ok {
+		return fmt.Errorf("order %s exists", o.ID)
+	}
+	o.StatusHistory = []StatusChange{{To: o.Status, ChangedAt: o.CreatedAt, ChangedBy: o.AccountID}}
+	r.orders[o.ID] = o
+	return nil
+}
+
+func (r *memoryRepository) GetOrder(ctx context.Context, id string) (*Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o, ok := r.orders[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	o.StatusHistory = append([]StatusChange{}, o.StatusHistory...)
+	o.Refunds = []OrderRefund{}
+	for _, ref := range r.refunds {
+		if ref.OrderID == id {
+			o.Refunds = append(o.Refunds, ref)
+		}
+	}
+	sort.Slice(o.Refunds, func(i, j int) bool { return o.Refunds[i].CreatedAt.Before(o.Refunds[j].CreatedAt) })
+	return &o, nil
+}
+
+func (r *memoryRepository) UpdateOrderStatus(ctx context.Context, id string, change StatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.changeStatus(id, change, nil)
+}
+
+func (r *memoryRepository) CancelOrder(ctx context.Context, id string, change StatusChange, c Cancellation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.changeStatus(id, change, &c)
+}
+
+func (r *memoryRepository) changeStatus(id string, change StatusChange, c *Cancellation) error {
+	o, ok := r.orders[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if o.Status != change.From {
+		return ErrStatusConflict
+	}
+	o.Status = change.To
+	o.StatusHistory = append(append([]StatusChange{}, o.StatusHistory...), change)
+	if c != nil {
+		o.Cancellation = c
+	}
+	r.orders[id] = o
+	return nil
+}
+
+func (r *memoryRepository) CreateSaga(ctx context.Context, s Saga, lockedUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s.Steps = append([]string{}, s.Steps...)
+	r.sagas[s.ID] = s
+	r.leases[s.ID] = lockedUntil
+	return nil
+}
+
+func (r *memoryRepository) UpdateSaga(ctx context.Context, s Saga) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s.Steps = append([]string{}, s.Steps...)
+	r.sagas[s.ID] = s
+	return nil
+}
+
+func (r *memoryRepository) ClaimSagas(ctx context.Context, now time.Time, lockedUntil time.Time, limit int) ([]Saga, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sagas := []Saga{}
+	for id, s := range r.sagas {
+		if s.unfinished() && r.leases[id].Before(now) {
+			s.Steps = append([]string{}, s.Steps...)
+			sagas = append(sagas, s)
+		}
+	}
+	sort.Slice(sagas, func(i, j int) bool { return sagas[i].CreatedAt.Before(sagas[j].CreatedAt) })
+	if len(sagas) > limit {
+		sagas = sagas[:limit]
+	}
+	for _, s := range sagas {
+		r.leases[s.ID] = lockedUntil
+	}
+	return sagas, nil
+}
+
+func (r *memoryRepository) GetSaga(ctx context.Context, id string) (*Saga, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sagas[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	s.Steps = append([]string{}, s.Steps...)
+	return &s, nil
+}
+
+// Lets every saga be claimed again, as if the processes running them had died a while ago.
+func (r *memoryRepository) expireLeases() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.leases {
+		r.leases[id] = time.Time{}
+	}
+}
+
+// Moves the start of every saga back by d, as if their checkouts had begun that long ago.
+func (r *memoryRepository) ageSagas(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, saga := range r.sagas {
+		saga.CreatedAt = saga.CreatedAt.Add(-d)
+		r.sagas[id] = saga
+	}
+}
+
+func (r *memoryRepository) PutRefund(ctx context.Context, ref OrderRefund, claimed map[string]uint32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	refunded := int64(0)
+	for _, other := range r.refunds {
+		if ref.ReturnID != "" && other.ReturnID == ref.ReturnID {
+			return ErrReturnConflict
+		}
+		if other.OrderID == ref.OrderID {
+			refunded += other.Amount.Amount
+		}
+	}
+	if refunded+ref.Amount.Amount > r.orders[ref.OrderID].TotalPrice.Amount {
+		return ErrNotRefundable
+	}
+	r.refunds[ref.ID] = ref
+	if !ref.CompletedAt.IsZero() {
+		return r.markRefunded(ref.OrderID, ref.CompletedAt)
+	}
+	return nil
+}
+
+// Moves a paid order to refunded once its completed refunds come to its total, like the Postgres repository.
+func (r *memoryRepository) markRefunded(orderID string, at time.Time) error {
+	o := r.orders[orderID]
+	refunded := int64(0)
+	for _, ref := range r.refunds {
+		if ref.OrderID == orderID && !ref.CompletedAt.IsZero() {
+			refunded += ref.Amount.Amount
+		}
+	}
+	if refunded < o.TotalPrice.Amount || !isPaid(o.Status) {
+		return nil
+	}
+	return r.changeStatus(orderID, StatusChange{From: o.Status, To: StatusRefunded, ChangedAt: at}, nil)
+}
+
+func (r *memoryRepository) CompleteRefund(ctx context.Context, id string, completedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ref, ok := r.refunds[id]
+	if !ok {
+		return ErrRefundNotFound
+	}
+	if ref.CompletedAt.IsZero() {
+		ref.CompletedAt = completedAt
+		r.refunds[id] = ref
+		return r.markRefunded(ref.OrderID, completedAt)
+	}
+	return nil
+}
+
+func (r *memoryRepository) DeleteRefund(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.refunds[id].CompletedAt.IsZero() {
+		delete(r.refunds, id)
+	}
+	return nil
+}
+
+func (r *memoryRepository) ListPendingRefunds(ctx context.Context, before time.Time) ([]OrderRefund, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	refunds := []OrderRefund{}
+	for _, ref := range r.refunds {
+		if ref.CompletedAt.IsZero() && ref.CreatedAt.Before(before) {
+			refunds = append(refunds, ref)
+		}
+	}
+	sort.Slice(refunds, func(i, j int) bool { return refunds[i].CreatedAt.Before(refunds[j].CreatedAt) })
+	return refunds, nil
+}
+
+// Moves the creation of every refund back by d, as if they had been written that long ago.
+func (r *memoryRepository) ageRefunds(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, ref := range r.refunds {
+		ref.CreatedAt = ref.CreatedAt.Add(-d)
+		r.refunds[id] = ref
+	}
+}
+
+func (r *memoryRepository) PutReturn(ctx context.Context, ret Return, claimed map[string]uint32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret.History = append([]ReturnEvent{}, ret.History...)
+	r.returns[ret.ID] = ret
+	return nil
+}
+
+func (r *memoryRepository) GetReturn(ctx context.Context, id string) (*Return, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret, ok := r.returns[id]
+	if !ok {
+		return nil, ErrReturnNotFound
+	}
+	ret.History = append([]ReturnEvent{}, ret.History...)
+	return &ret, nil
+}
+
+func (r *memoryRepository) UpdateReturnStatus(ctx context.Context, id string, from string, ev ReturnEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret, ok := r.returns[id]
+	if !ok {
+		return ErrReturnNotFound
+	}
+	if ret.Status != from {
+		return ErrReturnConflict
+	}
+	ret.Status = ev.Status
+	ret.History = append(append([]ReturnEvent{}, ret.History...), ev)
+	r.returns[id] = ret
+	return nil
+}
+
+func (r *memoryRepository) SetReturnRestocked(ctx context.Context, id string, restocked bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret, ok := r.returns[id]
+	if !ok {
+		return ErrReturnNotFound
+	}
+	if ret.Restocked == restocked {
+		return ErrReturnConflict
+	}
+	ret.Restocked = restocked
+	r.returns[id] = ret
+	return nil
+}
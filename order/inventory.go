@@ -0,0 +1,114 @@
+// Stock reservations for new orders, and restocking of returned units.
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/catalog"
+)
+
+var ErrOutOfStock = errors.New("not enough stock")
+
+// Holds stock for the lines of an order while it is being placed. Reserve either holds every line or none;
+// the reservation is then committed once the order is written, or released if it couldn't be.
+// Restock puts units that came back (see returns.go) into stock again.
+type Inventory interface {
+	Reserve(ctx context.Context, products []OrderedProduct) (string, error)
+	Commit(ctx context.Context, reservationID string) error
+	Release(ctx context.Context, reservationID string) error
+	Restock(ctx context.Context, products []OrderedProduct) error
+}
+
+// Lists the products Reserve couldn't get enough stock of. errors.Is(err, ErrOutOfStock) holds for it.
+type StockError struct {
+	ProductIDs []string
+}
+
+func (e *StockError) Error() string {
+	return ErrOutOfStock.Error() + ": " + strings.Join(e.ProductIDs, ", ")
+}
+
+func (e *StockError) Is(target error) bool {
+	return target == ErrOutOfStock
+}
+
+// Reservations are held long enough to write the order; if the order service dies in between,
+// the catalog releases them when they expire.
+const reservationTTL = 5 * time.Minute
+
+// Inventory backed by the catalog service. Reservations are internal calls, so they are made with the
+// order service's own token rather than the customer's.
+type catalogInventory struct {
+	client *catalog.Client
+	tokens *auth.TokenManager
+}
+
+func NewCatalogInventory(catalogURL string, tokens *auth.TokenManager) (Inventory, error) {
+	client, err := catalog.NewClient(catalogURL)
+	if err != nil {
+		return nil, err
+	}
+	return &catalogInventory{client, tokens}, nil
+}
+
+func (i *catalogInventory) Reserve(ctx context.Context, products []OrderedProduct) (string, error) {
+	ctx, err := i.tokens.ServiceContext(ctx, "order")
+	if err != nil {
+		return "", err
+	}
+	lines := []catalog.ReservationLine{}
+	for _, p := range products {
+		lines = append(lines, catalog.ReservationLine{ProductID: p.ID, Quantity: p.Quantity})
+	}
+	r, err := i.client.ReserveStock(ctx, lines, reservationTTL)
+	var stockErr *catalog.InsufficientStockError
+	if errors.As(err, &stockErr) {
+		return "", &StockError{stockErr.ProductIDs}
+	}
+	if err != nil {
+		return "", upstreamError(err, ErrProductsNotFound)
+	}
+	return r.ID, nil
+}
+
+func (i *catalogInventory) Commit(ctx context.Context, reservationID string) error {
+	ctx, err := i.tokens.ServiceContext(ctx, "order")
+	if err != nil {
+		return err
+	}
+	if _, err := i.client.CommitReservation(ctx, reservationID); err != nil {
+		return fmt.Errorf("committing reservation %s: %w", reservationID, upstreamError(err, err))
+	}
+	return nil
+}
+
+func (i *catalogInventory) Release(ctx context.Context, reservationID string) error {
+	ctx, err := i.tokens.ServiceContext(ctx, "order")
+	if err != nil {
+		return err
+	}
+	if _, err := i.client.ReleaseReservation(ctx, reservationID); err != nil {
+		return fmt.Errorf("releasing reservation %s: %w", reservationID, upstreamError(err, err))
+	}
+	return nil
+}
+
+// Adds the units back one product at a time; if one fails, the products before it stay restocked.
+func (i *catalogInventory) Restock(ctx context.Context, products []OrderedProduct) error {
+	ctx, err := i.tokens.ServiceContext(ctx, "order")
+	if err != nil {
+		return err
+	}
+	for _, p := range products {
+		if _, err := i.client.AdjustStock(ctx, p.ID, int64(p.Quantity)); err != nil {
+			return fmt.Errorf("restocking %s: %w", p.ID, upstreamError(err, err))
+		}
+	}
+	return nil
+}
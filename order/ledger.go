@@ -0,0 +1,257 @@
+// Ledger: an append-only, double-entry record of the money of every order. An entry moves amounts between ledger
+// accounts; its postings are debits (positive) and credits (negative) that add up to zero, so the ledger as a
+// whole always balances. The repository writes each entry in the same transaction as the change it records:
+//
+//	order_placed     receivable + discounts = sales + tax + shipping   (PutOrder)
+//	charge           payments = receivable                             (the order moves to paid)
+//	order_cancelled  the reverse of order_placed                       (the order is cancelled before it was paid)
+//	refund           refunds = payments or store_credit                (the refund completes, see refund.go)
+//
+// Entries are never changed; up.sql rejects updates and deletes. Reconcile checks the ledger against the orders.
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Ledger accounts. Every order has its own balance in each of them.
+const (
+	LedgerReceivable  = "receivable"   // owed by the customer
+	LedgerSales       = "sales"        // earned with the goods, before discounts and without tax
+	LedgerDiscounts   = "discounts"    // taken off by coupons
+	LedgerTax         = "tax"          // owed to the tax authorities
+	LedgerShipping    = "shipping"     // earned with shipping
+	LedgerPayments    = "payments"     // paid by the customer and not given back
+	LedgerRefunds     = "refunds"      // given back to the customer
+	LedgerStoreCredit = "store_credit" // owed to the customer as credit; the account's balance is in StoreCredit
+)
+
+// Every ledger account, in the order reports list them.
+var ledgerAccounts = []string{
+	LedgerReceivable, LedgerSales, LedgerDiscounts, LedgerTax, LedgerShipping, LedgerPayments, LedgerRefunds, LedgerStoreCredit,
+}
+
+const (
+	EntryOrderPlaced    = "order_placed"
+	EntryCharge         = "charge"
+	EntryOrderCancelled = "order_cancelled"
+	EntryRefund         = "refund"
+)
+
+var ErrUnbalancedEntry = errors.New("ledger entry does not balance")
+
+type LedgerEntry struct {
+	ID          string
+	Kind        string
+	OrderID     string
+	AccountID   string // account that placed the order
+	RefundID    string // the refund recorded, empty unless Kind is EntryRefund
+	Description string
+	Postings    []Posting
+	CreatedAt   time.Time
+}
+
+// One debit (positive Amount) or credit (negative Amount) of a ledger account.
+type Posting struct {
+	Account string
+	Amount  money.Money
+}
+
+// Reports whether the postings add up to zero in every currency.
+func (e LedgerEntry) Balanced() bool {
+	sums := map[string]int64{}
+	for _, p := range e.Postings {
+		sums[p.Amount.Currency] += p.Amount.Amount
+	}
+	for _, sum := range sums {
+		if sum != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Builds an entry of the order. Postings of zero are left out, so an entry can end up without postings;
+// such an entry is not written.
+func newLedgerEntry(kind string, o Order, description string, at time.Time, postings ...Posting) LedgerEntry {
+	e := LedgerEntry{
+		ID:          ksuid.New().String(),
+		Kind:        kind,
+		OrderID:     o.ID,
+		AccountID:   o.AccountID,
+		Description: description,
+		Postings:    []Posting{},
+		CreatedAt:   at,
+	}
+	for _, p := range postings {
+		if !p.Amount.IsZero() {
+			e.Postings = append(e.Postings, p)
+		}
+	}
+	return e
+}
+
+func debit(account string, amount money.Money) Posting {
+	return Posting{account, amount}
+}
+
+func credit(account string, amount money.Money) Posting {
+	return Posting{account, money.New(-amount.Amount, amount.Currency)}
+}
+
+// The customer owes the order's total; the goods (without any tax they contained), tax and shipping are earned,
+// and the discounts are what they cost.
+func placementEntry(o Order) LedgerEntry {
+	sales := o.Subtotal
+	if o.PricesIncludeTax {
+		sales.Amount -= o.Tax.Amount
+	}
+	return newLedgerEntry(EntryOrderPlaced, o, "order placed", o.CreatedAt,
+		debit(LedgerReceivable, o.TotalPrice),
+		debit(LedgerDiscounts, o.Discount),
+		credit(LedgerSales, sales),
+		credit(LedgerTax, o.Tax),
+		credit(LedgerShipping, o.Shipping),
+	)
+}
+
+// The customer paid the whole total.
+func chargeEntry(o Order, at time.Time) LedgerEntry {
+	return newLedgerEntry(EntryCharge, o, "order paid", at,
+		debit(LedgerPayments, o.TotalPrice),
+		credit(LedgerReceivable, o.TotalPrice),
+	)
+}
+
+// An order cancelled before it was paid owes nothing and earned nothing.
+func cancellationEntry(o Order, at time.Time) LedgerEntry {
+	e := placementEntry(o)
+	c := newLedgerEntry(EntryOrderCancelled, o, "order cancelled before it was paid", at)
+	for _, p := range e.Postings {
+		c.Postings = append(c.Postings, credit(p.Account, p.Amount))
+	}
+	return c
+}
+
+// Money given back, taken from what was paid or owed to the customer as store credit.
+func refundEntry(o Order, ref OrderRefund) LedgerEntry {
+	from := LedgerPayments
+	if ref.Destination == RefundToStoreCredit {
+		from = LedgerStoreCredit
+	}
+	description := "refund"
+	if ref.Reason != "" {
+		description += ": " + ref.Reason
+	}
+	e := newLedgerEntry(EntryRefund, o, description, ref.CompletedAt,
+		debit(LedgerRefunds, ref.Amount),
+		credit(from, ref.Amount),
+	)
+	e.RefundID = ref.ID
+	return e
+}
+
+// What the ledger of one order should follow from and what it holds, as read by LedgerBalances.
+type OrderLedger struct {
+	Order           Order                  // only the id, account and amounts are filled in
+	Paid            bool                   // the order moved to paid at some point
+	CancelledUnpaid bool                   // the order was cancelled while it was pending
+	Refunded        map[string]money.Money // sum of the order's completed refunds, by destination
+	Balances        map[string]money.Money // sum of the order's postings, by ledger account
+}
+
+// Result of Reconcile. The ledger agrees with the orders if both lists are empty.
+type Reconciliation struct {
+	From              time.Time // orders created from then on were checked; zero for all of them
+	To                time.Time // orders created before then were checked; zero for all of them
+	Orders            int       // how many orders were checked
+	Mismatches        []LedgerMismatch
+	UnbalancedEntries []string // ids of ledger entries whose postings don't add up to zero
+}
+
+func (r Reconciliation) Balanced() bool {
+	return len(r.Mismatches) == 0 && len(r.UnbalancedEntries) == 0
+}
+
+// A ledger account of an order whose balance isn't what the order says it should be.
+type LedgerMismatch struct {
+	OrderID  string
+	Account  string
+	Expected money.Money // following from the order's totals, payment, cancellation and refunds
+	Actual   money.Money // the balance in the ledger
+}
+
+// The balance of each ledger account of the order if every change was recorded: the order's totals unless it was
+// cancelled before it was paid, its total paid if it was, and its refunds.
+func expectedBalances(l OrderLedger) map[string]int64 {
+	expected := map[string]int64{}
+	add := func(e LedgerEntry) {
+		for _, p := range e.Postings {
+			expected[p.Account] += p.Amount.Amount
+		}
+	}
+	if !l.CancelledUnpaid {
+		add(placementEntry(l.Order))
+	}
+	if l.Paid {
+		add(chargeEntry(l.Order, time.Time{}))
+	}
+	for destination, amount := range l.Refunded {
+		add(refundEntry(l.Order, OrderRefund{Amount: amount, Destination: destination}))
+	}
+	return expected
+}
+
+// Entries of an order, or of every order of an account, oldest first. At least one of the ids must be given.
+func (s orderService) ListLedgerEntries(ctx context.Context, orderID, accountID string) ([]LedgerEntry, error) {
+	if orderID == "" && accountID == "" {
+		return nil, fmt.Errorf("%w: an order or account id is required", ErrInvalidFilter)
+	}
+	return s.repository.ListLedgerEntries(ctx, orderID, accountID)
+}
+
+// Store credit the account holds, one amount per currency; empty if it has none.
+func (s orderService) GetStoreCredit(ctx context.Context, accountID string) ([]money.Money, error) {
+	return s.repository.StoreCredit(ctx, accountID)
+}
+
+// Checks that every ledger entry balances and that the ledger balances of every order created in [from, to)
+// are those its totals, status and refunds call for. Zero times leave the range open.
+func (s orderService) Reconcile(ctx context.Context, from, to time.Time) (*Reconciliation, error) {
+	unbalanced, err := s.repository.UnbalancedLedgerEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ledgers, err := s.repository.LedgerBalances(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	rec := &Reconciliation{From: from, To: to, Orders: len(ledgers), Mismatches: []LedgerMismatch{}, UnbalancedEntries: unbalanced}
+	for _, l := range ledgers {
+		expected := expectedBalances(l)
+		currency := l.Order.TotalPrice.Currency
+		for _, account := range ledgerAccounts {
+			actual := l.Balances[account]
+			if actual.Currency == "" {
+				actual = money.Zero(currency)
+			}
+			if actual.Amount != expected[account] || actual.Currency != currency {
+				rec.Mismatches = append(rec.Mismatches, LedgerMismatch{
+					OrderID:  l.Order.ID,
+					Account:  account,
+					Expected: money.New(expected[account], currency),
+					Actual:   actual,
+				})
+			}
+		}
+	}
+	return rec, nil
+}
@@ -0,0 +1,155 @@
+// Payment of new orders, and refunds of returns.
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/money"
+	paymentpb "Microservices-based-E-commerce-System/payment/pb"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrPaymentDeclined = errors.New("payment was declined")
+	ErrNothingToRefund = errors.New("the order's payment is refunded in full already")
+	ErrRefundRefused   = errors.New("the payment service refused the refund")
+)
+
+// Takes the payment for an order while it is being placed. Authorize holds the order's total and returns the id of
+// the payment; it is then captured once the order is placed, or voided if it couldn't be. Authorizing twice with the
+// same idempotency key returns the same payment. A declined payment returns an error wrapping ErrPaymentDeclined.
+// Refund gives amount of what was captured for an order back to the customer; refunding again with the same
+// idempotency key refunds once. An error wrapping ErrNothingToRefund means nothing was refunded as the payment is
+// refunded in full already; one wrapping ErrRefundRefused means the refund was refused for another reason.
+type Payments interface {
+	Authorize(ctx context.Context, o Order, idempotencyKey string) (string, error)
+	Capture(ctx context.Context, paymentID string) error
+	Void(ctx context.Context, paymentID string) error
+	Refund(ctx context.Context, orderID string, amount money.Money, reason, idempotencyKey string) error
+}
+
+// Payments backed by the payment service, called with the order service's own token.
+// It talks to the service through its generated client, as the payment package itself depends on this one.
+type paymentServicePayments struct {
+	client paymentpb.PaymentServiceClient
+	tokens *auth.TokenManager
+}
+
+func NewPaymentServicePayments(paymentURL string, tokens *auth.TokenManager) (Payments, error) {
+	conn, err := grpc.Dial(paymentURL, grpc.WithInsecure(), grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor()))
+	if err != nil {
+		return nil, err
+	}
+	return &paymentServicePayments{paymentpb.NewPaymentServiceClient(conn), tokens}, nil
+}
+
+func (p *paymentServicePayments) Authorize(ctx context.Context, o Order, idempotencyKey string) (string, error) {
+	ctx, err := p.tokens.ServiceContext(ctx, "order")
+	if err != nil {
+		return "", err
+	}
+	r, err := p.client.AuthorizePayment(ctx, &paymentpb.AuthorizePaymentRequest{
+		OrderId:        o.ID,
+		AccountId:      o.AccountID,
+		Amount:         &paymentpb.Money{Amount: o.TotalPrice.Amount, Currency: o.TotalPrice.Currency},
+		IdempotencyKey: idempotencyKey,
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		return "", fmt.Errorf("%w: %s", ErrPaymentDeclined, status.Convert(err).Message())
+	}
+	if err != nil {
+		return "", upstreamError(err, err)
+	}
+	return r.Payment.Id, nil
+}
+
+func (p *paymentServicePayments) Capture(ctx context.Context, paymentID string) error {
+	ctx, err := p.tokens.ServiceContext(ctx, "order")
+	if err != nil {
+		return err
+	}
+	if _, err := p.client.CapturePayment(ctx, &paymentpb.CapturePaymentRequest{Id: paymentID}); err != nil {
+		return fmt.Errorf("capturing payment %s: %w", paymentID, upstreamError(err, err))
+	}
+	return nil
+}
+
+func (p *paymentServicePayments) Void(ctx context.Context, paymentID string) error {
+	ctx, err := p.tokens.ServiceContext(ctx, "order")
+	if err != nil {
+		return err
+	}
+	if _, err := p.client.VoidPayment(ctx, &paymentpb.VoidPaymentRequest{Id: paymentID}); err != nil {
+		return fmt.Errorf("voiding payment %s: %w", paymentID, upstreamError(err, err))
+	}
+	return nil
+}
+
+// Refunds amount from the order's captured payment; an order has at most one payment that went through (the
+// payment service enforces it). Refunding again with the same idempotency key refunds once. If the payment service
+// refuses the refund as the payment is refunded in full, an error wrapping ErrNothingToRefund is returned; if there
+// is no captured payment, or it refuses for any other reason (e.g. as more than is left of the payment), one wrapping
+// ErrRefundRefused.
+func (p *paymentServicePayments) Refund(ctx context.Context, orderID string, amount money.Money, reason, idempotencyKey string) error {
+	ctx, err := p.tokens.ServiceContext(ctx, "order")
+	if err != nil {
+		return err
+	}
+	r, err := p.client.GetPaymentsForOrder(ctx, &paymentpb.GetPaymentsForOrderRequest{OrderId: orderID})
+	if err != nil {
+		return upstreamError(err, err)
+	}
+	paymentID := ""
+	for _, payment := range r.Payments {
+		switch payment.Status {
+		case "captured", "partially_refunded", "refunded":
+			if payment.GetAmount().GetCurrency() == amount.Currency {
+				paymentID = payment.Id
+			}
+		}
+	}
+	if paymentID == "" {
+		return fmt.Errorf("%w: the order has no captured payment in %s", ErrRefundRefused, amount.Currency)
+	}
+	_, err = p.client.RefundPayment(ctx, &paymentpb.RefundPaymentRequest{
+		Id:             paymentID,
+		Amount:         &paymentpb.Money{Amount: amount.Amount, Currency: amount.Currency},
+		Reason:         reason,
+		IdempotencyKey: idempotencyKey,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.FailedPrecondition:
+		if fullyRefunded(err) {
+			return fmt.Errorf("%w: %s", ErrNothingToRefund, status.Convert(err).Message())
+		}
+		return fmt.Errorf("%w: %s", ErrRefundRefused, status.Convert(err).Message())
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrRefundRefused, status.Convert(err).Message())
+	}
+	return fmt.Errorf("refunding payment %s: %w", paymentID, upstreamError(err, err))
+}
+
+// Reports whether the payment service refused a refund because the payment is refunded in full: it says so with
+// a PreconditionFailure violation of this type.
+func fullyRefunded(err error) bool {
+	for _, d := range status.Convert(err).Details() {
+		if pf, ok := d.(*errdetails.PreconditionFailure); ok {
+			for _, v := range pf.Violations {
+				if v.Type == "FULLY_REFUNDED" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
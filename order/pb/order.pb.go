@@ -21,20 +21,188 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Order struct {
+type Address struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt     []byte                 `protobuf:"bytes,2,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
-	AccountId     string                 `protobuf:"bytes,3,opt,name=accountId,proto3" json:"accountId,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,4,opt,name=totalPrice,proto3" json:"totalPrice,omitempty"`
-	Products      []*Order_OrderProduct  `protobuf:"bytes,5,rep,name=products,proto3" json:"products,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Line1         string                 `protobuf:"bytes,3,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,4,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,7,opt,name=postalCode,proto3" json:"postalCode,omitempty"`
+	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Address) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Address) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Address) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *Address) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// An exact amount of money: an integer number of minor units (cents) in an ISO 4217 currency.
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"` // minor units, e.g. 1999 for 19.99 EUR
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Order struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt          []byte                 `protobuf:"bytes,2,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	AccountId          string                 `protobuf:"bytes,3,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Subtotal           *Money                 `protobuf:"bytes,12,opt,name=subtotal,proto3" json:"subtotal,omitempty"`                  // quantity × price of every line, before discounts
+	DiscountTotal      *Money                 `protobuf:"bytes,13,opt,name=discountTotal,proto3" json:"discountTotal,omitempty"`        // sum of the lines' discounts
+	TaxTotal           *Money                 `protobuf:"bytes,14,opt,name=taxTotal,proto3" json:"taxTotal,omitempty"`                  // sum of the lines' tax
+	ShippingTotal      *Money                 `protobuf:"bytes,16,opt,name=shippingTotal,proto3" json:"shippingTotal,omitempty"`        // charged by the shipping method
+	TotalPrice         *Money                 `protobuf:"bytes,10,opt,name=totalPrice,proto3" json:"totalPrice,omitempty"`              // subtotal - discountTotal + shippingTotal, + taxTotal unless pricesIncludeTax
+	PricesIncludeTax   bool                   `protobuf:"varint,15,opt,name=pricesIncludeTax,proto3" json:"pricesIncludeTax,omitempty"` // the line prices already contained their tax
+	ShippingMethodId   string                 `protobuf:"bytes,17,opt,name=shippingMethodId,proto3" json:"shippingMethodId,omitempty"`  // empty if the order was placed without a shipping method
+	ShippingMethodName string                 `protobuf:"bytes,18,opt,name=shippingMethodName,proto3" json:"shippingMethodName,omitempty"`
+	Shipments          []*Shipment            `protobuf:"bytes,19,rep,name=shipments,proto3" json:"shipments,omitempty"` // oldest first
+	Returns            []*Return              `protobuf:"bytes,20,rep,name=returns,proto3" json:"returns,omitempty"`     // oldest first
+	Refunds            []*OrderRefund         `protobuf:"bytes,21,rep,name=refunds,proto3" json:"refunds,omitempty"`     // oldest first
+	Products           []*Order_OrderProduct  `protobuf:"bytes,5,rep,name=products,proto3" json:"products,omitempty"`
+	ShippingAddress    *Address               `protobuf:"bytes,6,opt,name=shippingAddress,proto3" json:"shippingAddress,omitempty"`
+	Status             string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                // pending, paid, fulfilled, shipped, delivered, cancelled or refunded
+	StatusHistory      []*StatusChange        `protobuf:"bytes,8,rep,name=statusHistory,proto3" json:"statusHistory,omitempty"`  // oldest first
+	Cancellation       *Cancellation          `protobuf:"bytes,9,opt,name=cancellation,proto3" json:"cancellation,omitempty"`    // set only for cancelled orders
+	ExchangeRates      []*ExchangeRate        `protobuf:"bytes,11,rep,name=exchangeRates,proto3" json:"exchangeRates,omitempty"` // rates used to convert catalog prices into the order's currency
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_order_proto_msgTypes[0]
+	mi := &file_order_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -46,7 +214,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[0]
+	mi := &file_order_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +227,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{0}
+	return file_order_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Order) GetId() string {
@@ -83,11 +251,81 @@ func (x *Order) GetAccountId() string {
 	return ""
 }
 
-func (x *Order) GetTotalPrice() float64 {
+func (x *Order) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Order) GetDiscountTotal() *Money {
+	if x != nil {
+		return x.DiscountTotal
+	}
+	return nil
+}
+
+func (x *Order) GetTaxTotal() *Money {
+	if x != nil {
+		return x.TaxTotal
+	}
+	return nil
+}
+
+func (x *Order) GetShippingTotal() *Money {
+	if x != nil {
+		return x.ShippingTotal
+	}
+	return nil
+}
+
+func (x *Order) GetTotalPrice() *Money {
 	if x != nil {
 		return x.TotalPrice
 	}
-	return 0
+	return nil
+}
+
+func (x *Order) GetPricesIncludeTax() bool {
+	if x != nil {
+		return x.PricesIncludeTax
+	}
+	return false
+}
+
+func (x *Order) GetShippingMethodId() string {
+	if x != nil {
+		return x.ShippingMethodId
+	}
+	return ""
+}
+
+func (x *Order) GetShippingMethodName() string {
+	if x != nil {
+		return x.ShippingMethodName
+	}
+	return ""
+}
+
+func (x *Order) GetShipments() []*Shipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+func (x *Order) GetReturns() []*Return {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+func (x *Order) GetRefunds() []*OrderRefund {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
 }
 
 func (x *Order) GetProducts() []*Order_OrderProduct {
@@ -97,29 +335,4043 @@ func (x *Order) GetProducts() []*Order_OrderProduct {
 	return nil
 }
 
-type PostOrderRequest struct {
-	state         protoimpl.MessageState           `protogen:"open.v1"`
-	AccountId     string                           `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
-	Products      []*PostOrderRequest_OrderProduct `protobuf:"bytes,4,rep,name=products,proto3" json:"products,omitempty"`
+func (x *Order) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetStatusHistory() []*StatusChange {
+	if x != nil {
+		return x.StatusHistory
+	}
+	return nil
+}
+
+func (x *Order) GetCancellation() *Cancellation {
+	if x != nil {
+		return x.Cancellation
+	}
+	return nil
+}
+
+func (x *Order) GetExchangeRates() []*ExchangeRate {
+	if x != nil {
+		return x.ExchangeRates
+	}
+	return nil
+}
+
+// Part of an order line's price taken off by a coupon.
+type LineDiscount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId   string                 `protobuf:"bytes,1,opt,name=promotionId,proto3" json:"promotionId,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"` // off the whole line, not per unit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineDiscount) Reset() {
+	*x = LineDiscount{}
+	mi := &file_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineDiscount) ProtoMessage() {}
+
+func (x *LineDiscount) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineDiscount.ProtoReflect.Descriptor instead.
+func (*LineDiscount) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LineDiscount) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *LineDiscount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LineDiscount) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LineDiscount) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// Rate at which one unit of "from" converted into "to", as an exact decimal string (e.g. "1.0853").
+type ExchangeRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRate) Reset() {
+	*x = ExchangeRate{}
+	mi := &file_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRate) ProtoMessage() {}
+
+func (x *ExchangeRate) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRate.ProtoReflect.Descriptor instead.
+func (*ExchangeRate) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExchangeRate) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type Cancellation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	RefundDue     *Money                 `protobuf:"bytes,5,opt,name=refundDue,proto3" json:"refundDue,omitempty"` // amount already paid that has to be refunded
+	CancelledAt   []byte                 `protobuf:"bytes,3,opt,name=cancelledAt,proto3" json:"cancelledAt,omitempty"`
+	CancelledBy   string                 `protobuf:"bytes,4,opt,name=cancelledBy,proto3" json:"cancelledBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cancellation) Reset() {
+	*x = Cancellation{}
+	mi := &file_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cancellation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cancellation) ProtoMessage() {}
+
+func (x *Cancellation) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cancellation.ProtoReflect.Descriptor instead.
+func (*Cancellation) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Cancellation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Cancellation) GetRefundDue() *Money {
+	if x != nil {
+		return x.RefundDue
+	}
+	return nil
+}
+
+func (x *Cancellation) GetCancelledAt() []byte {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *Cancellation) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+type StatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // empty for the entry written when the order was placed
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	ChangedAt     []byte                 `protobuf:"bytes,3,opt,name=changedAt,proto3" json:"changedAt,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,4,opt,name=changedBy,proto3" json:"changedBy,omitempty"` // account id of whoever made the change
+	Note          string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusChange) Reset() {
+	*x = StatusChange{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusChange) ProtoMessage() {}
+
+func (x *StatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusChange.ProtoReflect.Descriptor instead.
+func (*StatusChange) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StatusChange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *StatusChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *StatusChange) GetChangedAt() []byte {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *StatusChange) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *StatusChange) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type PostOrderRequest struct {
+	state            protoimpl.MessageState           `protogen:"open.v1"`
+	AccountId        string                           `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Products         []*PostOrderRequest_OrderProduct `protobuf:"bytes,4,rep,name=products,proto3" json:"products,omitempty"`
+	AddressId        string                           `protobuf:"bytes,5,opt,name=addressId,proto3" json:"addressId,omitempty"`               // shipping address of the account; the default one is used when empty
+	IdempotencyKey   string                           `protobuf:"bytes,6,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`     // optional; retries with the same key return the first order instead of placing another
+	Currency         string                           `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`                 // ISO 4217 code to price the order in; defaults to the account's preferred currency
+	CouponCodes      []string                         `protobuf:"bytes,8,rep,name=couponCodes,proto3" json:"couponCodes,omitempty"`           // applied in this order; one that can't be used fails the request
+	ShippingMethodId string                           `protobuf:"bytes,9,opt,name=shippingMethodId,proto3" json:"shippingMethodId,omitempty"` // optional; needs a shipping address the method ships to
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PostOrderRequest) Reset() {
+	*x = PostOrderRequest{}
+	mi := &file_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostOrderRequest) ProtoMessage() {}
+
+func (x *PostOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostOrderRequest.ProtoReflect.Descriptor instead.
+func (*PostOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PostOrderRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PostOrderRequest) GetProducts() []*PostOrderRequest_OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *PostOrderRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+func (x *PostOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PostOrderRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PostOrderRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *PostOrderRequest) GetShippingMethodId() string {
+	if x != nil {
+		return x.ShippingMethodId
+	}
+	return ""
+}
+
+type PostOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostOrderResponse) Reset() {
+	*x = PostOrderResponse{}
+	mi := &file_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostOrderResponse) ProtoMessage() {}
+
+func (x *PostOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostOrderResponse.ProtoReflect.Descriptor instead.
+func (*PostOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PostOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Restricts an account's orders to a creation time range. Empty bounds are ignored.
+type OrderFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAfter  []byte                 `protobuf:"bytes,1,opt,name=createdAfter,proto3" json:"createdAfter,omitempty"`   // inclusive
+	CreatedBefore []byte                 `protobuf:"bytes,2,opt,name=createdBefore,proto3" json:"createdBefore,omitempty"` // exclusive
+	Statuses      []string               `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`           // any of these statuses
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFilter) Reset() {
+	*x = OrderFilter{}
+	mi := &file_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFilter) ProtoMessage() {}
+
+func (x *OrderFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFilter.ProtoReflect.Descriptor instead.
+func (*OrderFilter) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OrderFilter) GetCreatedAfter() []byte {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *OrderFilter) GetCreatedBefore() []byte {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *OrderFilter) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+// Offset-based paging over an account's orders, newest first. take defaults to (and is capped at) 100.
+type GetOrdersForAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Skip          uint64                 `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take          uint64                 `protobuf:"varint,3,opt,name=take,proto3" json:"take,omitempty"`
+	Filter        *OrderFilter           `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersForAccountRequest) Reset() {
+	*x = GetOrdersForAccountRequest{}
+	mi := &file_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersForAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersForAccountRequest) ProtoMessage() {}
+
+func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersForAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersForAccountRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetOrdersForAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetOrdersForAccountRequest) GetSkip() uint64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *GetOrdersForAccountRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *GetOrdersForAccountRequest) GetFilter() *OrderFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type GetOrdersForAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersForAccountResponse) Reset() {
+	*x = GetOrdersForAccountResponse{}
+	mi := &file_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersForAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersForAccountResponse) ProtoMessage() {}
+
+func (x *GetOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersForAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersForAccountResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrdersForAccountResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+// Cursor-based paging over an account's orders, newest first. Pass the cursor of the last edge as "after".
+type ListOrdersForAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	After         string                 `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	First         uint64                 `protobuf:"varint,3,opt,name=first,proto3" json:"first,omitempty"`
+	Filter        *OrderFilter           `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersForAccountRequest) Reset() {
+	*x = ListOrdersForAccountRequest{}
+	mi := &file_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersForAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersForAccountRequest) ProtoMessage() {}
+
+func (x *ListOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersForAccountRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersForAccountRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListOrdersForAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListOrdersForAccountRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *ListOrdersForAccountRequest) GetFirst() uint64 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *ListOrdersForAccountRequest) GetFilter() *OrderFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListOrdersForAccountResponse struct {
+	state         protoimpl.MessageState               `protogen:"open.v1"`
+	Edges         []*ListOrdersForAccountResponse_Edge `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	HasNextPage   bool                                 `protobuf:"varint,2,opt,name=hasNextPage,proto3" json:"hasNextPage,omitempty"`
+	TotalCount    uint64                               `protobuf:"varint,3,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersForAccountResponse) Reset() {
+	*x = ListOrdersForAccountResponse{}
+	mi := &file_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersForAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersForAccountResponse) ProtoMessage() {}
+
+func (x *ListOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersForAccountResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersForAccountResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListOrdersForAccountResponse) GetEdges() []*ListOrdersForAccountResponse_Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *ListOrdersForAccountResponse) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+func (x *ListOrdersForAccountResponse) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// Moves an order along its lifecycle. Illegal transitions are rejected with FailedPrecondition.
+type UpdateOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateOrderStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type UpdateOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusResponse) Reset() {
+	*x = UpdateOrderStatusResponse{}
+	mi := &file_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateOrderStatusResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Cancels an order that hasn't shipped yet. Customers may cancel their own orders.
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CancelOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CancelOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// A coupon code and the discount it grants. Which settings apply depends on kind:
+// percentage (percentOff), fixed_amount (amountOff) or buy_x_get_y (buyQuantity, getQuantity).
+type Promotion struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code              string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Kind              string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	PercentOff        uint32                 `protobuf:"varint,5,opt,name=percentOff,proto3" json:"percentOff,omitempty"`
+	AmountOff         *Money                 `protobuf:"bytes,6,opt,name=amountOff,proto3" json:"amountOff,omitempty"`
+	BuyQuantity       uint32                 `protobuf:"varint,7,opt,name=buyQuantity,proto3" json:"buyQuantity,omitempty"`
+	GetQuantity       uint32                 `protobuf:"varint,8,opt,name=getQuantity,proto3" json:"getQuantity,omitempty"`
+	ProductIds        []string               `protobuf:"bytes,9,rep,name=productIds,proto3" json:"productIds,omitempty"`                 // products it applies to; empty means every product
+	MinSpend          *Money                 `protobuf:"bytes,10,opt,name=minSpend,proto3" json:"minSpend,omitempty"`                    // subtotal the order must reach; unset means no minimum
+	MaxUsesPerAccount uint32                 `protobuf:"varint,11,opt,name=maxUsesPerAccount,proto3" json:"maxUsesPerAccount,omitempty"` // 0 means no limit
+	StartsAt          []byte                 `protobuf:"bytes,12,opt,name=startsAt,proto3" json:"startsAt,omitempty"`                    // empty means no start
+	EndsAt            []byte                 `protobuf:"bytes,13,opt,name=endsAt,proto3" json:"endsAt,omitempty"`                        // exclusive; empty means no end
+	Stackable         bool                   `protobuf:"varint,14,opt,name=stackable,proto3" json:"stackable,omitempty"`                 // may be combined with other coupons
+	Active            bool                   `protobuf:"varint,15,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt         []byte                 `protobuf:"bytes,16,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Promotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Promotion) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Promotion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Promotion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Promotion) GetPercentOff() uint32 {
+	if x != nil {
+		return x.PercentOff
+	}
+	return 0
+}
+
+func (x *Promotion) GetAmountOff() *Money {
+	if x != nil {
+		return x.AmountOff
+	}
+	return nil
+}
+
+func (x *Promotion) GetBuyQuantity() uint32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetGetQuantity() uint32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *Promotion) GetMinSpend() *Money {
+	if x != nil {
+		return x.MinSpend
+	}
+	return nil
+}
+
+func (x *Promotion) GetMaxUsesPerAccount() uint32 {
+	if x != nil {
+		return x.MaxUsesPerAccount
+	}
+	return 0
+}
+
+func (x *Promotion) GetStartsAt() []byte {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetEndsAt() []byte {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetStackable() bool {
+	if x != nil {
+		return x.Stackable
+	}
+	return false
+}
+
+func (x *Promotion) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Promotion) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Creates a promotion. id, active and createdAt are set by the service.
+type CreatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionRequest) ProtoMessage() {}
+
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreatePromotionRequest) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type CreatePromotionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionResponse) Reset() {
+	*x = CreatePromotionResponse{}
+	mi := &file_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionResponse) ProtoMessage() {}
+
+func (x *CreatePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionResponse.ProtoReflect.Descriptor instead.
+func (*CreatePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreatePromotionResponse) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type ListPromotionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{23}
+}
+
+type ListPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"` // newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsResponse) ProtoMessage() {}
+
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+// Switches a promotion on or off; orders already placed keep their discounts.
+type SetPromotionActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPromotionActiveRequest) Reset() {
+	*x = SetPromotionActiveRequest{}
+	mi := &file_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPromotionActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPromotionActiveRequest) ProtoMessage() {}
+
+func (x *SetPromotionActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPromotionActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetPromotionActiveRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetPromotionActiveRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetPromotionActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type SetPromotionActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPromotionActiveResponse) Reset() {
+	*x = SetPromotionActiveResponse{}
+	mi := &file_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPromotionActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPromotionActiveResponse) ProtoMessage() {}
+
+func (x *SetPromotionActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPromotionActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetPromotionActiveResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetPromotionActiveResponse) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+// Tax rate for one product tax class in a country, or in one region of it.
+type TaxRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"` // ISO 3166-1 alpha-2
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`   // empty for the whole country
+	TaxClass      string                 `protobuf:"bytes,4,opt,name=taxClass,proto3" json:"taxClass,omitempty"`
+	Rate          string                 `protobuf:"bytes,5,opt,name=rate,proto3" json:"rate,omitempty"` // percentage with up to four decimals, e.g. "8.875"
+	UpdatedAt     []byte                 `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxRule) Reset() {
+	*x = TaxRule{}
+	mi := &file_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxRule) ProtoMessage() {}
+
+func (x *TaxRule) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxRule.ProtoReflect.Descriptor instead.
+func (*TaxRule) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TaxRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaxRule) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *TaxRule) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *TaxRule) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *TaxRule) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *TaxRule) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Sets the rate for a country/region/tax class, replacing the rule already there.
+type SetTaxRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	TaxClass      string                 `protobuf:"bytes,3,opt,name=taxClass,proto3" json:"taxClass,omitempty"`
+	Rate          string                 `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTaxRuleRequest) Reset() {
+	*x = SetTaxRuleRequest{}
+	mi := &file_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTaxRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaxRuleRequest) ProtoMessage() {}
+
+func (x *SetTaxRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaxRuleRequest.ProtoReflect.Descriptor instead.
+func (*SetTaxRuleRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetTaxRuleRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SetTaxRuleRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *SetTaxRuleRequest) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *SetTaxRuleRequest) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type SetTaxRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *TaxRule               `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTaxRuleResponse) Reset() {
+	*x = SetTaxRuleResponse{}
+	mi := &file_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTaxRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaxRuleResponse) ProtoMessage() {}
+
+func (x *SetTaxRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaxRuleResponse.ProtoReflect.Descriptor instead.
+func (*SetTaxRuleResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SetTaxRuleResponse) GetRule() *TaxRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type ListTaxRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaxRulesRequest) Reset() {
+	*x = ListTaxRulesRequest{}
+	mi := &file_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaxRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaxRulesRequest) ProtoMessage() {}
+
+func (x *ListTaxRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaxRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListTaxRulesRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{30}
+}
+
+type ListTaxRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*TaxRule             `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"` // by country, region and tax class
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaxRulesResponse) Reset() {
+	*x = ListTaxRulesResponse{}
+	mi := &file_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaxRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaxRulesResponse) ProtoMessage() {}
+
+func (x *ListTaxRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaxRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListTaxRulesResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListTaxRulesResponse) GetRules() []*TaxRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type DeleteTaxRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaxRuleRequest) Reset() {
+	*x = DeleteTaxRuleRequest{}
+	mi := &file_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaxRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaxRuleRequest) ProtoMessage() {}
+
+func (x *DeleteTaxRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaxRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaxRuleRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteTaxRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTaxRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaxRuleResponse) Reset() {
+	*x = DeleteTaxRuleResponse{}
+	mi := &file_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaxRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaxRuleResponse) ProtoMessage() {}
+
+func (x *DeleteTaxRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaxRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaxRuleResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{33}
+}
+
+// How orders are shipped and what it costs. Which settings apply depends on kind: flat (price),
+// free_over_threshold (price, freeOver) or weight_based (weightRates). All amounts are in one currency.
+type ShippingMethod struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Id            string                       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Carrier       string                       `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	Kind          string                       `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Price         *Money                       `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	FreeOver      *Money                       `protobuf:"bytes,6,opt,name=freeOver,proto3" json:"freeOver,omitempty"`       // goods total after discounts from which shipping is free
+	WeightRates   []*ShippingMethod_WeightRate `protobuf:"bytes,7,rep,name=weightRates,proto3" json:"weightRates,omitempty"` // lightest first
+	Countries     []string                     `protobuf:"bytes,8,rep,name=countries,proto3" json:"countries,omitempty"`     // ISO 3166-1 alpha-2 codes it ships to; empty means everywhere
+	Active        bool                         `protobuf:"varint,9,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     []byte                       `protobuf:"bytes,10,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingMethod) Reset() {
+	*x = ShippingMethod{}
+	mi := &file_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingMethod) ProtoMessage() {}
+
+func (x *ShippingMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingMethod.ProtoReflect.Descriptor instead.
+func (*ShippingMethod) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ShippingMethod) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShippingMethod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShippingMethod) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *ShippingMethod) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ShippingMethod) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ShippingMethod) GetFreeOver() *Money {
+	if x != nil {
+		return x.FreeOver
+	}
+	return nil
+}
+
+func (x *ShippingMethod) GetWeightRates() []*ShippingMethod_WeightRate {
+	if x != nil {
+		return x.WeightRates
+	}
+	return nil
+}
+
+func (x *ShippingMethod) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *ShippingMethod) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *ShippingMethod) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Creates a shipping method. id, active and createdAt are set by the service.
+type CreateShippingMethodRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        *ShippingMethod        `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShippingMethodRequest) Reset() {
+	*x = CreateShippingMethodRequest{}
+	mi := &file_order_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShippingMethodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShippingMethodRequest) ProtoMessage() {}
+
+func (x *CreateShippingMethodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShippingMethodRequest.ProtoReflect.Descriptor instead.
+func (*CreateShippingMethodRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateShippingMethodRequest) GetMethod() *ShippingMethod {
+	if x != nil {
+		return x.Method
+	}
+	return nil
+}
+
+type CreateShippingMethodResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        *ShippingMethod        `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShippingMethodResponse) Reset() {
+	*x = CreateShippingMethodResponse{}
+	mi := &file_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShippingMethodResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShippingMethodResponse) ProtoMessage() {}
+
+func (x *CreateShippingMethodResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShippingMethodResponse.ProtoReflect.Descriptor instead.
+func (*CreateShippingMethodResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateShippingMethodResponse) GetMethod() *ShippingMethod {
+	if x != nil {
+		return x.Method
+	}
+	return nil
+}
+
+// Active methods shipping to country (any country if empty), by name. Admins may ask for inactive ones too.
+type ListShippingMethodsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Country         string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	IncludeInactive bool                   `protobuf:"varint,2,opt,name=includeInactive,proto3" json:"includeInactive,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListShippingMethodsRequest) Reset() {
+	*x = ListShippingMethodsRequest{}
+	mi := &file_order_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShippingMethodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShippingMethodsRequest) ProtoMessage() {}
+
+func (x *ListShippingMethodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShippingMethodsRequest.ProtoReflect.Descriptor instead.
+func (*ListShippingMethodsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListShippingMethodsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ListShippingMethodsRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+type ListShippingMethodsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Methods       []*ShippingMethod      `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShippingMethodsResponse) Reset() {
+	*x = ListShippingMethodsResponse{}
+	mi := &file_order_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShippingMethodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShippingMethodsResponse) ProtoMessage() {}
+
+func (x *ListShippingMethodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShippingMethodsResponse.ProtoReflect.Descriptor instead.
+func (*ListShippingMethodsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListShippingMethodsResponse) GetMethods() []*ShippingMethod {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+// Switches a shipping method on or off; orders already placed keep their shipping cost.
+type SetShippingMethodActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetShippingMethodActiveRequest) Reset() {
+	*x = SetShippingMethodActiveRequest{}
+	mi := &file_order_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetShippingMethodActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetShippingMethodActiveRequest) ProtoMessage() {}
+
+func (x *SetShippingMethodActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetShippingMethodActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetShippingMethodActiveRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SetShippingMethodActiveRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetShippingMethodActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type SetShippingMethodActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        *ShippingMethod        `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetShippingMethodActiveResponse) Reset() {
+	*x = SetShippingMethodActiveResponse{}
+	mi := &file_order_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetShippingMethodActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetShippingMethodActiveResponse) ProtoMessage() {}
+
+func (x *SetShippingMethodActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetShippingMethodActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetShippingMethodActiveResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SetShippingMethodActiveResponse) GetMethod() *ShippingMethod {
+	if x != nil {
+		return x.Method
+	}
+	return nil
+}
+
+// A parcel an order goes out in.
+type Shipment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Carrier        string                 `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,4,opt,name=trackingNumber,proto3" json:"trackingNumber,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`   // label_created, in_transit, out_for_delivery, delivered or failed
+	History        []*ShipmentEvent       `protobuf:"bytes,6,rep,name=history,proto3" json:"history,omitempty"` // oldest first
+	CreatedAt      []byte                 `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt      []byte                 `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_order_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Shipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Shipment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Shipment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Shipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Shipment) GetHistory() []*ShipmentEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Shipment) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ShipmentEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	OccurredAt    []byte                 `protobuf:"bytes,3,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,4,opt,name=changedBy,proto3" json:"changedBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentEvent) Reset() {
+	*x = ShipmentEvent{}
+	mi := &file_order_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentEvent) ProtoMessage() {}
+
+func (x *ShipmentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentEvent.ProtoReflect.Descriptor instead.
+func (*ShipmentEvent) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ShipmentEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetOccurredAt() []byte {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *ShipmentEvent) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+// Records a shipment of a fulfilled or shipped order. carrier defaults to the carrier of the order's shipping method.
+type CreateShipmentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Carrier        string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,3,opt,name=trackingNumber,proto3" json:"trackingNumber,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateShipmentRequest) Reset() {
+	*x = CreateShipmentRequest{}
+	mi := &file_order_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentRequest) ProtoMessage() {}
+
+func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateShipmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+type CreateShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShipmentResponse) Reset() {
+	*x = CreateShipmentResponse{}
+	mi := &file_order_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentResponse) ProtoMessage() {}
+
+func (x *CreateShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentResponse.ProtoReflect.Descriptor instead.
+func (*CreateShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CreateShipmentResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+// Records a status reported by the carrier; delivered and failed shipments don't change any more.
+type UpdateShipmentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShipmentStatusRequest) Reset() {
+	*x = UpdateShipmentStatusRequest{}
+	mi := &file_order_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShipmentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShipmentStatusRequest) ProtoMessage() {}
+
+func (x *UpdateShipmentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShipmentStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShipmentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UpdateShipmentStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateShipmentStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateShipmentStatusRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type UpdateShipmentStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShipmentStatusResponse) Reset() {
+	*x = UpdateShipmentStatusResponse{}
+	mi := &file_order_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShipmentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShipmentStatusResponse) ProtoMessage() {}
+
+func (x *UpdateShipmentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShipmentStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateShipmentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdateShipmentStatusResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+// Units of a delivered order sent back by the customer (an RMA).
+type Return struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // requested, approved, rejected or received
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Lines         []*Return_Line         `protobuf:"bytes,6,rep,name=lines,proto3" json:"lines,omitempty"`
+	Refund        *Money                 `protobuf:"bytes,7,opt,name=refund,proto3" json:"refund,omitempty"`         // sum of the lines' refund
+	RefundedAt    []byte                 `protobuf:"bytes,8,opt,name=refundedAt,proto3" json:"refundedAt,omitempty"` // empty until the refund went through
+	Restocked     bool                   `protobuf:"varint,9,opt,name=restocked,proto3" json:"restocked,omitempty"`
+	History       []*ReturnEvent         `protobuf:"bytes,10,rep,name=history,proto3" json:"history,omitempty"` // oldest first
+	CreatedAt     []byte                 `protobuf:"bytes,11,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt     []byte                 `protobuf:"bytes,12,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Return) Reset() {
+	*x = Return{}
+	mi := &file_order_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Return) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Return) ProtoMessage() {}
+
+func (x *Return) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Return.ProtoReflect.Descriptor instead.
+func (*Return) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Return) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Return) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Return) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Return) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Return) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Return) GetLines() []*Return_Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Return) GetRefund() *Money {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+func (x *Return) GetRefundedAt() []byte {
+	if x != nil {
+		return x.RefundedAt
+	}
+	return nil
+}
+
+func (x *Return) GetRestocked() bool {
+	if x != nil {
+		return x.Restocked
+	}
+	return false
+}
+
+func (x *Return) GetHistory() []*ReturnEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Return) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Return) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ReturnEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	OccurredAt    []byte                 `protobuf:"bytes,3,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,4,opt,name=changedBy,proto3" json:"changedBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnEvent) Reset() {
+	*x = ReturnEvent{}
+	mi := &file_order_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnEvent) ProtoMessage() {}
+
+func (x *ReturnEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnEvent.ProtoReflect.Descriptor instead.
+func (*ReturnEvent) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ReturnEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReturnEvent) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ReturnEvent) GetOccurredAt() []byte {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *ReturnEvent) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+// Asks to send back units of a delivered order within the return window.
+type RequestReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Lines         []*Return_Line         `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestReturnRequest) Reset() {
+	*x = RequestReturnRequest{}
+	mi := &file_order_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReturnRequest) ProtoMessage() {}
+
+func (x *RequestReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReturnRequest.ProtoReflect.Descriptor instead.
+func (*RequestReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RequestReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RequestReturnRequest) GetLines() []*Return_Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *RequestReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RequestReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestReturnResponse) Reset() {
+	*x = RequestReturnResponse{}
+	mi := &file_order_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReturnResponse) ProtoMessage() {}
+
+func (x *RequestReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReturnResponse.ProtoReflect.Descriptor instead.
+func (*RequestReturnResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RequestReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+// Every return in status (all of them if empty), oldest first.
+type ListReturnsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsRequest) Reset() {
+	*x = ListReturnsRequest{}
+	mi := &file_order_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsRequest) ProtoMessage() {}
+
+func (x *ListReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsRequest.ProtoReflect.Descriptor instead.
+func (*ListReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListReturnsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListReturnsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Returns       []*Return              `protobuf:"bytes,1,rep,name=returns,proto3" json:"returns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsResponse) Reset() {
+	*x = ListReturnsResponse{}
+	mi := &file_order_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsResponse) ProtoMessage() {}
+
+func (x *ListReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsResponse.ProtoReflect.Descriptor instead.
+func (*ListReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListReturnsResponse) GetReturns() []*Return {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+// Approves a requested return and refunds it; approving it again retries a refund that failed.
+type ApproveReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveReturnRequest) Reset() {
+	*x = ApproveReturnRequest{}
+	mi := &file_order_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveReturnRequest) ProtoMessage() {}
+
+func (x *ApproveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ApproveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ApproveReturnRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApproveReturnRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ApproveReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveReturnResponse) Reset() {
+	*x = ApproveReturnResponse{}
+	mi := &file_order_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveReturnResponse) ProtoMessage() {}
+
+func (x *ApproveReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveReturnResponse.ProtoReflect.Descriptor instead.
+func (*ApproveReturnResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ApproveReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+type RejectReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectReturnRequest) Reset() {
+	*x = RejectReturnRequest{}
+	mi := &file_order_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectReturnRequest) ProtoMessage() {}
+
+func (x *RejectReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectReturnRequest.ProtoReflect.Descriptor instead.
+func (*RejectReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RejectReturnRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RejectReturnRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type RejectReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectReturnResponse) Reset() {
+	*x = RejectReturnResponse{}
+	mi := &file_order_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectReturnResponse) ProtoMessage() {}
+
+func (x *RejectReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectReturnResponse.ProtoReflect.Descriptor instead.
+func (*RejectReturnResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *RejectReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+// Records that the parcel of an approved return arrived, putting its units back into stock with restock.
+type ReceiveReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Restock       bool                   `protobuf:"varint,2,opt,name=restock,proto3" json:"restock,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnRequest) Reset() {
+	*x = ReceiveReturnRequest{}
+	mi := &file_order_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnRequest) ProtoMessage() {}
+
+func (x *ReceiveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ReceiveReturnRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReceiveReturnRequest) GetRestock() bool {
+	if x != nil {
+		return x.Restock
+	}
+	return false
+}
+
+func (x *ReceiveReturnRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ReceiveReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnResponse) Reset() {
+	*x = ReceiveReturnResponse{}
+	mi := &file_order_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnResponse) ProtoMessage() {}
+
+func (x *ReceiveReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ReceiveReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+// Money given back for an order, to the customer's payment or as store credit.
+type OrderRefund struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	ReturnId      string                 `protobuf:"bytes,3,opt,name=returnId,proto3" json:"returnId,omitempty"` // empty unless the refund is for a return
+	Amount        *Money                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Destination   string                 `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"` // payment or store_credit
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Lines         []*OrderRefund_Line    `protobuf:"bytes,7,rep,name=lines,proto3" json:"lines,omitempty"` // empty for refunds of an amount
+	RefundedBy    string                 `protobuf:"bytes,8,opt,name=refundedBy,proto3" json:"refundedBy,omitempty"`
+	CreatedAt     []byte                 `protobuf:"bytes,9,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	CompletedAt   []byte                 `protobuf:"bytes,10,opt,name=completedAt,proto3" json:"completedAt,omitempty"` // empty while a refund to the payment waits for the payment service
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderRefund) Reset() {
+	*x = OrderRefund{}
+	mi := &file_order_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderRefund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderRefund) ProtoMessage() {}
+
+func (x *OrderRefund) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderRefund.ProtoReflect.Descriptor instead.
+func (*OrderRefund) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *OrderRefund) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderRefund) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderRefund) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+func (x *OrderRefund) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *OrderRefund) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *OrderRefund) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderRefund) GetLines() []*OrderRefund_Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *OrderRefund) GetRefundedBy() string {
+	if x != nil {
+		return x.RefundedBy
+	}
+	return ""
+}
+
+func (x *OrderRefund) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrderRefund) GetCompletedAt() []byte {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// Refunds units of the order's lines or, without lines, amount.
+type RefundOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Lines         []*OrderRefund_Line    `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Destination   string                 `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"` // payment or store_credit
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_order_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RefundOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetLines() []*OrderRefund_Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *RefundOrderRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *RefundOrderRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RefundOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refund        *OrderRefund           `protobuf:"bytes,1,opt,name=refund,proto3" json:"refund,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_order_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderResponse) ProtoMessage() {}
+
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RefundOrderResponse) GetRefund() *OrderRefund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+type LedgerEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // order_placed, charge, order_cancelled or refund
+	OrderId       string                 `protobuf:"bytes,3,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	AccountId     string                 `protobuf:"bytes,4,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	RefundId      string                 `protobuf:"bytes,5,opt,name=refundId,proto3" json:"refundId,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Postings      []*LedgerEntry_Posting `protobuf:"bytes,7,rep,name=postings,proto3" json:"postings,omitempty"`
+	CreatedAt     []byte                 `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_order_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *LedgerEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetPostings() []*LedgerEntry_Posting {
+	if x != nil {
+		return x.Postings
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Ledger entries of an order, or of an account's orders, oldest first.
+type ListLedgerEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesRequest) Reset() {
+	*x = ListLedgerEntriesRequest{}
+	mi := &file_order_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesRequest) ProtoMessage() {}
+
+func (x *ListLedgerEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListLedgerEntriesRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ListLedgerEntriesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ListLedgerEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesResponse) Reset() {
+	*x = ListLedgerEntriesResponse{}
+	mi := &file_order_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesResponse) ProtoMessage() {}
+
+func (x *ListLedgerEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListLedgerEntriesResponse) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GetStoreCreditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStoreCreditRequest) Reset() {
+	*x = GetStoreCreditRequest{}
+	mi := &file_order_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStoreCreditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStoreCreditRequest) ProtoMessage() {}
+
+func (x *GetStoreCreditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStoreCreditRequest.ProtoReflect.Descriptor instead.
+func (*GetStoreCreditRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetStoreCreditRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type GetStoreCreditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Credit        []*Money               `protobuf:"bytes,1,rep,name=credit,proto3" json:"credit,omitempty"` // one amount per currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStoreCreditResponse) Reset() {
+	*x = GetStoreCreditResponse{}
+	mi := &file_order_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStoreCreditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStoreCreditResponse) ProtoMessage() {}
+
+func (x *GetStoreCreditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStoreCreditResponse.ProtoReflect.Descriptor instead.
+func (*GetStoreCreditResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GetStoreCreditResponse) GetCredit() []*Money {
+	if x != nil {
+		return x.Credit
+	}
+	return nil
+}
+
+// Checks the ledger against the orders created in [from, to); empty times leave the range open.
+type ReconcileLedgerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          []byte                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            []byte                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileLedgerRequest) Reset() {
+	*x = ReconcileLedgerRequest{}
+	mi := &file_order_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileLedgerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileLedgerRequest) ProtoMessage() {}
+
+func (x *ReconcileLedgerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileLedgerRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileLedgerRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ReconcileLedgerRequest) GetFrom() []byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ReconcileLedgerRequest) GetTo() []byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type LedgerMismatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Account       string                 `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Expected      *Money                 `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual        *Money                 `protobuf:"bytes,4,opt,name=actual,proto3" json:"actual,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerMismatch) Reset() {
+	*x = LedgerMismatch{}
+	mi := &file_order_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerMismatch) ProtoMessage() {}
+
+func (x *LedgerMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerMismatch.ProtoReflect.Descriptor instead.
+func (*LedgerMismatch) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *LedgerMismatch) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *LedgerMismatch) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *LedgerMismatch) GetExpected() *Money {
+	if x != nil {
+		return x.Expected
+	}
+	return nil
+}
+
+func (x *LedgerMismatch) GetActual() *Money {
+	if x != nil {
+		return x.Actual
+	}
+	return nil
+}
+
+type ReconcileLedgerResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Orders            uint32                 `protobuf:"varint,1,opt,name=orders,proto3" json:"orders,omitempty"` // how many orders were checked
+	Mismatches        []*LedgerMismatch      `protobuf:"bytes,2,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+	UnbalancedEntries []string               `protobuf:"bytes,3,rep,name=unbalancedEntries,proto3" json:"unbalancedEntries,omitempty"` // ids of entries whose postings don't add up to zero
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReconcileLedgerResponse) Reset() {
+	*x = ReconcileLedgerResponse{}
+	mi := &file_order_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileLedgerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileLedgerResponse) ProtoMessage() {}
+
+func (x *ReconcileLedgerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileLedgerResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileLedgerResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ReconcileLedgerResponse) GetOrders() uint32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *ReconcileLedgerResponse) GetMismatches() []*LedgerMismatch {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+func (x *ReconcileLedgerResponse) GetUnbalancedEntries() []string {
+	if x != nil {
+		return x.UnbalancedEntries
+	}
+	return nil
+}
+
+// Product details as they were when the order was placed.
+type Order_OrderProduct struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price         *Money                 `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"` // unit price, in the order's currency
+	Quantity      uint32                 `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Discounts     []*LineDiscount        `protobuf:"bytes,7,rep,name=discounts,proto3" json:"discounts,omitempty"` // in the order the coupons were applied
+	TaxClass      string                 `protobuf:"bytes,8,opt,name=taxClass,proto3" json:"taxClass,omitempty"`
+	TaxRate       string                 `protobuf:"bytes,9,opt,name=taxRate,proto3" json:"taxRate,omitempty"` // percentage applied, e.g. "19"; "0" if no tax rule matched
+	Tax           *Money                 `protobuf:"bytes,10,opt,name=tax,proto3" json:"tax,omitempty"`        // on the line after its discounts
+	Weight        uint32                 `protobuf:"varint,11,opt,name=weight,proto3" json:"weight,omitempty"` // billable grams per unit
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostOrderRequest) Reset() {
-	*x = PostOrderRequest{}
-	mi := &file_order_proto_msgTypes[1]
+func (x *Order_OrderProduct) Reset() {
+	*x = Order_OrderProduct{}
+	mi := &file_order_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostOrderRequest) String() string {
+func (x *Order_OrderProduct) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostOrderRequest) ProtoMessage() {}
+func (*Order_OrderProduct) ProtoMessage() {}
 
-func (x *PostOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[1]
+func (x *Order_OrderProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -130,91 +4382,104 @@ func (x *PostOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostOrderRequest.ProtoReflect.Descriptor instead.
-func (*PostOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Order_OrderProduct.ProtoReflect.Descriptor instead.
+func (*Order_OrderProduct) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2, 0}
 }
 
-func (x *PostOrderRequest) GetAccountId() string {
+func (x *Order_OrderProduct) GetId() string {
 	if x != nil {
-		return x.AccountId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PostOrderRequest) GetProducts() []*PostOrderRequest_OrderProduct {
+func (x *Order_OrderProduct) GetName() string {
 	if x != nil {
-		return x.Products
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-type PostOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Order_OrderProduct) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-func (x *PostOrderResponse) Reset() {
-	*x = PostOrderResponse{}
-	mi := &file_order_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Order_OrderProduct) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
 }
 
-func (x *PostOrderResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Order_OrderProduct) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
 }
 
-func (*PostOrderResponse) ProtoMessage() {}
+func (x *Order_OrderProduct) GetDiscounts() []*LineDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
 
-func (x *PostOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[2]
+func (x *Order_OrderProduct) GetTaxClass() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TaxClass
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use PostOrderResponse.ProtoReflect.Descriptor instead.
-func (*PostOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{2}
+func (x *Order_OrderProduct) GetTaxRate() string {
+	if x != nil {
+		return x.TaxRate
+	}
+	return ""
 }
 
-func (x *PostOrderResponse) GetOrder() *Order {
+func (x *Order_OrderProduct) GetTax() *Money {
 	if x != nil {
-		return x.Order
+		return x.Tax
 	}
 	return nil
 }
 
-type GetOrderRequest struct {
+func (x *Order_OrderProduct) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type PostOrderRequest_OrderProduct struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_order_proto_msgTypes[3]
+func (x *PostOrderRequest_OrderProduct) Reset() {
+	*x = PostOrderRequest_OrderProduct{}
+	mi := &file_order_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *PostOrderRequest_OrderProduct) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*PostOrderRequest_OrderProduct) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[3]
+func (x *PostOrderRequest_OrderProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -225,40 +4490,48 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use PostOrderRequest_OrderProduct.ProtoReflect.Descriptor instead.
+func (*PostOrderRequest_OrderProduct) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7, 0}
 }
 
-func (x *GetOrderRequest) GetId() string {
+func (x *PostOrderRequest_OrderProduct) GetProductId() string {
 	if x != nil {
-		return x.Id
+		return x.ProductId
 	}
 	return ""
 }
 
-type GetOrderResponse struct {
+func (x *PostOrderRequest_OrderProduct) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ListOrdersForAccountResponse_Edge struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Order         *Order                 `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderResponse) Reset() {
-	*x = GetOrderResponse{}
-	mi := &file_order_proto_msgTypes[4]
+func (x *ListOrdersForAccountResponse_Edge) Reset() {
+	*x = ListOrdersForAccountResponse_Edge{}
+	mi := &file_order_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderResponse) String() string {
+func (x *ListOrdersForAccountResponse_Edge) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderResponse) ProtoMessage() {}
+func (*ListOrdersForAccountResponse_Edge) ProtoMessage() {}
 
-func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[4]
+func (x *ListOrdersForAccountResponse_Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,40 +4542,48 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListOrdersForAccountResponse_Edge.ProtoReflect.Descriptor instead.
+func (*ListOrdersForAccountResponse_Edge) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{15, 0}
 }
 
-func (x *GetOrderResponse) GetOrder() *Order {
+func (x *ListOrdersForAccountResponse_Edge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListOrdersForAccountResponse_Edge) GetOrder() *Order {
 	if x != nil {
 		return x.Order
 	}
 	return nil
 }
 
-type GetOrdersForAccountRequest struct {
+type ShippingMethod_WeightRate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	MaxWeight     uint32                 `protobuf:"varint,1,opt,name=maxWeight,proto3" json:"maxWeight,omitempty"` // grams, inclusive
+	Price         *Money                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersForAccountRequest) Reset() {
-	*x = GetOrdersForAccountRequest{}
-	mi := &file_order_proto_msgTypes[5]
+func (x *ShippingMethod_WeightRate) Reset() {
+	*x = ShippingMethod_WeightRate{}
+	mi := &file_order_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersForAccountRequest) String() string {
+func (x *ShippingMethod_WeightRate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersForAccountRequest) ProtoMessage() {}
+func (*ShippingMethod_WeightRate) ProtoMessage() {}
 
-func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[5]
+func (x *ShippingMethod_WeightRate) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,40 +4594,49 @@ func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersForAccountRequest.ProtoReflect.Descriptor instead.
-func (*GetOrdersForAccountRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ShippingMethod_WeightRate.ProtoReflect.Descriptor instead.
+func (*ShippingMethod_WeightRate) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{34, 0}
 }
 
-func (x *GetOrdersForAccountRequest) GetAccountId() string {
+func (x *ShippingMethod_WeightRate) GetMaxWeight() uint32 {
 	if x != nil {
-		return x.AccountId
+		return x.MaxWeight
 	}
-	return ""
+	return 0
 }
 
-type GetOrdersForAccountResponse struct {
+func (x *ShippingMethod_WeightRate) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type Return_Line struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Refund        *Money                 `protobuf:"bytes,3,opt,name=refund,proto3" json:"refund,omitempty"` // what the customer paid for these units; ignored in requests
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersForAccountResponse) Reset() {
-	*x = GetOrdersForAccountResponse{}
-	mi := &file_order_proto_msgTypes[6]
+func (x *Return_Line) Reset() {
+	*x = Return_Line{}
+	mi := &file_order_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersForAccountResponse) String() string {
+func (x *Return_Line) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersForAccountResponse) ProtoMessage() {}
+func (*Return_Line) ProtoMessage() {}
 
-func (x *GetOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[6]
+func (x *Return_Line) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -357,44 +4647,56 @@ func (x *GetOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersForAccountResponse.ProtoReflect.Descriptor instead.
-func (*GetOrdersForAccountResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Return_Line.ProtoReflect.Descriptor instead.
+func (*Return_Line) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{47, 0}
 }
 
-func (x *GetOrdersForAccountResponse) GetOrders() []*Order {
+func (x *Return_Line) GetProductId() string {
 	if x != nil {
-		return x.Orders
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Return_Line) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Return_Line) GetRefund() *Money {
+	if x != nil {
+		return x.Refund
 	}
 	return nil
 }
 
-type Order_OrderProduct struct {
+type OrderRefund_Line struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      uint32                 `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"` // what the customer paid for these units; ignored in requests
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order_OrderProduct) Reset() {
-	*x = Order_OrderProduct{}
-	mi := &file_order_proto_msgTypes[7]
+func (x *OrderRefund_Line) Reset() {
+	*x = OrderRefund_Line{}
+	mi := &file_order_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order_OrderProduct) String() string {
+func (x *OrderRefund_Line) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order_OrderProduct) ProtoMessage() {}
+func (*OrderRefund_Line) ProtoMessage() {}
 
-func (x *Order_OrderProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[7]
+func (x *OrderRefund_Line) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,69 +4707,55 @@ func (x *Order_OrderProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order_OrderProduct.ProtoReflect.Descriptor instead.
-func (*Order_OrderProduct) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{0, 0}
-}
-
-func (x *Order_OrderProduct) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Order_OrderProduct) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use OrderRefund_Line.ProtoReflect.Descriptor instead.
+func (*OrderRefund_Line) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{59, 0}
 }
 
-func (x *Order_OrderProduct) GetDescription() string {
+func (x *OrderRefund_Line) GetProductId() string {
 	if x != nil {
-		return x.Description
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *Order_OrderProduct) GetPrice() float64 {
+func (x *OrderRefund_Line) GetQuantity() uint32 {
 	if x != nil {
-		return x.Price
+		return x.Quantity
 	}
 	return 0
 }
 
-func (x *Order_OrderProduct) GetQuantity() uint32 {
+func (x *OrderRefund_Line) GetAmount() *Money {
 	if x != nil {
-		return x.Quantity
+		return x.Amount
 	}
-	return 0
+	return nil
 }
 
-type PostOrderRequest_OrderProduct struct {
+type LedgerEntry_Posting struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
-	Quantity      uint32                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"` // receivable, sales, discounts, tax, shipping, payments, refunds or store_credit
+	Amount        *Money                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`   // debits are positive, credits negative
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostOrderRequest_OrderProduct) Reset() {
-	*x = PostOrderRequest_OrderProduct{}
-	mi := &file_order_proto_msgTypes[8]
+func (x *LedgerEntry_Posting) Reset() {
+	*x = LedgerEntry_Posting{}
+	mi := &file_order_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostOrderRequest_OrderProduct) String() string {
+func (x *LedgerEntry_Posting) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostOrderRequest_OrderProduct) ProtoMessage() {}
+func (*LedgerEntry_Posting) ProtoMessage() {}
 
-func (x *PostOrderRequest_OrderProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[8]
+func (x *LedgerEntry_Posting) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,63 +4766,409 @@ func (x *PostOrderRequest_OrderProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostOrderRequest_OrderProduct.ProtoReflect.Descriptor instead.
-func (*PostOrderRequest_OrderProduct) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use LedgerEntry_Posting.ProtoReflect.Descriptor instead.
+func (*LedgerEntry_Posting) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{62, 0}
 }
 
-func (x *PostOrderRequest_OrderProduct) GetProductId() string {
+func (x *LedgerEntry_Posting) GetAccount() string {
 	if x != nil {
-		return x.ProductId
+		return x.Account
 	}
 	return ""
 }
 
-func (x *PostOrderRequest_OrderProduct) GetQuantity() uint32 {
+func (x *LedgerEntry_Posting) GetAmount() *Money {
 	if x != nil {
-		return x.Quantity
+		return x.Amount
 	}
-	return 0
+	return nil
 }
 
 var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\x02pb\"\xb0\x02\n" +
+	"\vorder.proto\x12\x05order\"\xbf\x01\n" +
+	"\aAddress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05line1\x18\x03 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x04 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12\x1e\n" +
+	"\n" +
+	"postalCode\x18\a \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\b \x01(\tR\acountry\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xc7\t\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
 	"\tcreatedAt\x18\x02 \x01(\fR\tcreatedAt\x12\x1c\n" +
-	"\taccountId\x18\x03 \x01(\tR\taccountId\x12\x1e\n" +
+	"\taccountId\x18\x03 \x01(\tR\taccountId\x12(\n" +
+	"\bsubtotal\x18\f \x01(\v2\f.order.MoneyR\bsubtotal\x122\n" +
+	"\rdiscountTotal\x18\r \x01(\v2\f.order.MoneyR\rdiscountTotal\x12(\n" +
+	"\btaxTotal\x18\x0e \x01(\v2\f.order.MoneyR\btaxTotal\x122\n" +
+	"\rshippingTotal\x18\x10 \x01(\v2\f.order.MoneyR\rshippingTotal\x12,\n" +
 	"\n" +
-	"totalPrice\x18\x04 \x01(\x01R\n" +
-	"totalPrice\x122\n" +
-	"\bproducts\x18\x05 \x03(\v2\x16.pb.Order.OrderProductR\bproducts\x1a\x86\x01\n" +
+	"totalPrice\x18\n" +
+	" \x01(\v2\f.order.MoneyR\n" +
+	"totalPrice\x12*\n" +
+	"\x10pricesIncludeTax\x18\x0f \x01(\bR\x10pricesIncludeTax\x12*\n" +
+	"\x10shippingMethodId\x18\x11 \x01(\tR\x10shippingMethodId\x12.\n" +
+	"\x12shippingMethodName\x18\x12 \x01(\tR\x12shippingMethodName\x12-\n" +
+	"\tshipments\x18\x13 \x03(\v2\x0f.order.ShipmentR\tshipments\x12'\n" +
+	"\areturns\x18\x14 \x03(\v2\r.order.ReturnR\areturns\x12,\n" +
+	"\arefunds\x18\x15 \x03(\v2\x12.order.OrderRefundR\arefunds\x125\n" +
+	"\bproducts\x18\x05 \x03(\v2\x19.order.Order.OrderProductR\bproducts\x128\n" +
+	"\x0fshippingAddress\x18\x06 \x01(\v2\x0e.order.AddressR\x0fshippingAddress\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x129\n" +
+	"\rstatusHistory\x18\b \x03(\v2\x13.order.StatusChangeR\rstatusHistory\x127\n" +
+	"\fcancellation\x18\t \x01(\v2\x13.order.CancellationR\fcancellation\x129\n" +
+	"\rexchangeRates\x18\v \x03(\v2\x13.order.ExchangeRateR\rexchangeRates\x1a\xbb\x02\n" +
 	"\fOrderProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x05 \x01(\rR\bquantity\"\xb9\x01\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\"\n" +
+	"\x05price\x18\x06 \x01(\v2\f.order.MoneyR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\rR\bquantity\x121\n" +
+	"\tdiscounts\x18\a \x03(\v2\x13.order.LineDiscountR\tdiscounts\x12\x1a\n" +
+	"\btaxClass\x18\b \x01(\tR\btaxClass\x12\x18\n" +
+	"\ataxRate\x18\t \x01(\tR\ataxRate\x12\x1e\n" +
+	"\x03tax\x18\n" +
+	" \x01(\v2\f.order.MoneyR\x03tax\x12\x16\n" +
+	"\x06weight\x18\v \x01(\rR\x06weightJ\x04\b\x04\x10\x05J\x04\b\x04\x10\x05\"\x8c\x01\n" +
+	"\fLineDiscount\x12 \n" +
+	"\vpromotionId\x18\x01 \x01(\tR\vpromotionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12$\n" +
+	"\x06amount\x18\x04 \x01(\v2\f.order.MoneyR\x06amount\"F\n" +
+	"\fExchangeRate\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\"\x9c\x01\n" +
+	"\fCancellation\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12*\n" +
+	"\trefundDue\x18\x05 \x01(\v2\f.order.MoneyR\trefundDue\x12 \n" +
+	"\vcancelledAt\x18\x03 \x01(\fR\vcancelledAt\x12 \n" +
+	"\vcancelledBy\x18\x04 \x01(\tR\vcancelledByJ\x04\b\x02\x10\x03\"\x82\x01\n" +
+	"\fStatusChange\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1c\n" +
+	"\tchangedAt\x18\x03 \x01(\fR\tchangedAt\x12\x1c\n" +
+	"\tchangedBy\x18\x04 \x01(\tR\tchangedBy\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\"\xec\x02\n" +
 	"\x10PostOrderRequest\x12\x1c\n" +
-	"\taccountId\x18\x01 \x01(\tR\taccountId\x12=\n" +
-	"\bproducts\x18\x04 \x03(\v2!.pb.PostOrderRequest.OrderProductR\bproducts\x1aH\n" +
+	"\taccountId\x18\x01 \x01(\tR\taccountId\x12@\n" +
+	"\bproducts\x18\x04 \x03(\v2$.order.PostOrderRequest.OrderProductR\bproducts\x12\x1c\n" +
+	"\taddressId\x18\x05 \x01(\tR\taddressId\x12&\n" +
+	"\x0eidempotencyKey\x18\x06 \x01(\tR\x0eidempotencyKey\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12 \n" +
+	"\vcouponCodes\x18\b \x03(\tR\vcouponCodes\x12*\n" +
+	"\x10shippingMethodId\x18\t \x01(\tR\x10shippingMethodId\x1aH\n" +
 	"\fOrderProduct\x12\x1c\n" +
 	"\tproductId\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\rR\bquantity\"4\n" +
-	"\x11PostOrderResponse\x12\x1f\n" +
-	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\"!\n" +
+	"\bquantity\x18\x03 \x01(\rR\bquantity\"7\n" +
+	"\x11PostOrderResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"!\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"3\n" +
-	"\x10GetOrderResponse\x12\x1f\n" +
-	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\":\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"6\n" +
+	"\x10GetOrderResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"s\n" +
+	"\vOrderFilter\x12\"\n" +
+	"\fcreatedAfter\x18\x01 \x01(\fR\fcreatedAfter\x12$\n" +
+	"\rcreatedBefore\x18\x02 \x01(\fR\rcreatedBefore\x12\x1a\n" +
+	"\bstatuses\x18\x03 \x03(\tR\bstatuses\"\x8e\x01\n" +
 	"\x1aGetOrdersForAccountRequest\x12\x1c\n" +
-	"\taccountId\x18\x01 \x01(\tR\taccountId\"@\n" +
-	"\x1bGetOrdersForAccountResponse\x12!\n" +
-	"\x06orders\x18\x01 \x03(\v2\t.pb.OrderR\x06orders2\xa4\x01\n" +
-	"\fOrderService\x12:\n" +
-	"\tPostOrder\x12\x14.pb.PostOrderRequest\x1a\x15.pb.PostOrderResponse\"\x00\x12X\n" +
-	"\x13GetOrdersForAccount\x12\x1e.pb.GetOrdersForAccountRequest\x1a\x1f.pb.GetOrdersForAccountResponse\"\x00B\x04Z\x02./b\x06proto3"
+	"\taccountId\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04skip\x18\x02 \x01(\x04R\x04skip\x12\x12\n" +
+	"\x04take\x18\x03 \x01(\x04R\x04take\x12*\n" +
+	"\x06filter\x18\x04 \x01(\v2\x12.order.OrderFilterR\x06filter\"C\n" +
+	"\x1bGetOrdersForAccountResponse\x12$\n" +
+	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\"\x93\x01\n" +
+	"\x1bListOrdersForAccountRequest\x12\x1c\n" +
+	"\taccountId\x18\x01 \x01(\tR\taccountId\x12\x14\n" +
+	"\x05after\x18\x02 \x01(\tR\x05after\x12\x14\n" +
+	"\x05first\x18\x03 \x01(\x04R\x05first\x12*\n" +
+	"\x06filter\x18\x04 \x01(\v2\x12.order.OrderFilterR\x06filter\"\xe4\x01\n" +
+	"\x1cListOrdersForAccountResponse\x12>\n" +
+	"\x05edges\x18\x01 \x03(\v2(.order.ListOrdersForAccountResponse.EdgeR\x05edges\x12 \n" +
+	"\vhasNextPage\x18\x02 \x01(\bR\vhasNextPage\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x03 \x01(\x04R\n" +
+	"totalCount\x1aB\n" +
+	"\x04Edge\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\"\n" +
+	"\x05order\x18\x02 \x01(\v2\f.order.OrderR\x05order\"V\n" +
+	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"?\n" +
+	"\x19UpdateOrderStatusResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"<\n" +
+	"\x12CancelOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"9\n" +
+	"\x13CancelOrderResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"\xf5\x03\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1e\n" +
+	"\n" +
+	"percentOff\x18\x05 \x01(\rR\n" +
+	"percentOff\x12*\n" +
+	"\tamountOff\x18\x06 \x01(\v2\f.order.MoneyR\tamountOff\x12 \n" +
+	"\vbuyQuantity\x18\a \x01(\rR\vbuyQuantity\x12 \n" +
+	"\vgetQuantity\x18\b \x01(\rR\vgetQuantity\x12\x1e\n" +
+	"\n" +
+	"productIds\x18\t \x03(\tR\n" +
+	"productIds\x12(\n" +
+	"\bminSpend\x18\n" +
+	" \x01(\v2\f.order.MoneyR\bminSpend\x12,\n" +
+	"\x11maxUsesPerAccount\x18\v \x01(\rR\x11maxUsesPerAccount\x12\x1a\n" +
+	"\bstartsAt\x18\f \x01(\fR\bstartsAt\x12\x16\n" +
+	"\x06endsAt\x18\r \x01(\fR\x06endsAt\x12\x1c\n" +
+	"\tstackable\x18\x0e \x01(\bR\tstackable\x12\x16\n" +
+	"\x06active\x18\x0f \x01(\bR\x06active\x12\x1c\n" +
+	"\tcreatedAt\x18\x10 \x01(\fR\tcreatedAt\"H\n" +
+	"\x16CreatePromotionRequest\x12.\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x10.order.PromotionR\tpromotion\"I\n" +
+	"\x17CreatePromotionResponse\x12.\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x10.order.PromotionR\tpromotion\"\x17\n" +
+	"\x15ListPromotionsRequest\"J\n" +
+	"\x16ListPromotionsResponse\x120\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x10.order.PromotionR\n" +
+	"promotions\"C\n" +
+	"\x19SetPromotionActiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"L\n" +
+	"\x1aSetPromotionActiveResponse\x12.\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x10.order.PromotionR\tpromotion\"\x99\x01\n" +
+	"\aTaxRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x1a\n" +
+	"\btaxClass\x18\x04 \x01(\tR\btaxClass\x12\x12\n" +
+	"\x04rate\x18\x05 \x01(\tR\x04rate\x12\x1c\n" +
+	"\tupdatedAt\x18\x06 \x01(\fR\tupdatedAt\"u\n" +
+	"\x11SetTaxRuleRequest\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1a\n" +
+	"\btaxClass\x18\x03 \x01(\tR\btaxClass\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\"8\n" +
+	"\x12SetTaxRuleResponse\x12\"\n" +
+	"\x04rule\x18\x01 \x01(\v2\x0e.order.TaxRuleR\x04rule\"\x15\n" +
+	"\x13ListTaxRulesRequest\"<\n" +
+	"\x14ListTaxRulesResponse\x12$\n" +
+	"\x05rules\x18\x01 \x03(\v2\x0e.order.TaxRuleR\x05rules\"&\n" +
+	"\x14DeleteTaxRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteTaxRuleResponse\"\x98\x03\n" +
+	"\x0eShippingMethod\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\"\n" +
+	"\x05price\x18\x05 \x01(\v2\f.order.MoneyR\x05price\x12(\n" +
+	"\bfreeOver\x18\x06 \x01(\v2\f.order.MoneyR\bfreeOver\x12B\n" +
+	"\vweightRates\x18\a \x03(\v2 .order.ShippingMethod.WeightRateR\vweightRates\x12\x1c\n" +
+	"\tcountries\x18\b \x03(\tR\tcountries\x12\x16\n" +
+	"\x06active\x18\t \x01(\bR\x06active\x12\x1c\n" +
+	"\tcreatedAt\x18\n" +
+	" \x01(\fR\tcreatedAt\x1aN\n" +
+	"\n" +
+	"WeightRate\x12\x1c\n" +
+	"\tmaxWeight\x18\x01 \x01(\rR\tmaxWeight\x12\"\n" +
+	"\x05price\x18\x02 \x01(\v2\f.order.MoneyR\x05price\"L\n" +
+	"\x1bCreateShippingMethodRequest\x12-\n" +
+	"\x06method\x18\x01 \x01(\v2\x15.order.ShippingMethodR\x06method\"M\n" +
+	"\x1cCreateShippingMethodResponse\x12-\n" +
+	"\x06method\x18\x01 \x01(\v2\x15.order.ShippingMethodR\x06method\"`\n" +
+	"\x1aListShippingMethodsRequest\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12(\n" +
+	"\x0fincludeInactive\x18\x02 \x01(\bR\x0fincludeInactive\"N\n" +
+	"\x1bListShippingMethodsResponse\x12/\n" +
+	"\amethods\x18\x01 \x03(\v2\x15.order.ShippingMethodR\amethods\"H\n" +
+	"\x1eSetShippingMethodActiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"P\n" +
+	"\x1fSetShippingMethodActiveResponse\x12-\n" +
+	"\x06method\x18\x01 \x01(\v2\x15.order.ShippingMethodR\x06method\"\xfa\x01\n" +
+	"\bShipment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aorderId\x18\x02 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12&\n" +
+	"\x0etrackingNumber\x18\x04 \x01(\tR\x0etrackingNumber\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12.\n" +
+	"\ahistory\x18\x06 \x03(\v2\x14.order.ShipmentEventR\ahistory\x12\x1c\n" +
+	"\tcreatedAt\x18\a \x01(\fR\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\b \x01(\fR\tupdatedAt\"y\n" +
+	"\rShipmentEvent\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x1e\n" +
+	"\n" +
+	"occurredAt\x18\x03 \x01(\fR\n" +
+	"occurredAt\x12\x1c\n" +
+	"\tchangedBy\x18\x04 \x01(\tR\tchangedBy\"s\n" +
+	"\x15CreateShipmentRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\x12&\n" +
+	"\x0etrackingNumber\x18\x03 \x01(\tR\x0etrackingNumber\"E\n" +
+	"\x16CreateShipmentResponse\x12+\n" +
+	"\bshipment\x18\x01 \x01(\v2\x0f.order.ShipmentR\bshipment\"Y\n" +
+	"\x1bUpdateShipmentStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"K\n" +
+	"\x1cUpdateShipmentStatusResponse\x12+\n" +
+	"\bshipment\x18\x01 \x01(\v2\x0f.order.ShipmentR\bshipment\"\xe0\x03\n" +
+	"\x06Return\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aorderId\x18\x02 \x01(\tR\aorderId\x12\x1c\n" +
+	"\taccountId\x18\x03 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12(\n" +
+	"\x05lines\x18\x06 \x03(\v2\x12.order.Return.LineR\x05lines\x12$\n" +
+	"\x06refund\x18\a \x01(\v2\f.order.MoneyR\x06refund\x12\x1e\n" +
+	"\n" +
+	"refundedAt\x18\b \x01(\fR\n" +
+	"refundedAt\x12\x1c\n" +
+	"\trestocked\x18\t \x01(\bR\trestocked\x12,\n" +
+	"\ahistory\x18\n" +
+	" \x03(\v2\x12.order.ReturnEventR\ahistory\x12\x1c\n" +
+	"\tcreatedAt\x18\v \x01(\fR\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\f \x01(\fR\tupdatedAt\x1af\n" +
+	"\x04Line\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\x12$\n" +
+	"\x06refund\x18\x03 \x01(\v2\f.order.MoneyR\x06refund\"w\n" +
+	"\vReturnEvent\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x1e\n" +
+	"\n" +
+	"occurredAt\x18\x03 \x01(\fR\n" +
+	"occurredAt\x12\x1c\n" +
+	"\tchangedBy\x18\x04 \x01(\tR\tchangedBy\"r\n" +
+	"\x14RequestReturnRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12(\n" +
+	"\x05lines\x18\x02 \x03(\v2\x12.order.Return.LineR\x05lines\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\">\n" +
+	"\x15RequestReturnResponse\x12%\n" +
+	"\x06return\x18\x01 \x01(\v2\r.order.ReturnR\x06return\",\n" +
+	"\x12ListReturnsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\">\n" +
+	"\x13ListReturnsResponse\x12'\n" +
+	"\areturns\x18\x01 \x03(\v2\r.order.ReturnR\areturns\":\n" +
+	"\x14ApproveReturnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\">\n" +
+	"\x15ApproveReturnResponse\x12%\n" +
+	"\x06return\x18\x01 \x01(\v2\r.order.ReturnR\x06return\"9\n" +
+	"\x13RejectReturnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\"=\n" +
+	"\x14RejectReturnResponse\x12%\n" +
+	"\x06return\x18\x01 \x01(\v2\r.order.ReturnR\x06return\"T\n" +
+	"\x14ReceiveReturnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\arestock\x18\x02 \x01(\bR\arestock\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\">\n" +
+	"\x15ReceiveReturnResponse\x12%\n" +
+	"\x06return\x18\x01 \x01(\v2\r.order.ReturnR\x06return\"\xaa\x03\n" +
+	"\vOrderRefund\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aorderId\x18\x02 \x01(\tR\aorderId\x12\x1a\n" +
+	"\breturnId\x18\x03 \x01(\tR\breturnId\x12$\n" +
+	"\x06amount\x18\x04 \x01(\v2\f.order.MoneyR\x06amount\x12 \n" +
+	"\vdestination\x18\x05 \x01(\tR\vdestination\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12-\n" +
+	"\x05lines\x18\a \x03(\v2\x17.order.OrderRefund.LineR\x05lines\x12\x1e\n" +
+	"\n" +
+	"refundedBy\x18\b \x01(\tR\n" +
+	"refundedBy\x12\x1c\n" +
+	"\tcreatedAt\x18\t \x01(\fR\tcreatedAt\x12 \n" +
+	"\vcompletedAt\x18\n" +
+	" \x01(\fR\vcompletedAt\x1af\n" +
+	"\x04Line\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\x12$\n" +
+	"\x06amount\x18\x03 \x01(\v2\f.order.MoneyR\x06amount\"\xbd\x01\n" +
+	"\x12RefundOrderRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12-\n" +
+	"\x05lines\x18\x02 \x03(\v2\x17.order.OrderRefund.LineR\x05lines\x12$\n" +
+	"\x06amount\x18\x03 \x01(\v2\f.order.MoneyR\x06amount\x12 \n" +
+	"\vdestination\x18\x04 \x01(\tR\vdestination\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"A\n" +
+	"\x13RefundOrderResponse\x12*\n" +
+	"\x06refund\x18\x01 \x01(\v2\x12.order.OrderRefundR\x06refund\"\xc8\x02\n" +
+	"\vLedgerEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\aorderId\x18\x03 \x01(\tR\aorderId\x12\x1c\n" +
+	"\taccountId\x18\x04 \x01(\tR\taccountId\x12\x1a\n" +
+	"\brefundId\x18\x05 \x01(\tR\brefundId\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x126\n" +
+	"\bpostings\x18\a \x03(\v2\x1a.order.LedgerEntry.PostingR\bpostings\x12\x1c\n" +
+	"\tcreatedAt\x18\b \x01(\fR\tcreatedAt\x1aI\n" +
+	"\aPosting\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12$\n" +
+	"\x06amount\x18\x02 \x01(\v2\f.order.MoneyR\x06amount\"R\n" +
+	"\x18ListLedgerEntriesRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12\x1c\n" +
+	"\taccountId\x18\x02 \x01(\tR\taccountId\"I\n" +
+	"\x19ListLedgerEntriesResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.order.LedgerEntryR\aentries\"5\n" +
+	"\x15GetStoreCreditRequest\x12\x1c\n" +
+	"\taccountId\x18\x01 \x01(\tR\taccountId\">\n" +
+	"\x16GetStoreCreditResponse\x12$\n" +
+	"\x06credit\x18\x01 \x03(\v2\f.order.MoneyR\x06credit\"<\n" +
+	"\x16ReconcileLedgerRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\fR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\fR\x02to\"\x94\x01\n" +
+	"\x0eLedgerMismatch\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\x12(\n" +
+	"\bexpected\x18\x03 \x01(\v2\f.order.MoneyR\bexpected\x12$\n" +
+	"\x06actual\x18\x04 \x01(\v2\f.order.MoneyR\x06actual\"\x96\x01\n" +
+	"\x17ReconcileLedgerResponse\x12\x16\n" +
+	"\x06orders\x18\x01 \x01(\rR\x06orders\x125\n" +
+	"\n" +
+	"mismatches\x18\x02 \x03(\v2\x15.order.LedgerMismatchR\n" +
+	"mismatches\x12,\n" +
+	"\x11unbalancedEntries\x18\x03 \x03(\tR\x11unbalancedEntries2\xfb\x10\n" +
+	"\fOrderService\x12@\n" +
+	"\tPostOrder\x12\x17.order.PostOrderRequest\x1a\x18.order.PostOrderResponse\"\x00\x12=\n" +
+	"\bGetOrder\x12\x16.order.GetOrderRequest\x1a\x17.order.GetOrderResponse\"\x00\x12^\n" +
+	"\x13GetOrdersForAccount\x12!.order.GetOrdersForAccountRequest\x1a\".order.GetOrdersForAccountResponse\"\x00\x12a\n" +
+	"\x14ListOrdersForAccount\x12\".order.ListOrdersForAccountRequest\x1a#.order.ListOrdersForAccountResponse\"\x00\x12X\n" +
+	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a .order.UpdateOrderStatusResponse\"\x00\x12F\n" +
+	"\vCancelOrder\x12\x19.order.CancelOrderRequest\x1a\x1a.order.CancelOrderResponse\"\x00\x12R\n" +
+	"\x0fCreatePromotion\x12\x1d.order.CreatePromotionRequest\x1a\x1e.order.CreatePromotionResponse\"\x00\x12O\n" +
+	"\x0eListPromotions\x12\x1c.order.ListPromotionsRequest\x1a\x1d.order.ListPromotionsResponse\"\x00\x12[\n" +
+	"\x12SetPromotionActive\x12 .order.SetPromotionActiveRequest\x1a!.order.SetPromotionActiveResponse\"\x00\x12C\n" +
+	"\n" +
+	"SetTaxRule\x12\x18.order.SetTaxRuleRequest\x1a\x19.order.SetTaxRuleResponse\"\x00\x12I\n" +
+	"\fListTaxRules\x12\x1a.order.ListTaxRulesRequest\x1a\x1b.order.ListTaxRulesResponse\"\x00\x12L\n" +
+	"\rDeleteTaxRule\x12\x1b.order.DeleteTaxRuleRequest\x1a\x1c.order.DeleteTaxRuleResponse\"\x00\x12a\n" +
+	"\x14CreateShippingMethod\x12\".order.CreateShippingMethodRequest\x1a#.order.CreateShippingMethodResponse\"\x00\x12^\n" +
+	"\x13ListShippingMethods\x12!.order.ListShippingMethodsRequest\x1a\".order.ListShippingMethodsResponse\"\x00\x12j\n" +
+	"\x17SetShippingMethodActive\x12%.order.SetShippingMethodActiveRequest\x1a&.order.SetShippingMethodActiveResponse\"\x00\x12O\n" +
+	"\x0eCreateShipment\x12\x1c.order.CreateShipmentRequest\x1a\x1d.order.CreateShipmentResponse\"\x00\x12a\n" +
+	"\x14UpdateShipmentStatus\x12\".order.UpdateShipmentStatusRequest\x1a#.order.UpdateShipmentStatusResponse\"\x00\x12L\n" +
+	"\rRequestReturn\x12\x1b.order.RequestReturnRequest\x1a\x1c.order.RequestReturnResponse\"\x00\x12F\n" +
+	"\vListReturns\x12\x19.order.ListReturnsRequest\x1a\x1a.order.ListReturnsResponse\"\x00\x12L\n" +
+	"\rApproveReturn\x12\x1b.order.ApproveReturnRequest\x1a\x1c.order.ApproveReturnResponse\"\x00\x12I\n" +
+	"\fRejectReturn\x12\x1a.order.RejectReturnRequest\x1a\x1b.order.RejectReturnResponse\"\x00\x12L\n" +
+	"\rReceiveReturn\x12\x1b.order.ReceiveReturnRequest\x1a\x1c.order.ReceiveReturnResponse\"\x00\x12F\n" +
+	"\vRefundOrder\x12\x19.order.RefundOrderRequest\x1a\x1a.order.RefundOrderResponse\"\x00\x12X\n" +
+	"\x11ListLedgerEntries\x12\x1f.order.ListLedgerEntriesRequest\x1a .order.ListLedgerEntriesResponse\"\x00\x12O\n" +
+	"\x0eGetStoreCredit\x12\x1c.order.GetStoreCreditRequest\x1a\x1d.order.GetStoreCreditResponse\"\x00\x12R\n" +
+	"\x0fReconcileLedger\x12\x1d.order.ReconcileLedgerRequest\x1a\x1e.order.ReconcileLedgerResponse\"\x00B\x04Z\x02./b\x06proto3"
 
 var (
 	file_order_proto_rawDescOnce sync.Once
@@ -548,33 +5182,214 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 77)
 var file_order_proto_goTypes = []any{
-	(*Order)(nil),                         // 0: pb.Order
-	(*PostOrderRequest)(nil),              // 1: pb.PostOrderRequest
-	(*PostOrderResponse)(nil),             // 2: pb.PostOrderResponse
-	(*GetOrderRequest)(nil),               // 3: pb.GetOrderRequest
-	(*GetOrderResponse)(nil),              // 4: pb.GetOrderResponse
-	(*GetOrdersForAccountRequest)(nil),    // 5: pb.GetOrdersForAccountRequest
-	(*GetOrdersForAccountResponse)(nil),   // 6: pb.GetOrdersForAccountResponse
-	(*Order_OrderProduct)(nil),            // 7: pb.Order.OrderProduct
-	(*PostOrderRequest_OrderProduct)(nil), // 8: pb.PostOrderRequest.OrderProduct
+	(*Address)(nil),                           // 0: order.Address
+	(*Money)(nil),                             // 1: order.Money
+	(*Order)(nil),                             // 2: order.Order
+	(*LineDiscount)(nil),                      // 3: order.LineDiscount
+	(*ExchangeRate)(nil),                      // 4: order.ExchangeRate
+	(*Cancellation)(nil),                      // 5: order.Cancellation
+	(*StatusChange)(nil),                      // 6: order.StatusChange
+	(*PostOrderRequest)(nil),                  // 7: order.PostOrderRequest
+	(*PostOrderResponse)(nil),                 // 8: order.PostOrderResponse
+	(*GetOrderRequest)(nil),                   // 9: order.GetOrderRequest
+	(*GetOrderResponse)(nil),                  // 10: order.GetOrderResponse
+	(*OrderFilter)(nil),                       // 11: order.OrderFilter
+	(*GetOrdersForAccountRequest)(nil),        // 12: order.GetOrdersForAccountRequest
+	(*GetOrdersForAccountResponse)(nil),       // 13: order.GetOrdersForAccountResponse
+	(*ListOrdersForAccountRequest)(nil),       // 14: order.ListOrdersForAccountRequest
+	(*ListOrdersForAccountResponse)(nil),      // 15: order.ListOrdersForAccountResponse
+	(*UpdateOrderStatusRequest)(nil),          // 16: order.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),         // 17: order.UpdateOrderStatusResponse
+	(*CancelOrderRequest)(nil),                // 18: order.CancelOrderRequest
+	(*CancelOrderResponse)(nil),               // 19: order.CancelOrderResponse
+	(*Promotion)(nil),                         // 20: order.Promotion
+	(*CreatePromotionRequest)(nil),            // 21: order.CreatePromotionRequest
+	(*CreatePromotionResponse)(nil),           // 22: order.CreatePromotionResponse
+	(*ListPromotionsRequest)(nil),             // 23: order.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),            // 24: order.ListPromotionsResponse
+	(*SetPromotionActiveRequest)(nil),         // 25: order.SetPromotionActiveRequest
+	(*SetPromotionActiveResponse)(nil),        // 26: order.SetPromotionActiveResponse
+	(*TaxRule)(nil),                           // 27: order.TaxRule
+	(*SetTaxRuleRequest)(nil),                 // 28: order.SetTaxRuleRequest
+	(*SetTaxRuleResponse)(nil),                // 29: order.SetTaxRuleResponse
+	(*ListTaxRulesRequest)(nil),               // 30: order.ListTaxRulesRequest
+	(*ListTaxRulesResponse)(nil),              // 31: order.ListTaxRulesResponse
+	(*DeleteTaxRuleRequest)(nil),              // 32: order.DeleteTaxRuleRequest
+	(*DeleteTaxRuleResponse)(nil),             // 33: order.DeleteTaxRuleResponse
+	(*ShippingMethod)(nil),                    // 34: order.ShippingMethod
+	(*CreateShippingMethodRequest)(nil),       // 35: order.CreateShippingMethodRequest
+	(*CreateShippingMethodResponse)(nil),      // 36: order.CreateShippingMethodResponse
+	(*ListShippingMethodsRequest)(nil),        // 37: order.ListShippingMethodsRequest
+	(*ListShippingMethodsResponse)(nil),       // 38: order.ListShippingMethodsResponse
+	(*SetShippingMethodActiveRequest)(nil),    // 39: order.SetShippingMethodActiveRequest
+	(*SetShippingMethodActiveResponse)(nil),   // 40: order.SetShippingMethodActiveResponse
+	(*Shipment)(nil),                          // 41: order.Shipment
+	(*ShipmentEvent)(nil),                     // 42: order.ShipmentEvent
+	(*CreateShipmentRequest)(nil),             // 43: order.CreateShipmentRequest
+	(*CreateShipmentResponse)(nil),            // 44: order.CreateShipmentResponse
+	(*UpdateShipmentStatusRequest)(nil),       // 45: order.UpdateShipmentStatusRequest
+	(*UpdateShipmentStatusResponse)(nil),      // 46: order.UpdateShipmentStatusResponse
+	(*Return)(nil),                            // 47: order.Return
+	(*ReturnEvent)(nil),                       // 48: order.ReturnEvent
+	(*RequestReturnRequest)(nil),              // 49: order.RequestReturnRequest
+	(*RequestReturnResponse)(nil),             // 50: order.RequestReturnResponse
+	(*ListReturnsRequest)(nil),                // 51: order.ListReturnsRequest
+	(*ListReturnsResponse)(nil),               // 52: order.ListReturnsResponse
+	(*ApproveReturnRequest)(nil),              // 53: order.ApproveReturnRequest
+	(*ApproveReturnResponse)(nil),             // 54: order.ApproveReturnResponse
+	(*RejectReturnRequest)(nil),               // 55: order.RejectReturnRequest
+	(*RejectReturnResponse)(nil),              // 56: order.RejectReturnResponse
+	(*ReceiveReturnRequest)(nil),              // 57: order.ReceiveReturnRequest
+	(*ReceiveReturnResponse)(nil),             // 58: order.ReceiveReturnResponse
+	(*OrderRefund)(nil),                       // 59: order.OrderRefund
+	(*RefundOrderRequest)(nil),                // 60: order.RefundOrderRequest
+	(*RefundOrderResponse)(nil),               // 61: order.RefundOrderResponse
+	(*LedgerEntry)(nil),                       // 62: order.LedgerEntry
+	(*ListLedgerEntriesRequest)(nil),          // 63: order.ListLedgerEntriesRequest
+	(*ListLedgerEntriesResponse)(nil),         // 64: order.ListLedgerEntriesResponse
+	(*GetStoreCreditRequest)(nil),             // 65: order.GetStoreCreditRequest
+	(*GetStoreCreditResponse)(nil),            // 66: order.GetStoreCreditResponse
+	(*ReconcileLedgerRequest)(nil),            // 67: order.ReconcileLedgerRequest
+	(*LedgerMismatch)(nil),                    // 68: order.LedgerMismatch
+	(*ReconcileLedgerResponse)(nil),           // 69: order.ReconcileLedgerResponse
+	(*Order_OrderProduct)(nil),                // 70: order.Order.OrderProduct
+	(*PostOrderRequest_OrderProduct)(nil),     // 71: order.PostOrderRequest.OrderProduct
+	(*ListOrdersForAccountResponse_Edge)(nil), // 72: order.ListOrdersForAccountResponse.Edge
+	(*ShippingMethod_WeightRate)(nil),         // 73: order.ShippingMethod.WeightRate
+	(*Return_Line)(nil),                       // 74: order.Return.Line
+	(*OrderRefund_Line)(nil),                  // 75: order.OrderRefund.Line
+	(*LedgerEntry_Posting)(nil),               // 76: order.LedgerEntry.Posting
 }
 var file_order_proto_depIdxs = []int32{
-	7, // 0: pb.Order.products:type_name -> pb.Order.OrderProduct
-	8, // 1: pb.PostOrderRequest.products:type_name -> pb.PostOrderRequest.OrderProduct
-	0, // 2: pb.PostOrderResponse.order:type_name -> pb.Order
-	0, // 3: pb.GetOrderResponse.order:type_name -> pb.Order
-	0, // 4: pb.GetOrdersForAccountResponse.orders:type_name -> pb.Order
-	1, // 5: pb.OrderService.PostOrder:input_type -> pb.PostOrderRequest
-	5, // 6: pb.OrderService.GetOrdersForAccount:input_type -> pb.GetOrdersForAccountRequest
-	2, // 7: pb.OrderService.PostOrder:output_type -> pb.PostOrderResponse
-	6, // 8: pb.OrderService.GetOrdersForAccount:output_type -> pb.GetOrdersForAccountResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	1,  // 0: order.Order.subtotal:type_name -> order.Money
+	1,  // 1: order.Order.discountTotal:type_name -> order.Money
+	1,  // 2: order.Order.taxTotal:type_name -> order.Money
+	1,  // 3: order.Order.shippingTotal:type_name -> order.Money
+	1,  // 4: order.Order.totalPrice:type_name -> order.Money
+	41, // 5: order.Order.shipments:type_name -> order.Shipment
+	47, // 6: order.Order.returns:type_name -> order.Return
+	59, // 7: order.Order.refunds:type_name -> order.OrderRefund
+	70, // 8: order.Order.products:type_name -> order.Order.OrderProduct
+	0,  // 9: order.Order.shippingAddress:type_name -> order.Address
+	6,  // 10: order.Order.statusHistory:type_name -> order.StatusChange
+	5,  // 11: order.Order.cancellation:type_name -> order.Cancellation
+	4,  // 12: order.Order.exchangeRates:type_name -> order.ExchangeRate
+	1,  // 13: order.LineDiscount.amount:type_name -> order.Money
+	1,  // 14: order.Cancellation.refundDue:type_name -> order.Money
+	71, // 15: order.PostOrderRequest.products:type_name -> order.PostOrderRequest.OrderProduct
+	2,  // 16: order.PostOrderResponse.order:type_name -> order.Order
+	2,  // 17: order.GetOrderResponse.order:type_name -> order.Order
+	11, // 18: order.GetOrdersForAccountRequest.filter:type_name -> order.OrderFilter
+	2,  // 19: order.GetOrdersForAccountResponse.orders:type_name -> order.Order
+	11, // 20: order.ListOrdersForAccountRequest.filter:type_name -> order.OrderFilter
+	72, // 21: order.ListOrdersForAccountResponse.edges:type_name -> order.ListOrdersForAccountResponse.Edge
+	2,  // 22: order.UpdateOrderStatusResponse.order:type_name -> order.Order
+	2,  // 23: order.CancelOrderResponse.order:type_name -> order.Order
+	1,  // 24: order.Promotion.amountOff:type_name -> order.Money
+	1,  // 25: order.Promotion.minSpend:type_name -> order.Money
+	20, // 26: order.CreatePromotionRequest.promotion:type_name -> order.Promotion
+	20, // 27: order.CreatePromotionResponse.promotion:type_name -> order.Promotion
+	20, // 28: order.ListPromotionsResponse.promotions:type_name -> order.Promotion
+	20, // 29: order.SetPromotionActiveResponse.promotion:type_name -> order.Promotion
+	27, // 30: order.SetTaxRuleResponse.rule:type_name -> order.TaxRule
+	27, // 31: order.ListTaxRulesResponse.rules:type_name -> order.TaxRule
+	1,  // 32: order.ShippingMethod.price:type_name -> order.Money
+	1,  // 33: order.ShippingMethod.freeOver:type_name -> order.Money
+	73, // 34: order.ShippingMethod.weightRates:type_name -> order.ShippingMethod.WeightRate
+	34, // 35: order.CreateShippingMethodRequest.method:type_name -> order.ShippingMethod
+	34, // 36: order.CreateShippingMethodResponse.method:type_name -> order.ShippingMethod
+	34, // 37: order.ListShippingMethodsResponse.methods:type_name -> order.ShippingMethod
+	34, // 38: order.SetShippingMethodActiveResponse.method:type_name -> order.ShippingMethod
+	42, // 39: order.Shipment.history:type_name -> order.ShipmentEvent
+	41, // 40: order.CreateShipmentResponse.shipment:type_name -> order.Shipment
+	41, // 41: order.UpdateShipmentStatusResponse.shipment:type_name -> order.Shipment
+	74, // 42: order.Return.lines:type_name -> order.Return.Line
+	1,  // 43: order.Return.refund:type_name -> order.Money
+	48, // 44: order.Return.history:type_name -> order.ReturnEvent
+	74, // 45: order.RequestReturnRequest.lines:type_name -> order.Return.Line
+	47, // 46: order.RequestReturnResponse.return:type_name -> order.Return
+	47, // 47: order.ListReturnsResponse.returns:type_name -> order.Return
+	47, // 48: order.ApproveReturnResponse.return:type_name -> order.Return
+	47, // 49: order.RejectReturnResponse.return:type_name -> order.Return
+	47, // 50: order.ReceiveReturnResponse.return:type_name -> order.Return
+	1,  // 51: order.OrderRefund.amount:type_name -> order.Money
+	75, // 52: order.OrderRefund.lines:type_name -> order.OrderRefund.Line
+	75, // 53: order.RefundOrderRequest.lines:type_name -> order.OrderRefund.Line
+	1,  // 54: order.RefundOrderRequest.amount:type_name -> order.Money
+	59, // 55: order.RefundOrderResponse.refund:type_name -> order.OrderRefund
+	76, // 56: order.LedgerEntry.postings:type_name -> order.LedgerEntry.Posting
+	62, // 57: order.ListLedgerEntriesResponse.entries:type_name -> order.LedgerEntry
+	1,  // 58: order.GetStoreCreditResponse.credit:type_name -> order.Money
+	1,  // 59: order.LedgerMismatch.expected:type_name -> order.Money
+	1,  // 60: order.LedgerMismatch.actual:type_name -> order.Money
+	68, // 61: order.ReconcileLedgerResponse.mismatches:type_name -> order.LedgerMismatch
+	1,  // 62: order.Order.OrderProduct.price:type_name -> order.Money
+	3,  // 63: order.Order.OrderProduct.discounts:type_name -> order.LineDiscount
+	1,  // 64: order.Order.OrderProduct.tax:type_name -> order.Money
+	2,  // 65: order.ListOrdersForAccountResponse.Edge.order:type_name -> order.Order
+	1,  // 66: order.ShippingMethod.WeightRate.price:type_name -> order.Money
+	1,  // 67: order.Return.Line.refund:type_name -> order.Money
+	1,  // 68: order.OrderRefund.Line.amount:type_name -> order.Money
+	1,  // 69: order.LedgerEntry.Posting.amount:type_name -> order.Money
+	7,  // 70: order.OrderService.PostOrder:input_type -> order.PostOrderRequest
+	9,  // 71: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
+	12, // 72: order.OrderService.GetOrdersForAccount:input_type -> order.GetOrdersForAccountRequest
+	14, // 73: order.OrderService.ListOrdersForAccount:input_type -> order.ListOrdersForAccountRequest
+	16, // 74: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
+	18, // 75: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
+	21, // 76: order.OrderService.CreatePromotion:input_type -> order.CreatePromotionRequest
+	23, // 77: order.OrderService.ListPromotions:input_type -> order.ListPromotionsRequest
+	25, // 78: order.OrderService.SetPromotionActive:input_type -> order.SetPromotionActiveRequest
+	28, // 79: order.OrderService.SetTaxRule:input_type -> order.SetTaxRuleRequest
+	30, // 80: order.OrderService.ListTaxRules:input_type -> order.ListTaxRulesRequest
+	32, // 81: order.OrderService.DeleteTaxRule:input_type -> order.DeleteTaxRuleRequest
+	35, // 82: order.OrderService.CreateShippingMethod:input_type -> order.CreateShippingMethodRequest
+	37, // 83: order.OrderService.ListShippingMethods:input_type -> order.ListShippingMethodsRequest
+	39, // 84: order.OrderService.SetShippingMethodActive:input_type -> order.SetShippingMethodActiveRequest
+	43, // 85: order.OrderService.CreateShipment:input_type -> order.CreateShipmentRequest
+	45, // 86: order.OrderService.UpdateShipmentStatus:input_type -> order.UpdateShipmentStatusRequest
+	49, // 87: order.OrderService.RequestReturn:input_type -> order.RequestReturnRequest
+	51, // 88: order.OrderService.ListReturns:input_type -> order.ListReturnsRequest
+	53, // 89: order.OrderService.ApproveReturn:input_type -> order.ApproveReturnRequest
+	55, // 90: order.OrderService.RejectReturn:input_type -> order.RejectReturnRequest
+	57, // 91: order.OrderService.ReceiveReturn:input_type -> order.ReceiveReturnRequest
+	60, // 92: order.OrderService.RefundOrder:input_type -> order.RefundOrderRequest
+	63, // 93: order.OrderService.ListLedgerEntries:input_type -> order.ListLedgerEntriesRequest
+	65, // 94: order.OrderService.GetStoreCredit:input_type -> order.GetStoreCreditRequest
+	67, // 95: order.OrderService.ReconcileLedger:input_type -> order.ReconcileLedgerRequest
+	8,  // 96: order.OrderService.PostOrder:output_type -> order.PostOrderResponse
+	10, // 97: order.OrderService.GetOrder:output_type -> order.GetOrderResponse
+	13, // 98: order.OrderService.GetOrdersForAccount:output_type -> order.GetOrdersForAccountResponse
+	15, // 99: order.OrderService.ListOrdersForAccount:output_type -> order.ListOrdersForAccountResponse
+	17, // 100: order.OrderService.UpdateOrderStatus:output_type -> order.UpdateOrderStatusResponse
+	19, // 101: order.OrderService.CancelOrder:output_type -> order.CancelOrderResponse
+	22, // 102: order.OrderService.CreatePromotion:output_type -> order.CreatePromotionResponse
+	24, // 103: order.OrderService.ListPromotions:output_type -> order.ListPromotionsResponse
+	26, // 104: order.OrderService.SetPromotionActive:output_type -> order.SetPromotionActiveResponse
+	29, // 105: order.OrderService.SetTaxRule:output_type -> order.SetTaxRuleResponse
+	31, // 106: order.OrderService.ListTaxRules:output_type -> order.ListTaxRulesResponse
+	33, // 107: order.OrderService.DeleteTaxRule:output_type -> order.DeleteTaxRuleResponse
+	36, // 108: order.OrderService.CreateShippingMethod:output_type -> order.CreateShippingMethodResponse
+	38, // 109: order.OrderService.ListShippingMethods:output_type -> order.ListShippingMethodsResponse
+	40, // 110: order.OrderService.SetShippingMethodActive:output_type -> order.SetShippingMethodActiveResponse
+	44, // 111: order.OrderService.CreateShipment:output_type -> order.CreateShipmentResponse
+	46, // 112: order.OrderService.UpdateShipmentStatus:output_type -> order.UpdateShipmentStatusResponse
+	50, // 113: order.OrderService.RequestReturn:output_type -> order.RequestReturnResponse
+	52, // 114: order.OrderService.ListReturns:output_type -> order.ListReturnsResponse
+	54, // 115: order.OrderService.ApproveReturn:output_type -> order.ApproveReturnResponse
+	56, // 116: order.OrderService.RejectReturn:output_type -> order.RejectReturnResponse
+	58, // 117: order.OrderService.ReceiveReturn:output_type -> order.ReceiveReturnResponse
+	61, // 118: order.OrderService.RefundOrder:output_type -> order.RefundOrderResponse
+	64, // 119: order.OrderService.ListLedgerEntries:output_type -> order.ListLedgerEntriesResponse
+	66, // 120: order.OrderService.GetStoreCredit:output_type -> order.GetStoreCreditResponse
+	69, // 121: order.OrderService.ReconcileLedger:output_type -> order.ReconcileLedgerResponse
+	96, // [96:122] is the sub-list for method output_type
+	70, // [70:96] is the sub-list for method input_type
+	70, // [70:70] is the sub-list for extension type_name
+	70, // [70:70] is the sub-list for extension extendee
+	0,  // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -588,7 +5403,7 @@ func file_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   77,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,327 @@
+// Coupons and the promotion rules behind them. A customer redeems a promotion by passing its coupon code with
+// a new order; the discounts it grants are stored per order line, so an order shows how its total came about:
+//
+//	subtotal (quantity × unit price of every line) - discounts = total
+//
+// Coupons are applied in the order they are given, each to what is left of every line after the ones before it.
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	PromotionPercentage  = "percentage"   // PercentOff percent off the eligible lines
+	PromotionFixedAmount = "fixed_amount" // AmountOff off the eligible lines, split between them by value
+	PromotionBuyXGetY    = "buy_x_get_y"  // for every BuyQuantity units of a line, GetQuantity more are free
+)
+
+var (
+	ErrInvalidCoupon      = errors.New("coupon cannot be applied")
+	ErrInvalidPromotion   = errors.New("invalid promotion")
+	ErrPromotionExists    = errors.New("a promotion with this coupon code already exists")
+	ErrPromotionNotFound  = errors.New("promotion not found")
+	ErrCouponLimitReached = fmt.Errorf("%w: usage limit reached", ErrInvalidCoupon)
+)
+
+type Promotion struct {
+	ID          string
+	Code        string // coupon code, stored upper case; codes are matched case-insensitively
+	Description string // shown on the order lines it discounts
+	Kind        string
+	PercentOff  uint32      // 1-100, percentage promotions only
+	AmountOff   money.Money // fixed_amount promotions only
+	BuyQuantity uint32      // buy_x_get_y promotions only
+	GetQuantity uint32      // buy_x_get_y promotions only
+	ProductIDs  []string    // products the promotion applies to; empty means every product
+	// Subtotal the order must reach for the coupon to apply; zero means no minimum.
+	// Orders are only compared with it, and only discounted by AmountOff, in the same currency.
+	MinSpend          money.Money
+	MaxUsesPerAccount uint32    // orders per account that may use the coupon, cancelled ones aside; 0 means no limit
+	StartsAt          time.Time // inclusive; zero means no start
+	EndsAt            time.Time // exclusive; zero means no end
+	Stackable         bool      // whether the coupon may be combined with other coupons on one order
+	Active            bool
+	CreatedAt         time.Time
+}
+
+// Part of a line's price taken off by one promotion.
+type LineDiscount struct {
+	PromotionID string
+	Code        string
+	Description string
+	Amount      money.Money // off the whole line, not per unit
+}
+
+// What the line costs after its discounts, not counting tax added on top of the price.
+func (p OrderedProduct) Total() money.Money {
+	total := money.New(p.Price.Amount*int64(p.Quantity), p.Price.Currency)
+	for _, d := range p.Discounts {
+		total.Amount -= d.Amount.Amount
+	}
+	return total
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (p Promotion) applies(productID string) bool {
+	if len(p.ProductIDs) == 0 {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == productID {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks a promotion before it is stored.
+func validatePromotion(p Promotion) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s", ErrInvalidPromotion, reason)
+	}
+	if p.Code == "" || len(p.Code) > 64 {
+		return invalid("code must be 1 to 64 characters")
+	}
+	switch p.Kind {
+	case PromotionPercentage:
+		if p.PercentOff == 0 || p.PercentOff > 100 {
+			return invalid("percentOff must be between 1 and 100")
+		}
+	case PromotionFixedAmount:
+		if !p.AmountOff.IsPositive() || !money.ValidCurrency(p.AmountOff.Currency) {
+			return invalid("amountOff must be a positive amount")
+		}
+	case PromotionBuyXGetY:
+		if p.BuyQuantity == 0 || p.GetQuantity == 0 {
+			return invalid("buyQuantity and getQuantity must be at least 1")
+		}
+	default:
+		return invalid("unknown kind " + p.Kind)
+	}
+	if p.MinSpend.IsNegative() || (!p.MinSpend.IsZero() && !money.ValidCurrency(p.MinSpend.Currency)) {
+		return invalid("minSpend must be a positive amount")
+	}
+	if p.Kind == PromotionFixedAmount && !p.MinSpend.IsZero() && p.MinSpend.Currency != p.AmountOff.Currency {
+		return invalid("minSpend and amountOff must be in the same currency")
+	}
+	if !p.StartsAt.IsZero() && !p.EndsAt.IsZero() && !p.EndsAt.After(p.StartsAt) {
+		return invalid("endsAt must be after startsAt")
+	}
+	return nil
+}
+
+// Creates a promotion; its coupon code must not be taken yet. The promotion is active right away.
+func (s orderService) CreatePromotion(ctx context.Context, p Promotion) (*Promotion, error) {
+	p.ID = ksuid.New().String()
+	p.Code = normalizeCode(p.Code)
+	p.Description = strings.TrimSpace(p.Description)
+	p.Active = true
+	p.CreatedAt = time.Now().UTC()
+	if p.ProductIDs == nil {
+		p.ProductIDs = []string{}
+	}
+	// Only the settings of the promotion's kind are kept.
+	if p.Kind != PromotionPercentage {
+		p.PercentOff = 0
+	}
+	if p.Kind != PromotionFixedAmount {
+		p.AmountOff = money.Money{}
+	}
+	if p.Kind != PromotionBuyXGetY {
+		p.BuyQuantity, p.GetQuantity = 0, 0
+	}
+	if err := validatePromotion(p); err != nil {
+		return nil, err
+	}
+	if err := s.repository.PutPromotion(ctx, p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s orderService) ListPromotions(ctx context.Context) ([]Promotion, error) {
+	return s.repository.ListPromotions(ctx)
+}
+
+// Switches a promotion on or off. Orders already placed keep their discounts.
+func (s orderService) SetPromotionActive(ctx context.Context, id string, active bool) (*Promotion, error) {
+	if err := s.repository.SetPromotionActive(ctx, id, active); err != nil {
+		return nil, err
+	}
+	return s.repository.GetPromotion(ctx, id)
+}
+
+// Looks up the promotions behind the coupon codes of an order placed by accountID at time now and checks that
+// each of them may be used: it exists, is active and running, the account hasn't used it up, and it isn't
+// combined with other coupons unless every one of them is stackable. Repeated codes count once.
+func (s orderService) redeemableCoupons(ctx context.Context, accountID string, codes []string, now time.Time) ([]Promotion, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, code := range codes {
+		code = normalizeCode(code)
+		if code != "" && !seen[code] {
+			seen[code] = true
+			normalized = append(normalized, code)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	found, err := s.repository.GetPromotionsByCode(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	byCode := map[string]Promotion{}
+	for _, p := range found {
+		byCode[p.Code] = p
+	}
+	promotions := []Promotion{}
+	for _, code := range normalized {
+		p, ok := byCode[code]
+		switch {
+		case !ok || !p.Active:
+			return nil, fmt.Errorf("%w: %s: unknown coupon", ErrInvalidCoupon, code)
+		case !p.StartsAt.IsZero() && now.Before(p.StartsAt):
+			return nil, fmt.Errorf("%w: %s: not valid yet", ErrInvalidCoupon, code)
+		case !p.EndsAt.IsZero() && !now.Before(p.EndsAt):
+			return nil, fmt.Errorf("%w: %s: expired", ErrInvalidCoupon, code)
+		case !p.Stackable && len(normalized) > 1:
+			return nil, fmt.Errorf("%w: %s: cannot be combined with other coupons", ErrInvalidCoupon, code)
+		}
+		// Checked again when the order is written, where concurrent orders can't both slip under the limit.
+		if p.MaxUsesPerAccount > 0 {
+			used, err := s.repository.CountRedemptions(ctx, p.ID, accountID)
+			if err != nil {
+				return nil, err
+			}
+			if used >= p.MaxUsesPerAccount {
+				return nil, fmt.Errorf("%w: %s", ErrCouponLimitReached, code)
+			}
+		}
+		promotions = append(promotions, p)
+	}
+	return promotions, nil
+}
+
+// Applies the promotions, in order, to the lines of an order in the given currency with the given subtotal,
+// adding a LineDiscount to every line a promotion takes something off. A promotion that takes nothing off
+// (e.g. no line qualifies, or the minimum spend isn't reached) fails the whole order, so a customer never
+// thinks a coupon was used when it wasn't.
+func applyPromotions(products []OrderedProduct, subtotal money.Money, promotions []Promotion) ([]OrderedProduct, error) {
+	lines := make([]OrderedProduct, len(products))
+	copy(lines, products)
+	for _, p := range promotions {
+		if !p.MinSpend.IsZero() {
+			if p.MinSpend.Currency != subtotal.Currency {
+				return nil, fmt.Errorf("%w: %s: only valid for orders in %s", ErrInvalidCoupon, p.Code, p.MinSpend.Currency)
+			}
+			if subtotal.Amount < p.MinSpend.Amount {
+				return nil, fmt.Errorf("%w: %s: requires a subtotal of at least %s", ErrInvalidCoupon, p.Code, p.MinSpend)
+			}
+		}
+		amounts, err := promotionDiscounts(p, lines, subtotal.Currency)
+		if err != nil {
+			return nil, err
+		}
+		applied := false
+		for i, amount := range amounts {
+			if amount == 0 {
+				continue
+			}
+			applied = true
+			discounts := append([]LineDiscount{}, lines[i].Discounts...)
+			lines[i].Discounts = append(discounts, LineDiscount{
+				PromotionID: p.ID,
+				Code:        p.Code,
+				Description: p.Description,
+				Amount:      money.New(amount, subtotal.Currency),
+			})
+		}
+		if !applied {
+			return nil, fmt.Errorf("%w: %s: no product of the order qualifies", ErrInvalidCoupon, p.Code)
+		}
+	}
+	return lines, nil
+}
+
+// Minor units the promotion takes off each line, never more than what is left of the line.
+func promotionDiscounts(p Promotion, lines []OrderedProduct, currency string) ([]int64, error) {
+	amounts := make([]int64, len(lines))
+	remaining := make([]int64, len(lines))
+	for i, line := range lines {
+		if p.applies(line.ID) {
+			remaining[i] = line.Total().Amount
+		}
+	}
+	switch p.Kind {
+	case PromotionPercentage:
+		for i := range lines {
+			// Rounded down, in the customer's disfavour by less than a cent.
+			amounts[i] = mulDiv(remaining[i], int64(p.PercentOff), 100)
+		}
+	case PromotionBuyXGetY:
+		for i, line := range lines {
+			if remaining[i] == 0 {
+				continue
+			}
+			free := line.Quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+			amounts[i] = min(int64(free)*line.Price.Amount, remaining[i])
+		}
+	case PromotionFixedAmount:
+		if p.AmountOff.Currency != currency {
+			return nil, fmt.Errorf("%w: %s: only valid for orders in %s", ErrInvalidCoupon, p.Code, p.AmountOff.Currency)
+		}
+		var eligible int64
+		for _, r := range remaining {
+			eligible += r
+		}
+		off := min(p.AmountOff.Amount, eligible)
+		if off == 0 {
+			break
+		}
+		// Each line gets its share of the amount by value; the cents lost to rounding go to the first lines.
+		var given int64
+		for i, r := range remaining {
+			amounts[i] = mulDiv(off, r, eligible)
+			given += amounts[i]
+		}
+		for i := 0; given < off && i < len(amounts); i++ {
+			if amounts[i] < remaining[i] {
+				amounts[i]++
+				given++
+			}
+		}
+	}
+	return amounts, nil
+}
+
+// a × b / c rounded down, without overflowing on the way.
+func mulDiv(a, b, c int64) int64 {
+	n := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	return n.Quo(n, big.NewInt(c)).Int64()
+}
+
+// Adds up what the promotions took off the lines.
+func discountTotal(products []OrderedProduct, currency string) money.Money {
+	total := money.Zero(currency)
+	for _, p := range products {
+		for _, d := range p.Discounts {
+			total.Amount += d.Amount.Amount
+		}
+	}
+	return total
+}
@@ -0,0 +1,220 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+)
+
+func percentOff(code string, percent uint32, productIDs ...string) Promotion {
+	return Promotion{ID: code, Code: code, Kind: PromotionPercentage, PercentOff: percent, ProductIDs: productIDs, Active: true}
+}
+
+func amountOff(code string, amount int64, currency string) Promotion {
+	return Promotion{ID: code, Code: code, Kind: PromotionFixedAmount, AmountOff: money.New(amount, currency), Active: true}
+}
+
+// Coupons are applied one after the other, each to what the ones before it left of every line.
+func TestApplyPromotions(t *testing.T) {
+	withMinSpend := func(p Promotion, amount int64, currency string) Promotion {
+		p.MinSpend = money.New(amount, currency)
+		return p
+	}
+	twoLines := []OrderedProduct{line("p1", 1000, 2), line("p2", 250, 1)}
+	tests := []struct {
+		name       string
+		products   []OrderedProduct
+		promotions []Promotion
+		want       [][]int64 // minor units each promotion takes off each line, in order
+		wantErr    error
+	}{
+		{
+			name:       "percentage off every line",
+			products:   twoLines,
+			promotions: []Promotion{percentOff("TEN", 10)},
+			want:       [][]int64{{200}, {25}},
+		},
+		{
+			name:       "percentage rounded down",
+			products:   []OrderedProduct{line("p1", 333, 1)},
+			promotions: []Promotion{percentOff("TEN", 10)},
+			want:       [][]int64{{33}},
+		},
+		{
+			name:       "percentage off the listed products only",
+			products:   twoLines,
+			promotions: []Promotion{percentOff("TEN", 10, "p2")},
+			want:       [][]int64{{}, {25}},
+		},
+		{
+			name:       "fixed amount shared by value, the rounded-off cent to the first line",
+			products:   []OrderedProduct{line("p1", 1000, 1), line("p2", 500, 1)},
+			promotions: []Promotion{amountOff("OFF", 100, "USD")},
+			want:       [][]int64{{67}, {33}},
+		},
+		{
+			name:       "fixed amount capped at the lines",
+			products:   []OrderedProduct{line("p1", 1000, 1), line("p2", 500, 1)},
+			promotions: []Promotion{amountOff("OFF", 5000, "USD")},
+			want:       [][]int64{{1000}, {500}},
+		},
+		{
+			name:     "buy two get one",
+			products: []OrderedProduct{line("p1", 300, 7)},
+			promotions: []Promotion{
+				{ID: "B2G1", Code: "B2G1", Kind: PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1, Active: true},
+			},
+			want: [][]int64{{600}},
+		},
+		{
+			name:       "stacked percentages apply to what is left",
+			products:   []OrderedProduct{line("p1", 1000, 1)},
+			promotions: []Promotion{percentOff("HALF", 50), percentOff("HALFAGAIN", 50)},
+			want:       [][]int64{{500, 250}},
+		},
+		{
+			name:       "fixed amount after a percentage",
+			products:   []OrderedProduct{line("p1", 1000, 1)},
+			promotions: []Promotion{percentOff("TEN", 10), amountOff("OFF", 1000, "USD")},
+			want:       [][]int64{{100, 900}},
+		},
+		{
+			name:       "a stacked coupon with nothing left to take off",
+			products:   []OrderedProduct{line("p1", 1000, 1)},
+			promotions: []Promotion{percentOff("ALL", 100), percentOff("TEN", 10)},
+			wantErr:    ErrInvalidCoupon,
+		},
+		{
+			name:       "minimum spend reached",
+			products:   twoLines,
+			promotions: []Promotion{withMinSpend(percentOff("TEN", 10), 2250, "USD")},
+			want:       [][]int64{{200}, {25}},
+		},
+		{
+			name:       "minimum spend not reached",
+			products:   twoLines,
+			promotions: []Promotion{withMinSpend(percentOff("TEN", 10), 2251, "USD")},
+			wantErr:    ErrInvalidCoupon,
+		},
+		{
+			name:       "minimum spend in another currency",
+			products:   twoLines,
+			promotions: []Promotion{withMinSpend(percentOff("TEN", 10), 100, "EUR")},
+			wantErr:    ErrInvalidCoupon,
+		},
+		{
+			name:       "fixed amount in another currency",
+			products:   twoLines,
+			promotions: []Promotion{amountOff("OFF", 100, "EUR")},
+			wantErr:    ErrInvalidCoupon,
+		},
+		{
+			name:       "no product qualifies",
+			products:   twoLines,
+			promotions: []Promotion{percentOff("TEN", 10, "p3")},
+			wantErr:    ErrInvalidCoupon,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subtotal, err := orderTotal(tt.products)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := applyPromotions(tt.products, subtotal, tt.promotions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			for i, p := range got {
+				amounts := []int64{}
+				for _, d := range p.Discounts {
+					amounts = append(amounts, d.Amount.Amount)
+				}
+				if !equalAmounts(amounts, tt.want[i]) {
+					t.Errorf("line %s: got discounts %v, want %v", p.ID, amounts, tt.want[i])
+				}
+				if len(tt.products[i].Discounts) != 0 {
+					t.Errorf("line %s: the given line was changed", p.ID)
+				}
+			}
+		})
+	}
+}
+
+func equalAmounts(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Coupons must exist and be running, within the account's limit, and only combined if all of them are stackable.
+func TestRedeemableCoupons(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	stackable := func(p Promotion) Promotion {
+		p.Stackable = true
+		return p
+	}
+	inactive := percentOff("OFF", 10)
+	inactive.Active = false
+	notYet := percentOff("SOON", 10)
+	notYet.StartsAt = now.Add(time.Hour)
+	ended := percentOff("OVER", 10)
+	ended.EndsAt = now // exclusive
+	limited := percentOff("ONCE", 10)
+	limited.MaxUsesPerAccount = 1
+	usedUp := percentOff("USED", 10)
+	usedUp.MaxUsesPerAccount = 1
+
+	tests := []struct {
+		name    string
+		codes   []string
+		want    []string
+		wantErr error
+	}{
+		{"no codes", nil, nil, nil},
+		{"matched case-insensitively", []string{" ten "}, []string{"TEN"}, nil},
+		{"repeated code counts once", []string{"TEN", "ten"}, []string{"TEN"}, nil},
+		{"unknown", []string{"NOPE"}, nil, ErrInvalidCoupon},
+		{"inactive", []string{"OFF"}, nil, ErrInvalidCoupon},
+		{"not started", []string{"SOON"}, nil, ErrInvalidCoupon},
+		{"ended", []string{"OVER"}, nil, ErrInvalidCoupon},
+		{"stackable coupons combined", []string{"S1", "S2"}, []string{"S1", "S2"}, nil},
+		{"a non-stackable coupon combined", []string{"S1", "TEN"}, nil, ErrInvalidCoupon},
+		{"under the limit", []string{"ONCE"}, []string{"ONCE"}, nil},
+		{"limit reached", []string{"USED"}, nil, ErrCouponLimitReached},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepository()
+			repo.promotions = []Promotion{
+				percentOff("TEN", 10), inactive, notYet, ended, limited, usedUp,
+				stackable(percentOff("S1", 10)), stackable(percentOff("S2", 5)),
+			}
+			repo.redemptions[usedUp.ID+"|account"] = 1
+			s := orderService{repository: repo}
+			got, err := s.redeemableCoupons(context.Background(), "account", tt.codes, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			codes := []string{}
+			for _, p := range got {
+				codes = append(codes, p.Code)
+			}
+			if !equalIDs(codes, tt.want) {
+				t.Errorf("got coupons %v, want %v", codes, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Partial refunds. Staff give back part of what was paid for an order, either for some units of its lines or as an
+// amount, to the customer's payment (through the payment service) or as store credit. Approved returns are
+// refunded the same way (see returns.go). Every refund is recorded in the ledger once it completes, see ledger.go.
+//
+// A refund to the payment is written pending before the payment service is asked for the money, so its amount
+// (and its return) can't be refunded a second time meanwhile, and completed once the money went back. The refund's
+// id is the idempotency key of the payment service's refund, so asking again after a failure or a crash never
+// refunds twice; ResumeRefunds does that for refunds left pending.
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Where the money of a refund goes.
+const (
+	RefundToPayment     = "payment"
+	RefundToStoreCredit = "store_credit"
+)
+
+var (
+	ErrInvalidRefund  = errors.New("invalid refund")
+	ErrNotRefundable  = errors.New("refund exceeds what is left of the order's payment")
+	ErrNoPayments     = errors.New("there is no payment service to refund through, refund to store credit instead")
+	ErrRefundNotFound = errors.New("refund not found")
+)
+
+// Pending refunds are left to the request that wrote them for this long before ResumeRefunds retries them.
+const refundRetryDelay = time.Minute
+
+type OrderRefund struct {
+	ID          string
+	OrderID     string
+	ReturnID    string      // the return refunded, empty if the refund isn't for a return
+	Amount      money.Money // in the order's currency; the sum of the lines' Amount if there are lines
+	Destination string      // RefundToPayment or RefundToStoreCredit
+	Reason      string
+	Lines       []RefundLine // empty for refunds of an amount
+	RefundedBy  string       // account id of the caller who gave the refund, empty for the system
+	CreatedAt   time.Time
+	CompletedAt time.Time // zero while the payment service hasn't made a refund to the payment yet
+}
+
+// Units of one order line being refunded.
+type RefundLine struct {
+	ProductID string
+	Quantity  uint32
+	Amount    money.Money // what the customer paid for these units, see returnRefund
+}
+
+// Reports whether the order was ever paid, from its status history.
+func wasPaid(o Order) bool {
+	for _, c := range o.StatusHistory {
+		if c.To == StatusPaid {
+			return true
+		}
+	}
+	return false
+}
+
+// Units of each product of the order that are refunded, or will be once their return is approved: those of
+// returns that weren't rejected and those of refunds by line that weren't for a return.
+func claimedQuantities(o Order) map[string]uint32 {
+	claimed := returnedQuantities(o)
+	for _, ref := range o.Refunds {
+		if ref.ReturnID != "" {
+			continue // counted with its return
+		}
+		for _, l := range ref.Lines {
+			claimed[l.ProductID] += l.Quantity
+		}
+	}
+	return claimed
+}
+
+// What can still be refunded for the order: its total once it has been paid, less its refunds, pending ones included.
+func refundable(o Order) money.Money {
+	left := money.Zero(o.TotalPrice.Currency)
+	if !wasPaid(o) {
+		return left
+	}
+	left.Amount = o.TotalPrice.Amount
+	for _, ref := range o.Refunds {
+		left.Amount -= ref.Amount.Amount
+	}
+	return left
+}
+
+// Gives back part of what was paid for an order: for units of its lines, priced like returned units (see
+// returnRefund), or, without lines, amount. Lines for the same product are merged; units that were refunded
+// already or are part of a return that wasn't rejected can't be refunded again, and the refunds of an order
+// never add up to more than its total. destination is RefundToPayment or RefundToStoreCredit.
+// refundedBy is the account id of the caller.
+func (s orderService) RefundOrder(ctx context.Context, orderID string, lines []RefundLine, amount money.Money, destination, reason, refundedBy string) (*OrderRefund, error) {
+	o, err := s.repository.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	invalid := func(problem string) error {
+		return fmt.Errorf("%w: %s", ErrInvalidRefund, problem)
+	}
+	if destination != RefundToPayment && destination != RefundToStoreCredit {
+		return nil, invalid("unknown destination " + destination)
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > 1000 {
+		return nil, invalid("reason must be at most 1000 characters")
+	}
+	ref := OrderRefund{
+		ID:          ksuid.New().String(),
+		OrderID:     o.ID,
+		Amount:      money.Zero(o.TotalPrice.Currency),
+		Destination: destination,
+		Reason:      reason,
+		Lines:       []RefundLine{},
+		RefundedBy:  refundedBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+	switch {
+	case len(lines) > 0 && !amount.IsZero():
+		return nil, invalid("give either lines or an amount, not both")
+	case len(lines) > 0:
+		requested := map[string]uint64{}
+		for _, l := range lines {
+			if l.Quantity == 0 {
+				return nil, invalid("quantities must be at least 1")
+			}
+			requested[l.ProductID] += uint64(l.Quantity)
+		}
+		claimed := claimedQuantities(*o)
+		for _, p := range o.Products {
+			quantity, ok := requested[p.ID]
+			if !ok {
+				continue
+			}
+			delete(requested, p.ID)
+			if quantity > uint64(p.Quantity-claimed[p.ID]) {
+				return nil, invalid(fmt.Sprintf("only %d units of %s are left to refund", p.Quantity-claimed[p.ID], p.ID))
+			}
+			l := RefundLine{
+				ProductID: p.ID,
+				Quantity:  uint32(quantity),
+				Amount:    returnRefund(p, o.PricesIncludeTax, claimed[p.ID], uint32(quantity)),
+			}
+			ref.Lines = append(ref.Lines, l)
+			ref.Amount.Amount += l.Amount.Amount
+		}
+		for id := range requested {
+			return nil, invalid("product " + id + " is not part of the order")
+		}
+	default:
+		if !amount.IsPositive() || amount.Currency != o.TotalPrice.Currency {
+			return nil, invalid("amount must be positive and in the order's currency " + o.TotalPrice.Currency)
+		}
+		ref.Amount = amount
+	}
+	if err := s.refund(ctx, o, &ref); err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+// Pays ref out and records it, together with its ledger entry. A refund to store credit (or of nothing) is recorded
+// in one go; one to the payment is written pending and then made through the payment service, see payOut.
+// ref.CompletedAt is set once the refund completed. o tells early whether the refund fits; PutRefund checks it
+// again with the order locked, before any money moves, along with the units its lines were priced against.
+func (s orderService) refund(ctx context.Context, o *Order, ref *OrderRefund) error {
+	if left := refundable(*o); ref.Amount.Amount > left.Amount {
+		return fmt.Errorf("%w: %s is left", ErrNotRefundable, left)
+	}
+	claimed := claimedQuantities(*o)
+	if ref.Destination != RefundToPayment || ref.Amount.IsZero() {
+		ref.CompletedAt = ref.CreatedAt
+		return s.repository.PutRefund(ctx, *ref, claimed)
+	}
+	if s.payments == nil {
+		return ErrNoPayments
+	}
+	if err := s.repository.PutRefund(ctx, *ref, claimed); err != nil {
+		return err
+	}
+	return s.payOut(ctx, ref)
+}
+
+// Has the payment service make a pending refund to the payment, and completes it. Only if the payment service
+// refused the refund as the payment is refunded in full already is the pending refund deleted. Otherwise, whether
+// it couldn't tell (e.g. it was unavailable) or refused for another reason, the refund stays pending, holding its
+// amount, and ResumeRefunds asks for it again under the same idempotency key, logging why it failed each time.
+func (s orderService) payOut(ctx context.Context, ref *OrderRefund) error {
+	reason := ref.Reason
+	if reason == "" {
+		reason = "refund " + ref.ID
+	}
+	if err := s.payments.Refund(ctx, ref.OrderID, ref.Amount, reason, ref.ID); err != nil {
+		if errors.Is(err, ErrNothingToRefund) {
+			if deleteErr := s.repository.DeleteRefund(context.WithoutCancel(ctx), ref.ID); deleteErr != nil {
+				log.Printf("deleting refund %s of a fully refunded payment: %v", ref.ID, deleteErr)
+			}
+			return err
+		}
+		return fmt.Errorf("refund %s is pending: %w", ref.ID, err)
+	}
+	completedAt := time.Now().UTC()
+	if err := s.repository.CompleteRefund(ctx, ref.ID, completedAt); err != nil {
+		return err
+	}
+	ref.CompletedAt = completedAt
+	return nil
+}
+
+// Asks the payment service again for the refunds left pending, e.g. because it was unavailable or the process died
+// while waiting for it. Meant to be run periodically. Returns how many refunds were completed.
+func (s orderService) ResumeRefunds(ctx context.Context) (int, error) {
+	if s.payments == nil {
+		return 0, nil
+	}
+	refunds, err := s.repository.ListPendingRefunds(ctx, time.Now().UTC().Add(-refundRetryDelay))
+	if err != nil {
+		return 0, err
+	}
+	completed := 0
+	for i := range refunds {
+		ref := &refunds[i]
+		if err := s.payOut(ctx, ref); err != nil {
+			log.Printf("resuming refund %s of order %s: %v", ref.ID, ref.OrderID, err)
+			continue
+		}
+		completed++
+	}
+	return completed, nil
+}
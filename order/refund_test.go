@@ -0,0 +1,143 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Microservices-based-E-commerce-System/money"
+)
+
+// A paid order of 20.00 and the checkout it was placed with.
+func paidOrder(t *testing.T) (checkout, *Order) {
+	t.Helper()
+	c := newCheckout(nil)
+	o, err := c.postOrder(line("p1", 1000, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, o
+}
+
+func (c checkout) refundAmount(orderID string, amount int64) (*OrderRefund, error) {
+	return c.service.RefundOrder(context.Background(), orderID, nil, money.New(amount, "USD"), RefundToPayment, "", "admin")
+}
+
+func TestRefundToPayment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("completes", func(t *testing.T) {
+		c, o := paidOrder(t)
+		ref, err := c.refundAmount(o.ID, 500)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ref.CompletedAt.IsZero() {
+			t.Error("got the refund pending, want it completed")
+		}
+		if got := c.payments.refunds[o.ID]; got.Amount != 500 {
+			t.Errorf("got %s refunded, want 5.00", got)
+		}
+	})
+
+	t.Run("refunds the order once they cover its total", func(t *testing.T) {
+		c, o := paidOrder(t)
+		if _, err := c.service.UpdateOrderStatus(ctx, o.ID, StatusRefunded, "admin", ""); !errors.Is(err, ErrInvalidTransition) {
+			t.Errorf("moving the order to refunded by hand: got error %v, want %v", err, ErrInvalidTransition)
+		}
+		for _, tt := range []struct {
+			amount     int64
+			wantStatus string
+		}{{500, StatusPaid}, {1500, StatusRefunded}} {
+			if _, err := c.refundAmount(o.ID, tt.amount); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := c.repository.GetOrder(ctx, o.ID); got.Status != tt.wantStatus {
+				t.Errorf("after refunding %d: got order %s, want it %s", tt.amount, got.Status, tt.wantStatus)
+			}
+		}
+	})
+
+	t.Run("refused", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			refusal     error
+			wantRefunds int
+		}{
+			{"as the payment is fully refunded, is deleted", ErrNothingToRefund, 0},
+			{"for another reason, stays pending", ErrRefundRefused, 1},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				c, o := paidOrder(t)
+				c.payments.refuseRefunds = tt.refusal
+				if _, err := c.refundAmount(o.ID, 500); !errors.Is(err, tt.refusal) {
+					t.Fatalf("got error %v, want %v", err, tt.refusal)
+				}
+				got, _ := c.repository.GetOrder(ctx, o.ID)
+				if len(got.Refunds) != tt.wantRefunds {
+					t.Fatalf("got refunds %+v, want %d", got.Refunds, tt.wantRefunds)
+				}
+				for _, ref := range got.Refunds {
+					if !ref.CompletedAt.IsZero() {
+						t.Errorf("got refund %+v completed, want it pending", ref)
+					}
+				}
+			})
+		}
+	})
+
+	// The payment service made the refund, but its answer got lost: the refund stays pending, holding its amount,
+	// and asking again completes it without refunding twice.
+	t.Run("stays pending until resumed", func(t *testing.T) {
+		c, o := paidOrder(t)
+		c.payments.failRefunds = 1
+		if _, err := c.refundAmount(o.ID, 1500); !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("got error %v, want %v", err, ErrUnavailable)
+		}
+		pending, _ := c.repository.GetOrder(ctx, o.ID)
+		if len(pending.Refunds) != 1 || !pending.Refunds[0].CompletedAt.IsZero() {
+			t.Fatalf("got refunds %+v, want one pending", pending.Refunds)
+		}
+		if _, err := c.refundAmount(o.ID, 1000); !errors.Is(err, ErrNotRefundable) {
+			t.Errorf("refunding past the pending refund: got error %v, want %v", err, ErrNotRefundable)
+		}
+
+		if n, err := c.service.ResumeRefunds(ctx); err != nil || n != 0 {
+			t.Fatalf("resumed %d refunds (error %v) right after they were written, want none", n, err)
+		}
+		c.repository.ageRefunds(2 * refundRetryDelay)
+		if n, err := c.service.ResumeRefunds(ctx); err != nil || n != 1 {
+			t.Fatalf("resumed %d refunds (error %v), want 1", n, err)
+		}
+		completed, _ := c.repository.GetOrder(ctx, o.ID)
+		if len(completed.Refunds) != 1 || completed.Refunds[0].CompletedAt.IsZero() {
+			t.Errorf("got refunds %+v, want one completed", completed.Refunds)
+		}
+		if got := c.payments.refunds[o.ID]; got.Amount != 1500 {
+			t.Errorf("got %s refunded, want 15.00 once", got)
+		}
+	})
+}
+
+// Cancelling an order refunds what its earlier refunds left of the total, through the same path.
+func TestCancelRefundedOrder(t *testing.T) {
+	ctx := context.Background()
+	c, o := paidOrder(t)
+	if _, err := c.refundAmount(o.ID, 500); err != nil {
+		t.Fatal(err)
+	}
+	cancelled, err := c.service.CancelOrder(ctx, o.ID, "account", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if due := cancelled.Cancellation.RefundDue; due.Amount != 1500 {
+		t.Errorf("got %s due, want 15.00", due)
+	}
+	if len(cancelled.Refunds) != 2 || cancelled.Refunds[1].Amount.Amount != 1500 || cancelled.Refunds[1].CompletedAt.IsZero() {
+		t.Errorf("got refunds %+v, want the cancellation's 15.00 completed after the first", cancelled.Refunds)
+	}
+	if got := c.payments.refunds[o.ID]; got.Amount != 2000 {
+		t.Errorf("got %s refunded, want 20.00", got)
+	}
+}
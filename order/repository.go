@@ -3,6 +3,13 @@ package order
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
 
 	"github.com/lib/pq"
 )
@@ -10,7 +17,46 @@ import (
 type Repository interface {
 	Close()
 	PutOrder(ctx context.Context, o Order) error
-	GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error)
+	GetOrder(ctx context.Context, id string) (*Order, error)
+	UpdateOrderStatus(ctx context.Context, id string, change StatusChange) error
+	CancelOrder(ctx context.Context, id string, change StatusChange, c Cancellation) error
+	GetOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter, skip uint64, take uint64) ([]Order, error)
+	ListOrdersForAccountAfter(ctx context.Context, accountID string, filter OrderFilter, after *OrderCursor, take uint64) ([]Order, error)
+	CountOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter) (uint64, error)
+	CreateSaga(ctx context.Context, s Saga, lockedUntil time.Time) error
+	UpdateSaga(ctx context.Context, s Saga) error
+	ClaimSagas(ctx context.Context, now time.Time, lockedUntil time.Time, limit int) ([]Saga, error)
+	GetSaga(ctx context.Context, id string) (*Saga, error)
+	PutPromotion(ctx context.Context, p Promotion) error
+	GetPromotion(ctx context.Context, id string) (*Promotion, error)
+	GetPromotionsByCode(ctx context.Context, codes []string) ([]Promotion, error)
+	ListPromotions(ctx context.Context) ([]Promotion, error)
+	SetPromotionActive(ctx context.Context, id string, active bool) error
+	CountRedemptions(ctx context.Context, promotionID string, accountID string) (uint32, error)
+	PutTaxRule(ctx context.Context, r TaxRule) (*TaxRule, error)
+	ListTaxRules(ctx context.Context) ([]TaxRule, error)
+	FindTaxRules(ctx context.Context, country string) ([]TaxRule, error)
+	DeleteTaxRule(ctx context.Context, id string) error
+	PutShippingMethod(ctx context.Context, m ShippingMethod) error
+	GetShippingMethod(ctx context.Context, id string) (*ShippingMethod, error)
+	ListShippingMethods(ctx context.Context) ([]ShippingMethod, error)
+	SetShippingMethodActive(ctx context.Context, id string, active bool) error
+	PutShipment(ctx context.Context, sh Shipment) error
+	GetShipment(ctx context.Context, id string) (*Shipment, error)
+	UpdateShipmentStatus(ctx context.Context, id string, from string, ev ShipmentEvent) error
+	PutReturn(ctx context.Context, ret Return, claimed map[string]uint32) error
+	GetReturn(ctx context.Context, id string) (*Return, error)
+	ListReturns(ctx context.Context, status string) ([]Return, error)
+	UpdateReturnStatus(ctx context.Context, id string, from string, ev ReturnEvent) error
+	SetReturnRestocked(ctx context.Context, id string, restocked bool) error
+	PutRefund(ctx context.Context, ref OrderRefund, claimed map[string]uint32) error
+	CompleteRefund(ctx context.Context, id string, completedAt time.Time) error
+	DeleteRefund(ctx context.Context, id string) error
+	ListPendingRefunds(ctx context.Context, before time.Time) ([]OrderRefund, error)
+	ListLedgerEntries(ctx context.Context, orderID, accountID string) ([]LedgerEntry, error)
+	StoreCredit(ctx context.Context, accountID string) ([]money.Money, error)
+	UnbalancedLedgerEntries(ctx context.Context) ([]string, error)
+	LedgerBalances(ctx context.Context, from, to time.Time) ([]OrderLedger, error)
 }
 
 type postgresRepository struct {
@@ -37,6 +83,8 @@ func (r *postgresRepository) Close() {
 // BeginTx() to execute multiple related queries, used for atomicity - if one query fails, we want to roll back everything.
 // PrepareContext() to prepare a statement to efficiently batch insert many rows (ExecContext() will send each query separately — slower and more load on DB).
 // pq.CopyIn allows fast bulk inserts
+// The coupons the order uses are checked against their per-account limits in the same transaction (see checkRedemptions),
+// and the order is entered in the ledger (see ledger.go).
 func (r *postgresRepository) PutOrder(ctx context.Context, o Order) (err error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -49,13 +97,38 @@ func (r *postgresRepository) PutOrder(ctx context.Context, o Order) (err error)
 		}
 		err = tx.Commit()
 	}()
-	_, err = tx.ExecContext(ctx, "INSERT INTO orders(id, created_at, account_id, total_price) VALUES ($1, $2, $3, $4)", o.ID, o.CreatedAt, o.AccountID, o.TotalPrice)
+	// The address snapshot is stored as JSON; a nil address is stored as NULL.
+	var shippingAddress []byte
+	if o.ShippingAddress != nil {
+		if shippingAddress, err = json.Marshal(o.ShippingAddress); err != nil {
+			return
+		}
+	}
+	exchangeRates, err := json.Marshal(o.ExchangeRates)
+	if err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO orders(id, created_at, account_id, subtotal, discount_total, tax_total, shipping_total, total_price, currency,
+			prices_include_tax, shipping_address, shipping_method_id, shipping_method_name, status, exchange_rates)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''), $13, $14, $15)`,
+		o.ID, o.CreatedAt, o.AccountID, o.Subtotal.Amount, o.Discount.Amount, o.Tax.Amount, o.Shipping.Amount, o.TotalPrice.Amount,
+		o.TotalPrice.Currency, o.PricesIncludeTax, shippingAddress, o.ShippingMethodID, o.ShippingMethodName, o.Status, exchangeRates,
+	)
 	if err != nil {
 		return
 	}
-	stmt, _ := tx.PrepareContext(ctx, pq.CopyIn("order_products", "order_id", "product_id", "quantity"))
+	// The first history entry records the status the order was placed in.
+	if err = insertStatusChange(ctx, tx, o.ID, StatusChange{To: o.Status, ChangedAt: o.CreatedAt, ChangedBy: o.AccountID}); err != nil {
+		return
+	}
+	// Each line keeps its own copy of the product's name, description, unit price (in the order's currency)
+	// and billable weight, and the tax it was charged.
+	stmt, _ := tx.PrepareContext(ctx, pq.CopyIn("order_products", "order_id", "product_id", "quantity", "name", "description", "price",
+		"tax_class", "tax_rate", "tax", "weight"))
 	for _, p := range o.Products {
-		_, err = stmt.ExecContext(ctx, o.ID, p.ID, p.Quantity)
+		_, err = stmt.ExecContext(ctx, o.ID, p.ID, p.Quantity, p.Name, p.Description, p.Price.Amount, p.TaxClass, p.TaxRate, p.Tax.Amount,
+			p.Weight)
 		if err != nil {
 			return
 		}
@@ -65,92 +138,1790 @@ func (r *postgresRepository) PutOrder(ctx context.Context, o Order) (err error)
 		return
 	}
 	stmt.Close() // because the prepared statement holds resources
+	for _, p := range o.Products {
+		for seq, d := range p.Discounts {
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO order_line_discounts(order_id, product_id, seq, promotion_id, code, description, amount)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				o.ID, p.ID, seq, d.PromotionID, d.Code, d.Description, d.Amount.Amount,
+			)
+			if err != nil {
+				return
+			}
+		}
+	}
+	if err = insertLedgerEntry(ctx, tx, placementEntry(o)); err != nil {
+		return
+	}
+	err = checkRedemptions(ctx, tx, o)
+	return
+}
+
+// Fails with ErrCouponLimitReached if the order, already inserted in tx, takes a promotion past its per-account limit.
+// The promotion rows are locked first, so concurrent orders of the same account using the same coupon are counted
+// one after the other.
+func checkRedemptions(ctx context.Context, tx *sql.Tx, o Order) error {
+	seen := map[string]bool{}
+	for _, p := range o.Products {
+		for _, d := range p.Discounts {
+			if seen[d.PromotionID] {
+				continue
+			}
+			seen[d.PromotionID] = true
+			var maxUses uint32
+			err := tx.QueryRowContext(ctx, "SELECT max_uses_per_account FROM promotions WHERE id = $1 FOR UPDATE", d.PromotionID).Scan(&maxUses)
+			if err != nil {
+				return err
+			}
+			if maxUses == 0 {
+				continue
+			}
+			used, err := countRedemptions(ctx, tx, d.PromotionID, o.AccountID)
+			if err != nil {
+				return err
+			}
+			if used > maxUses {
+				return fmt.Errorf("%w: %s", ErrCouponLimitReached, d.Code)
+			}
+		}
+	}
+	return nil
+}
+
+// Satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Orders of the account that used the promotion; cancelled orders don't count.
+func countRedemptions(ctx context.Context, q querier, promotionID string, accountID string) (uint32, error) {
+	var n uint32
+	err := q.QueryRowContext(ctx,
+		`SELECT COUNT(DISTINCT orders.id)
+		FROM order_line_discounts JOIN orders ON orders.id = order_line_discounts.order_id
+		WHERE order_line_discounts.promotion_id = $1 AND orders.account_id = $2 AND orders.status <> 'cancelled'`,
+		promotionID, accountID,
+	).Scan(&n)
+	return n, err
+}
+
+func (r *postgresRepository) CountRedemptions(ctx context.Context, promotionID string, accountID string) (uint32, error) {
+	return countRedemptions(ctx, r.db, promotionID, accountID)
+}
+
+// Fetches a single order with its product lines. Returns ErrNotFound if there is no such order.
+func (r *postgresRepository) GetOrder(ctx context.Context, id string) (*Order, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+orderColumns+`
+		FROM `+orderTables+`
+		WHERE orders.id = $1`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := scanOrders(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, ErrNotFound
+	}
+	if err = r.loadOrderDetails(ctx, orders); err != nil {
+		return nil, err
+	}
+	return &orders[0], nil
+}
+
+// Sets the order's status to change.To and appends change to its history, in one transaction.
+// The update only applies while the order is still in change.From, so two concurrent changes
+// can't both succeed; the loser gets ErrStatusConflict.
+func (r *postgresRepository) UpdateOrderStatus(ctx context.Context, id string, change StatusChange) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	return changeStatus(ctx, tx, id, change)
+}
+
+// Marks the order cancelled, appends the change to its history and stores the cancellation record, in one transaction.
+// Like UpdateOrderStatus it fails with ErrStatusConflict if the order left change.From in the meantime.
+func (r *postgresRepository) CancelOrder(ctx context.Context, id string, change StatusChange, c Cancellation) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = changeStatus(ctx, tx, id, change); err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO order_cancellations(order_id, reason, refund_due, cancelled_by, cancelled_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)`,
+		id, c.Reason, c.RefundDue.Amount, c.CancelledBy, c.CancelledAt,
+	)
 	return
 }
 
-// Querying the orders and their associated products for a specific account.
-// `total_price::money::numeric::float8` is used because PostgreSQL does not allow direct casting from MONEY to float8
-// because MONEY includes formatting (like currency symbols, commas, etc.), and converting that directly to a float can cause errors or data loss.
-func (r *postgresRepository) GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error) {
-	// Query orders + their products with JOIN
-	// The result will have 1 row per product per order (duplicate order data, different product_id per row)
+// Moves the order from change.From to change.To and records the change, inside tx, together with what it means for the
+// order's money: moving to paid charges its total, and cancelling it while it is pending reverses it in the ledger.
+func changeStatus(ctx context.Context, tx *sql.Tx, id string, change StatusChange) error {
+	res, err := tx.ExecContext(ctx, "UPDATE orders SET status = $2 WHERE id = $1 AND status = $3", id, change.To, change.From)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrStatusConflict
+	}
+	if err := insertStatusChange(ctx, tx, id, change); err != nil {
+		return err
+	}
+	if change.To != StatusPaid && (change.From != StatusPending || change.To != StatusCancelled) {
+		return nil
+	}
+	o, err := ledgerOrder(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if change.To == StatusPaid {
+		return insertLedgerEntry(ctx, tx, chargeEntry(*o, change.ChangedAt))
+	}
+	return insertLedgerEntry(ctx, tx, cancellationEntry(*o, change.ChangedAt))
+}
+
+func insertStatusChange(ctx context.Context, tx *sql.Tx, orderID string, c StatusChange) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO order_status_history(order_id, from_status, to_status, changed_at, changed_by, note)
+		VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), $6)`,
+		orderID, c.From, c.To, c.ChangedAt, c.ChangedBy, c.Note,
+	)
+	return err
+}
+
+// Orders are read together with their cancellation record, if any.
+const orderTables = "orders LEFT JOIN order_cancellations ON order_cancellations.order_id = orders.id"
+
+// Columns of orderTables read by scanOrders, in order.
+const orderColumns = `id, created_at, account_id, subtotal, discount_total, tax_total, shipping_total, total_price, currency,
+	prices_include_tax, shipping_address, COALESCE(shipping_method_id, ''), shipping_method_name, status, exchange_rates,
+	reason, refund_due, cancelled_by, cancelled_at`
+
+// Shared WHERE clause for an account's orders: $1 is the account id, $2/$3 the optional created_at range
+// and $4 the optional list of statuses. The arguments come from filterArgs; NULL means "no restriction".
+const orderFilterClause = `account_id = $1
+	AND ($2::timestamptz IS NULL OR created_at >= $2)
+	AND ($3::timestamptz IS NULL OR created_at < $3)
+	AND ($4::text[] IS NULL OR status = ANY($4))`
+
+func filterArgs(accountID string, f OrderFilter) []interface{} {
+	var createdAfter, createdBefore *time.Time
+	if !f.CreatedAfter.IsZero() {
+		createdAfter = &f.CreatedAfter
+	}
+	if !f.CreatedBefore.IsZero() {
+		createdBefore = &f.CreatedBefore
+	}
+	var statuses interface{} // stays NULL unless statuses were given
+	if len(f.Statuses) > 0 {
+		statuses = pq.Array(f.Statuses)
+	}
+	return []interface{}{accountID, createdAfter, createdBefore, statuses}
+}
+
+// Querying the orders and their associated products for a specific account, newest first, with offset pagination.
+// Runs in two steps: first the page of orders, then their products (and status history) in one query each (see loadOrderDetails),
+// so every order is returned exactly once, including orders that have no product lines.
+func (r *postgresRepository) GetOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter, skip uint64, take uint64) ([]Order, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+orderColumns+`
+		FROM `+orderTables+`
+		WHERE `+orderFilterClause+`
+		ORDER BY created_at DESC, id DESC
+		OFFSET $5 LIMIT $6`,
+		append(filterArgs(accountID, filter), skip, take)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := scanOrders(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.loadOrderDetails(ctx, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// Returns one page of an account's orders, newest first, starting after the given position (keyset pagination).
+// Orders are sorted by (created_at, id) so that orders created in the same instant still have a stable order.
+// A nil cursor starts at the newest order.
+// Runs in two steps: first the page of orders, then all their products in a single query.
+func (r *postgresRepository) ListOrdersForAccountAfter(ctx context.Context, accountID string, filter OrderFilter, after *OrderCursor, take uint64) ([]Order, error) {
+	var afterCreatedAt *time.Time
+	afterID := ""
+	if after != nil {
+		afterCreatedAt, afterID = &after.CreatedAt, after.ID
+	}
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT
-		o.id,
-		o.created_at,
-		o.account_id,
-		o.total_price::money::numeric::float8,
-		op.product_id,
-		op.quantity
-		FROM orders o JOIN order_products op ON(o.id = op.order_id)
-		WHERE o.account_id = $1
-		ORDER BY o.id`,
-		accountID,
+		`SELECT `+orderColumns+`
+		FROM `+orderTables+`
+		WHERE `+orderFilterClause+`
+		AND ($5::timestamptz IS NULL OR (created_at, id) < ($5, $6))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $7`,
+		append(filterArgs(accountID, filter), afterCreatedAt, afterID, take)...,
 	)
 	if err != nil {
 		return nil, err
 	}
+	orders, err := scanOrders(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.loadOrderDetails(ctx, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *postgresRepository) CountOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter) (uint64, error) {
+	var n uint64
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders WHERE "+orderFilterClause, filterArgs(accountID, filter)...).Scan(&n)
+	return n, err
+}
+
+// Reads rows of orderColumns and closes them.
+// Products, status history, shipments and returns are left empty; loadOrderDetails fills them in.
+func scanOrders(rows *sql.Rows) ([]Order, error) {
 	defer rows.Close()
 	orders := []Order{}
-	order := &Order{}
-	lastOrder := &Order{}
-	orderedProduct := &OrderedProduct{}
-	products := []OrderedProduct{}
-
-	// Iterate through each row of the flattened JOIN result
-	for rows.Next() {
-		// Read current row's data into order + orderedProduct fields
-		if err = rows.Scan(
-			&order.ID,
-			&order.CreatedAt,
-			&order.AccountID,
-			&order.TotalPrice,
-			&orderedProduct.ID,
-			&orderedProduct.Quantity,
+	for rows.Next() {
+		o := Order{}
+		var shippingAddress, exchangeRates []byte
+		var reason, cancelledBy sql.NullString
+		var refundDue sql.NullInt64
+		var cancelledAt sql.NullTime
+		if err := rows.Scan(
+			&o.ID, &o.CreatedAt, &o.AccountID, &o.Subtotal.Amount, &o.Discount.Amount, &o.Tax.Amount, &o.Shipping.Amount,
+			&o.TotalPrice.Amount, &o.TotalPrice.Currency, &o.PricesIncludeTax, &shippingAddress, &o.ShippingMethodID,
+			&o.ShippingMethodName, &o.Status, &exchangeRates, &reason, &refundDue, &cancelledBy, &cancelledAt,
 		); err != nil {
 			return nil, err
 		}
-		// Each product of an order comes as a separate row in the JOIN result.
-		// So for the same order_id, multiple rows will appear — each with a different product_id and quantity.
-		// As long as we see the same order_id, we keep appending the products to the 'products' slice.
-		// When we detect that the order_id has changed (a new order is starting),
-		// we "flush" the current order:
-		//   - create an Order object with its list of collected products,
-		//   - append it to the 'orders' slice,
-		//   - and reset 'products' to start collecting products for the next order.
-		if lastOrder.ID != "" && lastOrder.ID != order.ID {
-			neworder := Order{
-				ID:         lastOrder.ID,
-				CreatedAt:  lastOrder.CreatedAt,
-				AccountID:  lastOrder.AccountID,
-				TotalPrice: lastOrder.TotalPrice,
-				Products:   products,
+		o.Subtotal.Currency = o.TotalPrice.Currency
+		o.Discount.Currency = o.TotalPrice.Currency
+		o.Tax.Currency = o.TotalPrice.Currency
+		o.Shipping.Currency = o.TotalPrice.Currency
+		if cancelledAt.Valid {
+			o.Cancellation = &Cancellation{
+				Reason:      reason.String,
+				RefundDue:   money.New(refundDue.Int64, o.TotalPrice.Currency),
+				CancelledAt: cancelledAt.Time,
+				CancelledBy: cancelledBy.String,
+			}
+		}
+		if err := json.Unmarshal(exchangeRates, &o.ExchangeRates); err != nil {
+			return nil, err
+		}
+		if shippingAddress != nil {
+			o.ShippingAddress = &Address{}
+			if err := json.Unmarshal(shippingAddress, o.ShippingAddress); err != nil {
+				return nil, err
 			}
-			orders = append(orders, neworder)
-			products = []OrderedProduct{} // reset products slice for new order
 		}
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+// Fills in the product lines (with their discounts), status history, shipments, returns and refunds of the given orders.
+func (r *postgresRepository) loadOrderDetails(ctx context.Context, orders []Order) error {
+	if err := r.loadOrderProducts(ctx, orders); err != nil {
+		return err
+	}
+	if err := r.loadLineDiscounts(ctx, orders); err != nil {
+		return err
+	}
+	if err := r.loadStatusHistory(ctx, orders); err != nil {
+		return err
+	}
+	if err := r.loadShipments(ctx, orders); err != nil {
+		return err
+	}
+	if err := r.loadReturns(ctx, orders); err != nil {
+		return err
+	}
+	return r.loadRefunds(ctx, orders)
+}
+
+// Fills in the StatusHistory of the given orders (oldest first) with one query over all their ids.
+func (r *postgresRepository) loadStatusHistory(ctx context.Context, orders []Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := []string{}
+	index := map[string]int{} // order id -> position in orders
+	for i := range orders {
+		ids = append(ids, orders[i].ID)
+		index[orders[i].ID] = i
+		orders[i].StatusHistory = []StatusChange{}
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT order_id, COALESCE(from_status, ''), to_status, changed_at, COALESCE(changed_by, ''), note
+		FROM order_status_history
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var orderID string
+		c := StatusChange{}
+		if err = rows.Scan(&orderID, &c.From, &c.To, &c.ChangedAt, &c.ChangedBy, &c.Note); err != nil {
+			return err
+		}
+		i := index[orderID]
+		orders[i].StatusHistory = append(orders[i].StatusHistory, c)
+	}
+	return rows.Err()
+}
+
+// Fills in the Products of the given orders with one query over all their ids.
+// Orders without any product rows keep an empty (non-nil) product list.
+func (r *postgresRepository) loadOrderProducts(ctx context.Context, orders []Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := []string{}
+	index := map[string]int{} // order id -> position in orders
+	for i := range orders {
+		ids = append(ids, orders[i].ID)
+		index[orders[i].ID] = i
+		orders[i].Products = []OrderedProduct{}
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT order_id, product_id, quantity, name, description, price, tax_class, tax_rate, tax, weight
+		FROM order_products
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, product_id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var orderID string
+		p := OrderedProduct{}
+		if err = rows.Scan(&orderID, &p.ID, &p.Quantity, &p.Name, &p.Description, &p.Price.Amount, &p.TaxClass, &p.TaxRate,
+			&p.Tax.Amount, &p.Weight); err != nil {
+			return err
+		}
+		i := index[orderID]
+		p.Price.Currency = orders[i].TotalPrice.Currency
+		p.Tax.Currency = orders[i].TotalPrice.Currency
+		orders[i].Products = append(orders[i].Products, p)
+	}
+	return rows.Err()
+}
+
+// Fills in the Discounts of the given orders' lines, in the order they were applied, with one query over all their ids.
+// Must run after loadOrderProducts.
+func (r *postgresRepository) loadLineDiscounts(ctx context.Context, orders []Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := []string{}
+	type lineKey struct{ orderID, productID string }
+	index := map[lineKey][2]int{} // order and product line -> position in orders and in its products
+	for i := range orders {
+		ids = append(ids, orders[i].ID)
+		for j, p := range orders[i].Products {
+			index[lineKey{orders[i].ID, p.ID}] = [2]int{i, j}
+			orders[i].Products[j].Discounts = []LineDiscount{}
+		}
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT order_id, product_id, promotion_id, code, description, amount
+		FROM order_line_discounts
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, product_id, seq`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key lineKey
+		d := LineDiscount{}
+		if err = rows.Scan(&key.orderID, &key.productID, &d.PromotionID, &d.Code, &d.Description, &d.Amount.Amount); err != nil {
+			return err
+		}
+		pos, ok := index[key]
+		if !ok {
+			continue
+		}
+		line := &orders[pos[0]].Products[pos[1]]
+		d.Amount.Currency = line.Price.Currency
+		line.Discounts = append(line.Discounts, d)
+	}
+	return rows.Err()
+}
+
+// Stores a new checkout saga, leased to the caller until lockedUntil.
+func (r *postgresRepository) CreateSaga(ctx context.Context, s Saga, lockedUntil time.Time) error {
+	order, err := json.Marshal(s.Order)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO order_sagas(id, state, order_data, steps, reservation_id, payment_id, failure, locked_until, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		s.ID, s.State, order, pq.Array(s.Steps), s.ReservationID, s.PaymentID, s.Failure, lockedUntil, s.CreatedAt, s.UpdatedAt,
+	)
+	return err
+}
 
-		// Always append the current product to the products slice
-		products = append(products, OrderedProduct{
-			ID:       orderedProduct.ID,
-			Quantity: orderedProduct.Quantity,
-		})
-		*lastOrder = *order // Remember current order as lastOrder for next iteration comparison
+// Saves the progress of a saga. The order it places doesn't change once the saga is created.
+func (r *postgresRepository) UpdateSaga(ctx context.Context, s Saga) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE order_sagas
+		SET state = $2, steps = $3, reservation_id = $4, payment_id = $5, failure = $6, updated_at = $7
+		WHERE id = $1`,
+		s.ID, s.State, pq.Array(s.Steps), s.ReservationID, s.PaymentID, s.Failure, s.UpdatedAt,
+	)
+	return err
+}
+
+// Takes the lease of up to limit unfinished sagas whose lease ran out by now, oldest first.
+// Rows locked by another process claiming at the same time are skipped, so no saga is handed out twice.
+func (r *postgresRepository) ClaimSagas(ctx context.Context, now time.Time, lockedUntil time.Time, limit int) ([]Saga, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`UPDATE order_sagas
+		SET locked_until = $2
+		WHERE id IN (
+			SELECT id FROM order_sagas
+			WHERE state IN ('running', 'compensating') AND locked_until < $1
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+sagaColumns,
+		now, lockedUntil, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanSagas(rows)
+}
+
+// The saga that placed (or is placing) the order with the same id. Orders placed before sagas existed have none,
+// which is reported as ErrNotFound.
+func (r *postgresRepository) GetSaga(ctx context.Context, id string) (*Saga, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+sagaColumns+" FROM order_sagas WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	sagas, err := scanSagas(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(sagas) == 0 {
+		return nil, ErrNotFound
 	}
+	return &sagas[0], nil
+}
+
+// Columns of order_sagas read by scanSagas, in order.
+const sagaColumns = "id, state, order_data, steps, reservation_id, payment_id, failure, created_at, updated_at"
 
-	// After the loop, flush the last order (since it won't be flushed in the loop)
-	if lastOrder != nil {
-		neworder := Order{
-			ID:         lastOrder.ID,
-			CreatedAt:  lastOrder.CreatedAt,
-			AccountID:  lastOrder.AccountID,
-			TotalPrice: lastOrder.TotalPrice,
-			Products:   lastOrder.Products,
+// Reads rows of sagaColumns and closes them.
+func scanSagas(rows *sql.Rows) ([]Saga, error) {
+	defer rows.Close()
+	sagas := []Saga{}
+	for rows.Next() {
+		s := Saga{}
+		var order []byte
+		if err := rows.Scan(&s.ID, &s.State, &order, pq.Array(&s.Steps), &s.ReservationID, &s.PaymentID, &s.Failure,
+			&s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(order, &s.Order); err != nil {
+			return nil, err
 		}
-		orders = append(orders, neworder)
+		sagas = append(sagas, s)
+	}
+	return sagas, rows.Err()
+}
+
+// Columns read by scanPromotions, in order.
+const promotionColumns = `id, code, description, kind, percent_off, amount_off, buy_quantity, get_quantity, product_ids,
+	min_spend, currency, max_uses_per_account, starts_at, ends_at, stackable, active, created_at`
+
+// Stores a new promotion. A coupon code that is taken already is reported as ErrPromotionExists.
+func (r *postgresRepository) PutPromotion(ctx context.Context, p Promotion) error {
+	// AmountOff and MinSpend share one currency column; it stays empty for promotions without amounts.
+	currency := p.AmountOff.Currency
+	if currency == "" {
+		currency = p.MinSpend.Currency
+	}
+	var startsAt, endsAt *time.Time
+	if !p.StartsAt.IsZero() {
+		startsAt = &p.StartsAt
+	}
+	if !p.EndsAt.IsZero() {
+		endsAt = &p.EndsAt
+	}
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO promotions(`+promotionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`,
+		p.ID, p.Code, p.Description, p.Kind, p.PercentOff, p.AmountOff.Amount, p.BuyQuantity, p.GetQuantity, pq.Array(p.ProductIDs),
+		p.MinSpend.Amount, currency, p.MaxUsesPerAccount, startsAt, endsAt, p.Stackable, p.Active, p.CreatedAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+		return ErrPromotionExists
+	}
+	return err
+}
+
+// Returns ErrPromotionNotFound if there is no such promotion.
+func (r *postgresRepository) GetPromotion(ctx context.Context, id string) (*Promotion, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+promotionColumns+" FROM promotions WHERE id = $1", id)
+	if err != nil {
+		return nil, err
 	}
-	if err = rows.Err(); err != nil {
+	promotions, err := scanPromotions(rows)
+	if err != nil {
 		return nil, err
 	}
-	return orders, nil
+	if len(promotions) == 0 {
+		return nil, ErrPromotionNotFound
+	}
+	return &promotions[0], nil
+}
+
+// Returns the promotions with any of the given (upper-case) coupon codes; unknown codes are left out.
+func (r *postgresRepository) GetPromotionsByCode(ctx context.Context, codes []string) ([]Promotion, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+promotionColumns+" FROM promotions WHERE code = ANY($1)", pq.Array(codes))
+	if err != nil {
+		return nil, err
+	}
+	return scanPromotions(rows)
+}
+
+// Every promotion, newest first.
+func (r *postgresRepository) ListPromotions(ctx context.Context) ([]Promotion, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+promotionColumns+" FROM promotions ORDER BY created_at DESC, id DESC")
+	if err != nil {
+		return nil, err
+	}
+	return scanPromotions(rows)
+}
+
+func (r *postgresRepository) SetPromotionActive(ctx context.Context, id string, active bool) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE promotions SET active = $2 WHERE id = $1", id, active)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+// Reads rows of promotionColumns and closes them.
+func scanPromotions(rows *sql.Rows) ([]Promotion, error) {
+	defer rows.Close()
+	promotions := []Promotion{}
+	for rows.Next() {
+		p := Promotion{}
+		var currency string
+		var startsAt, endsAt sql.NullTime
+		if err := rows.Scan(
+			&p.ID, &p.Code, &p.Description, &p.Kind, &p.PercentOff, &p.AmountOff.Amount, &p.BuyQuantity, &p.GetQuantity,
+			pq.Array(&p.ProductIDs), &p.MinSpend.Amount, &currency, &p.MaxUsesPerAccount, &startsAt, &endsAt,
+			&p.Stackable, &p.Active, &p.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if p.AmountOff.Amount != 0 {
+			p.AmountOff.Currency = currency
+		}
+		if p.MinSpend.Amount != 0 {
+			p.MinSpend.Currency = currency
+		}
+		p.StartsAt = startsAt.Time
+		p.EndsAt = endsAt.Time
+		promotions = append(promotions, p)
+	}
+	return promotions, rows.Err()
+}
+
+// Stores a tax rule, replacing the rate of an existing rule for the same country, region and tax class
+// (which keeps its id). Returns the rule as stored.
+func (r *postgresRepository) PutTaxRule(ctx context.Context, rule TaxRule) (*TaxRule, error) {
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO tax_rules(id, country, region, tax_class, rate, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (country, region, tax_class) DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at
+		RETURNING id`,
+		rule.ID, rule.Country, rule.Region, rule.TaxClass, rule.Rate, rule.UpdatedAt,
+	).Scan(&rule.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *postgresRepository) ListTaxRules(ctx context.Context) ([]TaxRule, error) {
+	return r.queryTaxRules(ctx, "SELECT id, country, region, tax_class, rate, updated_at FROM tax_rules ORDER BY country, region, tax_class")
+}
+
+// The rules of one country, both country-wide and for its regions.
+func (r *postgresRepository) FindTaxRules(ctx context.Context, country string) ([]TaxRule, error) {
+	return r.queryTaxRules(ctx, "SELECT id, country, region, tax_class, rate, updated_at FROM tax_rules WHERE country = $1",
+		strings.ToUpper(country))
+}
+
+func (r *postgresRepository) queryTaxRules(ctx context.Context, query string, args ...interface{}) ([]TaxRule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	rules := []TaxRule{}
+	for rows.Next() {
+		rule := TaxRule{}
+		if err := rows.Scan(&rule.ID, &rule.Country, &rule.Region, &rule.TaxClass, &rule.Rate, &rule.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (r *postgresRepository) DeleteTaxRule(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM tax_rules WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTaxRuleNotFound
+	}
+	return nil
+}
+
+// Weight rates as stored in the weight_rates column, in the method's currency.
+type weightRateDocument struct {
+	MaxWeight uint32 `json:"maxWeight"`
+	Price     int64  `json:"price"`
+}
+
+// Columns read by scanShippingMethods, in order.
+const shippingMethodColumns = "id, name, carrier, kind, currency, price, free_over, weight_rates, countries, active, created_at"
+
+func (r *postgresRepository) PutShippingMethod(ctx context.Context, m ShippingMethod) error {
+	rates := []weightRateDocument{}
+	for _, wr := range m.WeightRates {
+		rates = append(rates, weightRateDocument{wr.MaxWeight, wr.Price.Amount})
+	}
+	weightRates, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO shipping_methods(`+shippingMethodColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		m.ID, m.Name, m.Carrier, m.Kind, m.Currency(), m.Price.Amount, m.FreeOver.Amount, weightRates, pq.Array(m.Countries),
+		m.Active, m.CreatedAt,
+	)
+	return err
+}
+
+// Returns ErrShippingMethodNotFound if there is no such method.
+func (r *postgresRepository) GetShippingMethod(ctx context.Context, id string) (*ShippingMethod, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+shippingMethodColumns+" FROM shipping_methods WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := scanShippingMethods(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		return nil, ErrShippingMethodNotFound
+	}
+	return &methods[0], nil
+}
+
+// Every shipping method, by name.
+func (r *postgresRepository) ListShippingMethods(ctx context.Context) ([]ShippingMethod, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+shippingMethodColumns+" FROM shipping_methods ORDER BY name, id")
+	if err != nil {
+		return nil, err
+	}
+	return scanShippingMethods(rows)
+}
+
+func (r *postgresRepository) SetShippingMethodActive(ctx context.Context, id string, active bool) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE shipping_methods SET active = $2 WHERE id = $1", id, active)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrShippingMethodNotFound
+	}
+	return nil
+}
+
+// Reads rows of shippingMethodColumns and closes them.
+func scanShippingMethods(rows *sql.Rows) ([]ShippingMethod, error) {
+	defer rows.Close()
+	methods := []ShippingMethod{}
+	for rows.Next() {
+		m := ShippingMethod{}
+		var currency string
+		var weightRates []byte
+		if err := rows.Scan(
+			&m.ID, &m.Name, &m.Carrier, &m.Kind, &currency, &m.Price.Amount, &m.FreeOver.Amount, &weightRates,
+			pq.Array(&m.Countries), &m.Active, &m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		rates := []weightRateDocument{}
+		if err := json.Unmarshal(weightRates, &rates); err != nil {
+			return nil, err
+		}
+		m.WeightRates = []WeightRate{}
+		for _, wr := range rates {
+			m.WeightRates = append(m.WeightRates, WeightRate{wr.MaxWeight, money.New(wr.Price, currency)})
+		}
+		if m.Kind != ShippingWeightBased {
+			m.Price.Currency = currency
+		}
+		if m.Kind == ShippingFreeOverThreshold {
+			m.FreeOver.Currency = currency
+		}
+		if m.Countries == nil {
+			m.Countries = []string{}
+		}
+		methods = append(methods, m)
+	}
+	return methods, rows.Err()
+}
+
+// Stores a new shipment with its first event. A carrier and tracking number that are taken already
+// are reported as ErrShipmentExists.
+func (r *postgresRepository) PutShipment(ctx context.Context, sh Shipment) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO shipments(id, order_id, carrier, tracking_number, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		sh.ID, sh.OrderID, sh.Carrier, sh.TrackingNumber, sh.Status, sh.CreatedAt, sh.UpdatedAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+		err = ErrShipmentExists
+		return
+	}
+	if err != nil {
+		return
+	}
+	for _, ev := range sh.History {
+		if err = insertShipmentEvent(ctx, tx, sh.ID, ev); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Returns ErrShipmentNotFound if there is no such shipment.
+func (r *postgresRepository) GetShipment(ctx context.Context, id string) (*Shipment, error) {
+	shipments, err := r.queryShipments(ctx, "id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(shipments) == 0 {
+		return nil, ErrShipmentNotFound
+	}
+	return &shipments[0], nil
+}
+
+// Sets the shipment's status to ev.Status and appends ev to its history, in one transaction.
+// Like UpdateOrderStatus, the update only applies while the shipment is still in status from;
+// otherwise it fails with ErrShipmentStatusConflict.
+func (r *postgresRepository) UpdateShipmentStatus(ctx context.Context, id string, from string, ev ShipmentEvent) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	res, err := tx.ExecContext(ctx,
+		"UPDATE shipments SET status = $2, updated_at = $3 WHERE id = $1 AND status = $4",
+		id, ev.Status, ev.OccurredAt, from,
+	)
+	if err != nil {
+		return
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		err = ErrShipmentStatusConflict
+		return
+	}
+	err = insertShipmentEvent(ctx, tx, id, ev)
+	return
+}
+
+func insertShipmentEvent(ctx context.Context, tx *sql.Tx, shipmentID string, ev ShipmentEvent) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO shipment_events(shipment_id, status, note, occurred_at, changed_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))`,
+		shipmentID, ev.Status, ev.Note, ev.OccurredAt, ev.ChangedBy,
+	)
+	return err
+}
+
+// Fills in the Shipments of the given orders (oldest first) with one query over all their ids.
+func (r *postgresRepository) loadShipments(ctx context.Context, orders []Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := []string{}
+	index := map[string]int{} // order id -> position in orders
+	for i := range orders {
+		ids = append(ids, orders[i].ID)
+		index[orders[i].ID] = i
+		orders[i].Shipments = []Shipment{}
+	}
+	shipments, err := r.queryShipments(ctx, "order_id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for _, sh := range shipments {
+		i := index[sh.OrderID]
+		orders[i].Shipments = append(orders[i].Shipments, sh)
+	}
+	return nil
+}
+
+// Reads the shipments matching where, oldest first, together with their history.
+func (r *postgresRepository) queryShipments(ctx context.Context, where string, args ...interface{}) ([]Shipment, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, order_id, carrier, tracking_number, status, created_at, updated_at
+		FROM shipments
+		WHERE `+where+`
+		ORDER BY created_at, id`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	shipments := []Shipment{}
+	ids := []string{}
+	for rows.Next() {
+		sh := Shipment{History: []ShipmentEvent{}}
+		if err := rows.Scan(&sh.ID, &sh.OrderID, &sh.Carrier, &sh.TrackingNumber, &sh.Status, &sh.CreatedAt, &sh.UpdatedAt); err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, sh)
+		ids = append(ids, sh.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(shipments) == 0 {
+		return shipments, nil
+	}
+	events, err := r.db.QueryContext(ctx,
+		`SELECT shipment_id, status, note, occurred_at, COALESCE(changed_by, '')
+		FROM shipment_events
+		WHERE shipment_id = ANY($1)
+		ORDER BY shipment_id, id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer events.Close()
+	index := map[string]int{} // shipment id -> position in shipments
+	for i, sh := range shipments {
+		index[sh.ID] = i
+	}
+	for events.Next() {
+		var shipmentID string
+		ev := ShipmentEvent{}
+		if err := events.Scan(&shipmentID, &ev.Status, &ev.Note, &ev.OccurredAt, &ev.ChangedBy); err != nil {
+			return nil, err
+		}
+		i := index[shipmentID]
+		shipments[i].History = append(shipments[i].History, ev)
+	}
+	return shipments, events.Err()
+}
+
+// Stores a new return with its lines and first event. claimed holds the units of each product of the order that
+// are returned or refunded already (see claimedQuantities), as ret was checked and priced against; the order row is
+// locked while they are counted again, and if they changed meanwhile the return fails with ErrReturnConflict.
+func (r *postgresRepository) PutReturn(ctx context.Context, ret Return, claimed map[string]uint32) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if _, err = tx.ExecContext(ctx, "SELECT id FROM orders WHERE id = $1 FOR UPDATE", ret.OrderID); err != nil {
+		return
+	}
+	current, err := claimedUnits(ctx, tx, ret.OrderID)
+	if err != nil {
+		return
+	}
+	for _, l := range ret.Lines {
+		if current[l.ProductID] != claimed[l.ProductID] {
+			err = ErrReturnConflict
+			return
+		}
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO returns(id, order_id, account_id, status, reason, refund, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		ret.ID, ret.OrderID, ret.AccountID, ret.Status, ret.Reason, ret.Refund.Amount, ret.Refund.Currency, ret.CreatedAt, ret.UpdatedAt,
+	)
+	if err != nil {
+		return
+	}
+	for _, l := range ret.Lines {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO return_lines(return_id, product_id, quantity, refund) VALUES ($1, $2, $3, $4)",
+			ret.ID, l.ProductID, l.Quantity, l.Refund.Amount,
+		)
+		if err != nil {
+			return
+		}
+	}
+	for _, ev := range ret.History {
+		if err = insertReturnEvent(ctx, tx, ret.ID, ev); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Returns ErrReturnNotFound if there is no such return.
+func (r *postgresRepository) GetReturn(ctx context.Context, id string) (*Return, error) {
+	returns, err := r.queryReturns(ctx, "id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
+		return nil, ErrReturnNotFound
+	}
+	return &returns[0], nil
+}
+
+func (r *postgresRepository) ListReturns(ctx context.Context, status string) ([]Return, error) {
+	if status == "" {
+		return r.queryReturns(ctx, "TRUE")
+	}
+	return r.queryReturns(ctx, "status = $1", status)
+}
+
+// Sets the return's status to ev.Status and appends ev to its history, in one transaction.
+// Like UpdateOrderStatus, the update only applies while the return is still in status from;
+// otherwise it fails with ErrReturnConflict.
+func (r *postgresRepository) UpdateReturnStatus(ctx context.Context, id string, from string, ev ReturnEvent) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	res, err := tx.ExecContext(ctx,
+		"UPDATE returns SET status = $2, updated_at = $3 WHERE id = $1 AND status = $4",
+		id, ev.Status, ev.OccurredAt, from,
+	)
+	if err != nil {
+		return
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		err = ErrReturnConflict
+		return
+	}
+	err = insertReturnEvent(ctx, tx, id, ev)
+	return
+}
+
+// Sets whether the units of a return were put back into stock. Fails with ErrReturnConflict if the flag is set
+// that way already, so only one caller gets to restock a return; ErrReturnNotFound if there is no such return.
+func (r *postgresRepository) SetReturnRestocked(ctx context.Context, id string, restocked bool) error {
+	res, err := r.db.ExecContext(ctx,
+		"UPDATE returns SET restocked = $2, updated_at = $3 WHERE id = $1 AND restocked <> $2",
+		id, restocked, time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM returns WHERE id = $1)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrReturnNotFound
+	}
+	return ErrReturnConflict
+}
+
+func insertReturnEvent(ctx context.Context, tx *sql.Tx, returnID string, ev ReturnEvent) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO return_events(return_id, status, note, occurred_at, changed_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))`,
+		returnID, ev.Status, ev.Note, ev.OccurredAt, ev.ChangedBy,
+	)
+	return err
+}
+
+// Fills in the Returns of the given orders (oldest first) with one query over all their ids.
+func (r *postgresRepository) loadReturns(ctx context.Context, orders []Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := []string{}
+	index := map[string]int{} // order id -> position in orders
+	for i := range orders {
+		ids = append(ids, orders[i].ID)
+		index[orders[i].ID] = i
+		orders[i].Returns = []Return{}
+	}
+	returns, err := r.queryReturns(ctx, "order_id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for _, ret := range returns {
+		i := index[ret.OrderID]
+		orders[i].Returns = append(orders[i].Returns, ret)
+	}
+	return nil
+}
+
+// Reads the returns matching where, oldest first, together with their lines and history.
+func (r *postgresRepository) queryReturns(ctx context.Context, where string, args ...interface{}) ([]Return, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, order_id, account_id, status, reason, refund, currency, refunded_at, restocked, created_at, updated_at
+		FROM returns
+		WHERE `+where+`
+		ORDER BY created_at, id`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	returns := []Return{}
+	ids := []string{}
+	for rows.Next() {
+		ret := Return{Lines: []ReturnLine{}, History: []ReturnEvent{}}
+		var refundedAt sql.NullTime
+		if err := rows.Scan(
+			&ret.ID, &ret.OrderID, &ret.AccountID, &ret.Status, &ret.Reason, &ret.Refund.Amount, &ret.Refund.Currency,
+			&refundedAt, &ret.Restocked, &ret.CreatedAt, &ret.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if refundedAt.Valid {
+			ret.RefundedAt = refundedAt.Time
+		}
+		returns = append(returns, ret)
+		ids = append(ids, ret.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
+		return returns, nil
+	}
+	index := map[string]int{} // return id -> position in returns
+	for i, ret := range returns {
+		index[ret.ID] = i
+	}
+	lines, err := r.db.QueryContext(ctx,
+		`SELECT return_id, product_id, quantity, refund
+		FROM return_lines
+		WHERE return_id = ANY($1)
+		ORDER BY return_id, product_id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer lines.Close()
+	for lines.Next() {
+		var returnID string
+		l := ReturnLine{}
+		if err := lines.Scan(&returnID, &l.ProductID, &l.Quantity, &l.Refund.Amount); err != nil {
+			return nil, err
+		}
+		i := index[returnID]
+		l.Refund.Currency = returns[i].Refund.Currency
+		returns[i].Lines = append(returns[i].Lines, l)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+	events, err := r.db.QueryContext(ctx,
+		`SELECT return_id, status, note, occurred_at, COALESCE(changed_by, '')
+		FROM return_events
+		WHERE return_id = ANY($1)
+		ORDER BY return_id, id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer events.Close()
+	for events.Next() {
+		var returnID string
+		ev := ReturnEvent{}
+		if err := events.Scan(&returnID, &ev.Status, &ev.Note, &ev.OccurredAt, &ev.ChangedBy); err != nil {
+			return nil, err
+		}
+		i := index[returnID]
+		returns[i].History = append(returns[i].History, ev)
+	}
+	return returns, events.Err()
+}
+
+// Stores a refund with its lines in one transaction. The order row is locked, so the refunds of an order are
+// written one after the other. A refund with CompletedAt set is recorded in full: its ledger entry is written and
+// its return marked refunded. Otherwise it is written pending, claiming its amount (and its return, which can only
+// have one refund; a second one fails with ErrReturnConflict) until CompleteRefund or DeleteRefund.
+// A refund that takes the order's refunds, pending ones included, past its total fails with ErrNotRefundable,
+// whatever its destination; as the order row is locked while they are added up, concurrent refunds can't get past
+// it either. The lines of a refund that isn't for a return are checked like those of PutReturn: claimed holds the
+// units returned or refunded already as ref was priced against, and if they changed meanwhile the refund fails
+// with ErrReturnConflict.
+func (r *postgresRepository) PutRefund(ctx context.Context, ref OrderRefund, claimed map[string]uint32) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	o, err := ledgerOrder(ctx, tx, ref.OrderID)
+	if err != nil {
+		return
+	}
+	var refunded int64
+	err = tx.QueryRowContext(ctx, "SELECT COALESCE(SUM(amount), 0) FROM order_refunds WHERE order_id = $1", ref.OrderID).Scan(&refunded)
+	if err != nil {
+		return
+	}
+	if refunded+ref.Amount.Amount > o.TotalPrice.Amount {
+		err = fmt.Errorf("%w: %s is left", ErrNotRefundable, money.New(o.TotalPrice.Amount-refunded, o.TotalPrice.Currency))
+		return
+	}
+	if ref.ReturnID == "" && len(ref.Lines) > 0 {
+		var current map[string]uint32
+		if current, err = claimedUnits(ctx, tx, ref.OrderID); err != nil {
+			return
+		}
+		for _, l := range ref.Lines {
+			if current[l.ProductID] != claimed[l.ProductID] {
+				err = fmt.Errorf("%w: units of %s were returned or refunded meanwhile", ErrReturnConflict, l.ProductID)
+				return
+			}
+		}
+	}
+	var completedAt *time.Time
+	if !ref.CompletedAt.IsZero() {
+		completedAt = &ref.CompletedAt
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO order_refunds(id, order_id, return_id, amount, currency, destination, reason, refunded_by, created_at, completed_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, NULLIF($8, ''), $9, $10)`,
+		ref.ID, ref.OrderID, ref.ReturnID, ref.Amount.Amount, ref.Amount.Currency, ref.Destination, ref.Reason, ref.RefundedBy,
+		ref.CreatedAt, completedAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation, on return_id
+		err = fmt.Errorf("%w: return %s already has a refund", ErrReturnConflict, ref.ReturnID)
+		return
+	}
+	if err != nil {
+		return
+	}
+	for _, l := range ref.Lines {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO order_refund_lines(refund_id, product_id, quantity, amount) VALUES ($1, $2, $3, $4)",
+			ref.ID, l.ProductID, l.Quantity, l.Amount.Amount,
+		)
+		if err != nil {
+			return
+		}
+	}
+	if completedAt != nil {
+		err = recordRefund(ctx, tx, *o, ref)
+	}
+	return
+}
+
+// Records that the payment service made a pending refund: marks it completed at completedAt, writes its ledger entry
+// and marks its return refunded, in one transaction. Completing a refund again does nothing.
+// Returns ErrRefundNotFound if there is no such refund.
+func (r *postgresRepository) CompleteRefund(ctx context.Context, id string, completedAt time.Time) (err error) {
+	var orderID string
+	err = r.db.QueryRowContext(ctx, "SELECT order_id FROM order_refunds WHERE id = $1", id).Scan(&orderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrRefundNotFound
+	}
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	// The order row is locked first, like PutRefund does, so the two can't deadlock.
+	o, err := ledgerOrder(ctx, tx, orderID)
+	if err != nil {
+		return
+	}
+	ref := OrderRefund{ID: id, OrderID: orderID, CompletedAt: completedAt}
+	err = tx.QueryRowContext(ctx,
+		`UPDATE order_refunds SET completed_at = $2
+		WHERE id = $1 AND completed_at IS NULL
+		RETURNING COALESCE(return_id, ''), amount, currency, destination, reason`,
+		id, completedAt,
+	).Scan(&ref.ReturnID, &ref.Amount.Amount, &ref.Amount.Currency, &ref.Destination, &ref.Reason)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil // completed already
+		return
+	}
+	if err != nil {
+		return
+	}
+	err = recordRefund(ctx, tx, *o, ref)
+	return
+}
+
+// Deletes a pending refund the payment service didn't make, giving its amount (and its return) free again.
+// Completed refunds are never deleted.
+func (r *postgresRepository) DeleteRefund(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM order_refunds WHERE id = $1 AND completed_at IS NULL", id)
+	return err
+}
+
+// Refunds still pending that were written before the given time, oldest first, without their lines.
+func (r *postgresRepository) ListPendingRefunds(ctx context.Context, before time.Time) ([]OrderRefund, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, order_id, COALESCE(return_id, ''), amount, currency, destination, reason, COALESCE(refunded_by, ''), created_at
+		FROM order_refunds
+		WHERE completed_at IS NULL AND created_at < $1
+		ORDER BY created_at, id`,
+		before,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	refunds := []OrderRefund{}
+	for rows.Next() {
+		ref := OrderRefund{Lines: []RefundLine{}}
+		if err := rows.Scan(
+			&ref.ID, &ref.OrderID, &ref.ReturnID, &ref.Amount.Amount, &ref.Amount.Currency, &ref.Destination, &ref.Reason,
+			&ref.RefundedBy, &ref.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, ref)
+	}
+	return refunds, rows.Err()
+}
+
+// Writes the ledger entry of a completed refund and marks its return refunded, and its order refunded if the
+// refunds now cover it, inside tx.
+func recordRefund(ctx context.Context, tx *sql.Tx, o Order, ref OrderRefund) error {
+	if ref.ReturnID != "" {
+		_, err := tx.ExecContext(ctx, "UPDATE returns SET refunded_at = $2, updated_at = $2 WHERE id = $1", ref.ReturnID, ref.CompletedAt)
+		if err != nil {
+			return err
+		}
+	}
+	if err := insertLedgerEntry(ctx, tx, refundEntry(o, ref)); err != nil {
+		return err
+	}
+	return markRefunded(ctx, tx, o, ref.CompletedAt)
+}
+
+// Moves a paid order to refunded once its completed refunds come to its total, inside tx, which holds the order row.
+// Cancelled orders stay cancelled.
+func markRefunded(ctx context.Context, tx *sql.Tx, o Order, at time.Time) error {
+	var refunded int64
+	var status string
+	err := tx.QueryRowContext(ctx,
+		`SELECT (SELECT COALESCE(SUM(amount), 0) FROM order_refunds WHERE order_id = $1 AND completed_at IS NOT NULL), status
+		FROM orders
+		WHERE id = $1`,
+		o.ID,
+	).Scan(&refunded, &status)
+	if err != nil || refunded < o.TotalPrice.Amount || !isPaid(status) {
+		return err
+	}
+	return changeStatus(ctx, tx, o.ID, StatusChange{From: status, To: StatusRefunded, ChangedAt: at, Note: "refunds cover the total"})
+}
+
+// Units of each product of the order that are returned or refunded, counted like claimedQuantities: those of returns
+// that weren't rejected and those of refunds by line that weren't for a return.
+func claimedUnits(ctx context.Context, tx *sql.Tx, orderID string) (map[string]uint32, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT product_id, SUM(quantity) FROM (
+			SELECT l.product_id, l.quantity
+			FROM return_lines l JOIN returns r ON r.id = l.return_id
+			WHERE r.order_id = $1 AND r.status <> $2
+			UNION ALL
+			SELECT l.product_id, l.quantity
+			FROM order_refund_lines l JOIN order_refunds f ON f.id = l.refund_id
+			WHERE f.order_id = $1 AND f.return_id IS NULL
+		) claimed
+		GROUP BY product_id`,
+		orderID, ReturnRejected,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	claimed := map[string]uint32{}
+	for rows.Next() {
+		var productID string
+		var quantity uint32
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			return nil, err
+		}
+		claimed[productID] = quantity
+	}
+	return claimed, rows.Err()
+}
+
+// Reads the id, account and amounts of an order, locking its row when q is a transaction.
+func ledgerOrder(ctx context.Context, q querier, id string) (*Order, error) {
+	o := &Order{}
+	var currency string
+	err := q.QueryRowContext(ctx,
+		`SELECT id, account_id, created_at, subtotal, discount_total, tax_total, shipping_total, total_price, currency, prices_include_tax
+		FROM orders
+		WHERE id = $1
+		FOR UPDATE`,
+		id,
+	).Scan(
+		&o.ID, &o.AccountID, &o.CreatedAt, &o.Subtotal.Amount, &o.Discount.Amount, &o.Tax.Amount, &o.Shipping.Amount,
+		&o.TotalPrice.Amount, &currency, &o.PricesIncludeTax,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	o.Subtotal.Currency, o.Discount.Currency, o.Tax.Currency, o.Shipping.Currency, o.TotalPrice.Currency = currency, currency, currency, currency, currency
+	return o, nil
+}
+
+// Writes a ledger entry and its postings inside tx. An entry without postings (nothing to record) is skipped;
+// one that doesn't balance is refused with ErrUnbalancedEntry.
+func insertLedgerEntry(ctx context.Context, tx *sql.Tx, e LedgerEntry) error {
+	if len(e.Postings) == 0 {
+		return nil
+	}
+	if !e.Balanced() {
+		return fmt.Errorf("%w: %s of order %s", ErrUnbalancedEntry, e.Kind, e.OrderID)
+	}
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO ledger_entries(id, kind, order_id, account_id, refund_id, description, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)`,
+		e.ID, e.Kind, e.OrderID, e.AccountID, e.RefundID, e.Description, e.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	for _, p := range e.Postings {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO ledger_postings(entry_id, ledger_account, amount, currency) VALUES ($1, $2, $3, $4)",
+			e.ID, p.Account, p.Amount.Amount, p.Amount.Currency,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fills in the Refunds of the given orders (oldest first), with their lines.
+func (r *postgresRepository) loadRefunds(ctx context.Context, orders []Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := []string{}
+	index := map[string]int{} // order id -> position in orders
+	for i := range orders {
+		ids = append(ids, orders[i].ID)
+		index[orders[i].ID] = i
+		orders[i].Refunds = []OrderRefund{}
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, order_id, COALESCE(return_id, ''), amount, currency, destination, reason, COALESCE(refunded_by, ''), created_at,
+			completed_at
+		FROM order_refunds
+		WHERE order_id = ANY($1)
+		ORDER BY created_at, id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	refunds := []OrderRefund{}
+	refundIDs := []string{}
+	refundIndex := map[string]int{} // refund id -> position in refunds
+	for rows.Next() {
+		ref := OrderRefund{Lines: []RefundLine{}}
+		var completedAt sql.NullTime
+		if err := rows.Scan(
+			&ref.ID, &ref.OrderID, &ref.ReturnID, &ref.Amount.Amount, &ref.Amount.Currency, &ref.Destination, &ref.Reason,
+			&ref.RefundedBy, &ref.CreatedAt, &completedAt,
+		); err != nil {
+			return err
+		}
+		if completedAt.Valid {
+			ref.CompletedAt = completedAt.Time
+		}
+		refundIndex[ref.ID] = len(refunds)
+		refunds = append(refunds, ref)
+		refundIDs = append(refundIDs, ref.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(refunds) == 0 {
+		return nil
+	}
+	lines, err := r.db.QueryContext(ctx,
+		`SELECT refund_id, product_id, quantity, amount
+		FROM order_refund_lines
+		WHERE refund_id = ANY($1)
+		ORDER BY refund_id, product_id`,
+		pq.Array(refundIDs),
+	)
+	if err != nil {
+		return err
+	}
+	defer lines.Close()
+	for lines.Next() {
+		var refundID string
+		l := RefundLine{}
+		if err := lines.Scan(&refundID, &l.ProductID, &l.Quantity, &l.Amount.Amount); err != nil {
+			return err
+		}
+		i := refundIndex[refundID]
+		l.Amount.Currency = refunds[i].Amount.Currency
+		refunds[i].Lines = append(refunds[i].Lines, l)
+	}
+	if err := lines.Err(); err != nil {
+		return err
+	}
+	for _, ref := range refunds {
+		i := index[ref.OrderID]
+		orders[i].Refunds = append(orders[i].Refunds, ref)
+	}
+	return nil
+}
+
+// Entries of an order, of an account's orders, or of an account's entries for one order; oldest first.
+func (r *postgresRepository) ListLedgerEntries(ctx context.Context, orderID, accountID string) ([]LedgerEntry, error) {
+	where, args := "order_id = $1", []interface{}{orderID}
+	switch {
+	case orderID == "":
+		where, args = "account_id = $1", []interface{}{accountID}
+	case accountID != "":
+		where, args = "order_id = $1 AND account_id = $2", []interface{}{orderID, accountID}
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, kind, order_id, account_id, COALESCE(refund_id, ''), description, created_at
+		FROM ledger_entries
+		WHERE `+where+`
+		ORDER BY created_at, id`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := []LedgerEntry{}
+	ids := []string{}
+	index := map[string]int{} // entry id -> position in entries
+	for rows.Next() {
+		e := LedgerEntry{Postings: []Posting{}}
+		if err := rows.Scan(&e.ID, &e.Kind, &e.OrderID, &e.AccountID, &e.RefundID, &e.Description, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		index[e.ID] = len(entries)
+		entries = append(entries, e)
+		ids = append(ids, e.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return entries, nil
+	}
+	postings, err := r.db.QueryContext(ctx,
+		`SELECT entry_id, ledger_account, amount, currency
+		FROM ledger_postings
+		WHERE entry_id = ANY($1)
+		ORDER BY entry_id, id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer postings.Close()
+	for postings.Next() {
+		var entryID string
+		p := Posting{}
+		if err := postings.Scan(&entryID, &p.Account, &p.Amount.Amount, &p.Amount.Currency); err != nil {
+			return nil, err
+		}
+		i := index[entryID]
+		entries[i].Postings = append(entries[i].Postings, p)
+	}
+	return entries, postings.Err()
+}
+
+// The credit balance of the account's store_credit postings, one amount per currency it isn't zero in.
+func (r *postgresRepository) StoreCredit(ctx context.Context, accountID string) ([]money.Money, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT p.currency, -SUM(p.amount)
+		FROM ledger_postings p JOIN ledger_entries e ON e.id = p.entry_id
+		WHERE e.account_id = $1 AND p.ledger_account = $2
+		GROUP BY p.currency
+		HAVING SUM(p.amount) <> 0
+		ORDER BY p.currency`,
+		accountID, LedgerStoreCredit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	credit := []money.Money{}
+	for rows.Next() {
+		m := money.Money{}
+		if err := rows.Scan(&m.Currency, &m.Amount); err != nil {
+			return nil, err
+		}
+		credit = append(credit, m)
+	}
+	return credit, rows.Err()
+}
+
+// Ids of the entries whose postings don't add up to zero in some currency.
+func (r *postgresRepository) UnbalancedLedgerEntries(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT DISTINCT entry_id
+		FROM (SELECT entry_id FROM ledger_postings GROUP BY entry_id, currency HAVING SUM(amount) <> 0) unbalanced
+		ORDER BY entry_id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Reads what Reconcile needs of every order created in [from, to) (zero times leave the range open), oldest first:
+// its amounts, whether it was paid or cancelled unpaid (from its status history), its refunds and its ledger balances.
+func (r *postgresRepository) LedgerBalances(ctx context.Context, from, to time.Time) ([]OrderLedger, error) {
+	var createdAfter, createdBefore *time.Time
+	if !from.IsZero() {
+		createdAfter = &from
+	}
+	if !to.IsZero() {
+		createdBefore = &to
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, account_id, created_at, subtotal, discount_total, tax_total, shipping_total, total_price, currency, prices_include_tax,
+			EXISTS (SELECT 1 FROM order_status_history h WHERE h.order_id = orders.id AND h.to_status = $3),
+			EXISTS (SELECT 1 FROM order_status_history h WHERE h.order_id = orders.id AND h.from_status = $4 AND h.to_status = $5)
+		FROM orders
+		WHERE ($1::timestamptz IS NULL OR created_at >= $1) AND ($2::timestamptz IS NULL OR created_at < $2)
+		ORDER BY created_at, id`,
+		createdAfter, createdBefore, StatusPaid, StatusPending, StatusCancelled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ledgers := []OrderLedger{}
+	ids := []string{}
+	index := map[string]int{} // order id -> position in ledgers
+	for rows.Next() {
+		l := OrderLedger{Refunded: map[string]money.Money{}, Balances: map[string]money.Money{}}
+		o := &l.Order
+		var currency string
+		if err := rows.Scan(
+			&o.ID, &o.AccountID, &o.CreatedAt, &o.Subtotal.Amount, &o.Discount.Amount, &o.Tax.Amount, &o.Shipping.Amount,
+			&o.TotalPrice.Amount, &currency, &o.PricesIncludeTax, &l.Paid, &l.CancelledUnpaid,
+		); err != nil {
+			return nil, err
+		}
+		o.Subtotal.Currency, o.Discount.Currency, o.Tax.Currency, o.Shipping.Currency, o.TotalPrice.Currency = currency, currency, currency, currency, currency
+		index[o.ID] = len(ledgers)
+		ledgers = append(ledgers, l)
+		ids = append(ids, o.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ledgers) == 0 {
+		return ledgers, nil
+	}
+	refunds, err := r.db.QueryContext(ctx,
+		`SELECT order_id, destination, SUM(amount), currency
+		FROM order_refunds
+		WHERE order_id = ANY($1) AND completed_at IS NOT NULL
+		GROUP BY order_id, destination, currency`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer refunds.Close()
+	for refunds.Next() {
+		var orderID, destination string
+		m := money.Money{}
+		if err := refunds.Scan(&orderID, &destination, &m.Amount, &m.Currency); err != nil {
+			return nil, err
+		}
+		ledgers[index[orderID]].Refunded[destination] = m
+	}
+	if err := refunds.Err(); err != nil {
+		return nil, err
+	}
+	balances, err := r.db.QueryContext(ctx,
+		`SELECT e.order_id, p.ledger_account, SUM(p.amount), p.currency
+		FROM ledger_postings p JOIN ledger_entries e ON e.id = p.entry_id
+		WHERE e.order_id = ANY($1)
+		GROUP BY e.order_id, p.ledger_account, p.currency`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer balances.Close()
+	for balances.Next() {
+		var orderID, account string
+		m := money.Money{}
+		if err := balances.Scan(&orderID, &account, &m.Amount, &m.Currency); err != nil {
+			return nil, err
+		}
+		l := &ledgers[index[orderID]]
+		// A balance in a currency other than the order's, which shouldn't exist, is kept so it shows up as a mismatch.
+		if _, ok := l.Balances[account]; !ok || m.Currency != l.Order.TotalPrice.Currency {
+			l.Balances[account] = m
+		}
+	}
+	return ledgers, balances.Err()
 }
@@ -0,0 +1,318 @@
+// Returns (RMAs). Within the return window after an order is delivered, its customer may ask to send back some
+// units of its lines. An admin approves or rejects the request; approving it refunds what the customer paid for
+// those units (see returnRefund) through the payment service, like any other refund (see refund.go). Once the
+// parcel arrives, the admin marks the return received and may put the units back into stock.
+//
+//	requested -> approved -> received
+//	requested -> rejected
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	ReturnRequested = "requested"
+	ReturnApproved  = "approved"
+	ReturnRejected  = "rejected"
+	ReturnReceived  = "received"
+)
+
+var (
+	ErrInvalidReturn           = errors.New("invalid return")
+	ErrReturnNotFound          = errors.New("return not found")
+	ErrNotReturnable           = errors.New("only delivered orders can be returned")
+	ErrReturnWindowClosed      = errors.New("the return window of the order has closed")
+	ErrInvalidReturnTransition = errors.New("return cannot move to that status from its current status")
+	ErrReturnConflict          = errors.New("return was changed concurrently, reload and try again")
+)
+
+type Return struct {
+	ID         string
+	OrderID    string
+	AccountID  string // account that placed the order
+	Status     string
+	Reason     string       // given by the customer
+	Lines      []ReturnLine // in the order of the order's lines
+	Refund     money.Money  // sum of the lines' Refund, in the order's currency
+	RefundedAt time.Time    // zero until the refund went through
+	Restocked  bool         // the units were put back into stock when the return was received
+	History    []ReturnEvent
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Units of one order line being sent back.
+type ReturnLine struct {
+	ProductID string
+	Quantity  uint32
+	Refund    money.Money // what the customer paid for these units
+}
+
+// One status change of a return.
+type ReturnEvent struct {
+	Status     string
+	Note       string
+	OccurredAt time.Time
+	ChangedBy  string // account id of the caller who made the change
+}
+
+// Allowed next statuses for each return status.
+var returnTransitions = map[string][]string{
+	ReturnRequested: {ReturnApproved, ReturnRejected},
+	ReturnApproved:  {ReturnReceived},
+	ReturnRejected:  {},
+	ReturnReceived:  {},
+}
+
+func canTransitionReturn(from, to string) bool {
+	for _, next := range returnTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// When the order was delivered, from its status history; zero if it wasn't.
+func deliveredAt(o Order) time.Time {
+	at := time.Time{}
+	for _, c := range o.StatusHistory {
+		if c.To == StatusDelivered {
+			at = c.ChangedAt
+		}
+	}
+	return at
+}
+
+// Units of each product of the order sent back, or asked to be, by returns that weren't rejected.
+func returnedQuantities(o Order) map[string]uint32 {
+	returned := map[string]uint32{}
+	for _, ret := range o.Returns {
+		if ret.Status == ReturnRejected {
+			continue
+		}
+		for _, l := range ret.Lines {
+			returned[l.ProductID] += l.Quantity
+		}
+	}
+	return returned
+}
+
+// What the customer paid for units previous+1 to previous+quantity of the line: its total after discounts, plus
+// its tax unless that is part of the prices, shared out by quantity. Each share is the difference of two rounded
+// cumulative amounts, so returning every unit of a line, in any number of returns, refunds exactly what it cost.
+func returnRefund(p OrderedProduct, pricesIncludeTax bool, previous, quantity uint32) money.Money {
+	paid := p.Total()
+	if !pricesIncludeTax {
+		paid.Amount += p.Tax.Amount
+	}
+	upTo := func(units uint32) int64 {
+		return mulDivRound(paid.Amount, int64(units), int64(p.Quantity))
+	}
+	return money.New(upTo(previous+quantity)-upTo(previous), paid.Currency)
+}
+
+// Asks to send back units of a delivered order. Lines for the same product are merged; every line must name a
+// product of the order and, together with earlier returns that weren't rejected and refunds by line, stay within
+// its quantity.
+// requestedBy is the account id of the caller.
+func (s orderService) RequestReturn(ctx context.Context, orderID string, lines []ReturnLine, reason, requestedBy string) (*Return, error) {
+	o, err := s.repository.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != StatusDelivered {
+		return nil, fmt.Errorf("%w: order is %s", ErrNotReturnable, o.Status)
+	}
+	now := time.Now().UTC()
+	if now.After(deliveredAt(*o).Add(s.returnWindow)) {
+		return nil, ErrReturnWindowClosed
+	}
+	invalid := func(problem string) error {
+		return fmt.Errorf("%w: %s", ErrInvalidReturn, problem)
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > 1000 {
+		return nil, invalid("reason must be at most 1000 characters")
+	}
+	if len(lines) == 0 {
+		return nil, invalid("at least one line is required")
+	}
+	requested := map[string]uint64{}
+	for _, l := range lines {
+		if l.Quantity == 0 {
+			return nil, invalid("quantities must be at least 1")
+		}
+		requested[l.ProductID] += uint64(l.Quantity)
+	}
+	claimed := claimedQuantities(*o)
+	ret := Return{
+		ID:        ksuid.New().String(),
+		OrderID:   o.ID,
+		AccountID: o.AccountID,
+		Status:    ReturnRequested,
+		Reason:    reason,
+		Lines:     []ReturnLine{},
+		Refund:    money.Zero(o.TotalPrice.Currency),
+		History:   []ReturnEvent{{Status: ReturnRequested, Note: reason, OccurredAt: now, ChangedBy: requestedBy}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, p := range o.Products {
+		quantity, ok := requested[p.ID]
+		if !ok {
+			continue
+		}
+		delete(requested, p.ID)
+		if quantity > uint64(p.Quantity-claimed[p.ID]) {
+			return nil, invalid(fmt.Sprintf("only %d units of %s are left to return", p.Quantity-claimed[p.ID], p.ID))
+		}
+		l := ReturnLine{
+			ProductID: p.ID,
+			Quantity:  uint32(quantity),
+			Refund:    returnRefund(p, o.PricesIncludeTax, claimed[p.ID], uint32(quantity)),
+		}
+		ret.Lines = append(ret.Lines, l)
+		ret.Refund.Amount += l.Refund.Amount
+	}
+	for id := range requested {
+		return nil, invalid("product " + id + " is not part of the order")
+	}
+	if err := s.repository.PutReturn(ctx, ret, claimed); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (s orderService) GetReturn(ctx context.Context, id string) (*Return, error) {
+	return s.repository.GetReturn(ctx, id)
+}
+
+// Every return in status (all of them if status is empty), oldest first.
+func (s orderService) ListReturns(ctx context.Context, status string) ([]Return, error) {
+	if _, ok := returnTransitions[status]; status != "" && !ok {
+		return nil, fmt.Errorf("%w: unknown status %s", ErrInvalidReturn, status)
+	}
+	return s.repository.ListReturns(ctx, status)
+}
+
+// Approves a requested return and refunds it to the customer's payment. Without a payment service the refund is
+// left to staff (e.g. as store credit with RefundOrder), and RefundedAt stays zero. If the refund fails the return
+// stays approved; approving it again retries the refund. A return has at most one refund: a retry asks the payment
+// service again for the refund left pending, and approving the return twice at the same time fails with
+// ErrReturnConflict for one of the calls.
+func (s orderService) ApproveReturn(ctx context.Context, id, note, changedBy string) (*Return, error) {
+	ret, err := s.repository.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != ReturnApproved {
+		if err := s.changeReturnStatus(ctx, ret, ReturnApproved, note, changedBy); err != nil {
+			return nil, err
+		}
+	}
+	if s.payments != nil && ret.RefundedAt.IsZero() {
+		o, err := s.repository.GetOrder(ctx, ret.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.refundReturn(ctx, o, ret, changedBy); err != nil {
+			return nil, fmt.Errorf("return %s is approved, but refunding it failed: %w", ret.ID, err)
+		}
+	}
+	return s.repository.GetReturn(ctx, id)
+}
+
+// Refunds an approved return to the customer's payment, or finishes its pending refund.
+func (s orderService) refundReturn(ctx context.Context, o *Order, ret *Return, refundedBy string) error {
+	for i := range o.Refunds {
+		if ref := &o.Refunds[i]; ref.ReturnID == ret.ID {
+			if !ref.CompletedAt.IsZero() {
+				return nil
+			}
+			return s.payOut(ctx, ref)
+		}
+	}
+	ref := OrderRefund{
+		ID:          ksuid.New().String(),
+		OrderID:     ret.OrderID,
+		ReturnID:    ret.ID,
+		Amount:      ret.Refund,
+		Destination: RefundToPayment,
+		Reason:      "return " + ret.ID,
+		Lines:       []RefundLine{},
+		RefundedBy:  refundedBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+	for _, l := range ret.Lines {
+		ref.Lines = append(ref.Lines, RefundLine{ProductID: l.ProductID, Quantity: l.Quantity, Amount: l.Refund})
+	}
+	return s.refund(ctx, o, &ref)
+}
+
+func (s orderService) RejectReturn(ctx context.Context, id, note, changedBy string) (*Return, error) {
+	ret, err := s.repository.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.changeReturnStatus(ctx, ret, ReturnRejected, note, changedBy); err != nil {
+		return nil, err
+	}
+	return s.repository.GetReturn(ctx, id)
+}
+
+// Records that the parcel of an approved return arrived. With restock, its units are put back into stock; the
+// return is marked restocked first, so two calls can't both restock it. If restocking fails the mark is taken back
+// and the return stays received; receiving it again with restock retries the restock (products the failed attempt
+// managed to restock are added again, see Inventory.Restock).
+func (s orderService) ReceiveReturn(ctx context.Context, id string, restock bool, note, changedBy string) (*Return, error) {
+	ret, err := s.repository.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if retry := restock && ret.Status == ReturnReceived && !ret.Restocked; !retry {
+		if err := s.changeReturnStatus(ctx, ret, ReturnReceived, note, changedBy); err != nil {
+			return nil, err
+		}
+	}
+	if restock {
+		if err := s.repository.SetReturnRestocked(ctx, ret.ID, true); err != nil {
+			return nil, err
+		}
+		products := []OrderedProduct{}
+		for _, l := range ret.Lines {
+			products = append(products, OrderedProduct{ID: l.ProductID, Quantity: l.Quantity})
+		}
+		if err := s.inventory.Restock(ctx, products); err != nil {
+			if undoErr := s.repository.SetReturnRestocked(context.WithoutCancel(ctx), ret.ID, false); undoErr != nil {
+				log.Printf("unmarking return %s restocked after restocking failed: %v", ret.ID, undoErr)
+			}
+			return nil, fmt.Errorf("return %s is received, but restocking it failed, receive it again to retry: %w", ret.ID, err)
+		}
+	}
+	return s.repository.GetReturn(ctx, id)
+}
+
+func (s orderService) changeReturnStatus(ctx context.Context, ret *Return, status, note, changedBy string) error {
+	if !canTransitionReturn(ret.Status, status) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidReturnTransition, ret.Status, status)
+	}
+	ev := ReturnEvent{
+		Status:     status,
+		Note:       strings.TrimSpace(note),
+		OccurredAt: time.Now().UTC(),
+		ChangedBy:  changedBy,
+	}
+	return s.repository.UpdateReturnStatus(ctx, ret.ID, ret.Status, ev)
+}
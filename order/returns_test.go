@@ -0,0 +1,150 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+// An approved return of two units of p1, in a checkout whose catalog has none left.
+func approvedReturn(t *testing.T) (checkout, Return) {
+	t.Helper()
+	c := newCheckout(map[string]uint32{"p1": 0})
+	now := time.Now().UTC()
+	ret := Return{
+		ID:        ksuid.New().String(),
+		OrderID:   ksuid.New().String(),
+		AccountID: "account",
+		Status:    ReturnApproved,
+		Lines:     []ReturnLine{{ProductID: "p1", Quantity: 2, Refund: money.New(2000, "USD")}},
+		Refund:    money.New(2000, "USD"),
+		History:   []ReturnEvent{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := c.repository.PutReturn(context.Background(), ret, nil); err != nil {
+		t.Fatal(err)
+	}
+	return c, ret
+}
+
+func TestReceiveReturn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("restocks", func(t *testing.T) {
+		c, ret := approvedReturn(t)
+		got, err := c.service.ReceiveReturn(ctx, ret.ID, true, "", "admin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != ReturnReceived || !got.Restocked {
+			t.Errorf("got return %s (restocked %t), want it received and restocked", got.Status, got.Restocked)
+		}
+		if units := c.inventory.stock["p1"]; units != 2 {
+			t.Errorf("got %d units of p1, want 2", units)
+		}
+		if _, err := c.service.ReceiveReturn(ctx, ret.ID, true, "", "admin"); !errors.Is(err, ErrInvalidReturnTransition) {
+			t.Errorf("receiving it again: got error %v, want %v", err, ErrInvalidReturnTransition)
+		}
+		if units := c.inventory.stock["p1"]; units != 2 {
+			t.Errorf("got %d units of p1 after receiving the return again, want still 2", units)
+		}
+	})
+
+	t.Run("retries a failed restock", func(t *testing.T) {
+		c, ret := approvedReturn(t)
+		c.inventory.failRestocks = 1
+		if _, err := c.service.ReceiveReturn(ctx, ret.ID, true, "", "admin"); !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("got error %v, want %v", err, ErrUnavailable)
+		}
+		failed, _ := c.repository.GetReturn(ctx, ret.ID)
+		if failed.Status != ReturnReceived || failed.Restocked {
+			t.Fatalf("got return %s (restocked %t), want it received and not restocked", failed.Status, failed.Restocked)
+		}
+		got, err := c.service.ReceiveReturn(ctx, ret.ID, true, "", "admin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Restocked || len(got.History) != 1 {
+			t.Errorf("got return restocked %t with %d events, want it restocked with the one receive event", got.Restocked, len(got.History))
+		}
+		if units := c.inventory.stock["p1"]; units != 2 {
+			t.Errorf("got %d units of p1, want 2", units)
+		}
+	})
+
+	t.Run("without restock", func(t *testing.T) {
+		c, ret := approvedReturn(t)
+		if _, err := c.service.ReceiveReturn(ctx, ret.ID, false, "damaged", "admin"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.service.ReceiveReturn(ctx, ret.ID, false, "", "admin"); !errors.Is(err, ErrInvalidReturnTransition) {
+			t.Errorf("receiving it again without restock: got error %v, want %v", err, ErrInvalidReturnTransition)
+		}
+		if units := c.inventory.stock["p1"]; units != 0 {
+			t.Errorf("got %d units of p1, want none", units)
+		}
+	})
+}
+
+// A return and a refund by line asking for the same units at the same time, both checked against an order with none
+// claimed yet: with the order locked while the units are counted again, only one of them gets the units.
+func TestPutReturnAndRefundClaimUnitsOnce(t *testing.T) {
+	r := testRepository(t)
+	ctx := context.Background()
+	product := testProduct(1000, 2)
+	o := testOrder(ksuid.New().String(), time.Now().UTC().Truncate(time.Second), StatusDelivered, product)
+	putOrders(t, r, o)
+	now := time.Now().UTC()
+	ret := Return{
+		ID:        ksuid.New().String(),
+		OrderID:   o.ID,
+		AccountID: o.AccountID,
+		Status:    ReturnRequested,
+		Lines:     []ReturnLine{{ProductID: product.ID, Quantity: 2, Refund: money.New(2000, "USD")}},
+		Refund:    money.New(2000, "USD"),
+		History:   []ReturnEvent{{Status: ReturnRequested, OccurredAt: now, ChangedBy: o.AccountID}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	ref := OrderRefund{
+		ID:          ksuid.New().String(),
+		OrderID:     o.ID,
+		Amount:      money.New(2000, "USD"),
+		Destination: RefundToPayment,
+		Lines:       []RefundLine{{ProductID: product.ID, Quantity: 2, Amount: money.New(2000, "USD")}},
+		CreatedAt:   now,
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs[0] = r.PutReturn(ctx, ret, map[string]uint32{})
+	}()
+	go func() {
+		defer wg.Done()
+		errs[1] = r.PutRefund(ctx, ref, map[string]uint32{})
+	}()
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, ErrReturnConflict):
+			t.Errorf("got error %v, want %v", err, ErrReturnConflict)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("got the return (error %v) and the refund (error %v), want exactly one of them", errs[0], errs[1])
+	}
+}
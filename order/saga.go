@@ -0,0 +1,325 @@
+// Checkout saga: placing an order spans the catalog (stock), the payment service and the order database,
+// so it runs as a sequence of steps whose progress is saved after each one:
+//
+//	reserve stock -> authorize payment -> write order -> commit stock -> capture payment -> mark paid
+//
+// If a step up to and including the stock commit fails, the steps already done are undone in reverse order
+// (release the stock, void the authorization, cancel the order) and the saga ends as aborted. Committing the stock
+// is the point of no return: the steps after it are never undone, only retried until they succeed, or until
+// sagaDeadline has passed: then the saga is given up on as failed, and its order waits, pending, to be cancelled.
+// A saga whose process died is picked up again by ResumeSagas once its lease runs out.
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+)
+
+var ErrCheckoutInProgress = errors.New("order is still being placed, try again later")
+
+const (
+	SagaRunning      = "running"
+	SagaCompensating = "compensating" // a step failed; the steps done so far are being undone
+	SagaCompleted    = "completed"
+	SagaAborted      = "aborted" // every step done was undone
+	SagaFailed       = "failed"  // a step after the point of no return kept failing until sagaDeadline
+)
+
+const (
+	// How long one run of a saga may take, whether started by PostOrder or by ResumeSagas.
+	sagaTimeout = 30 * time.Second
+	// A saga is left alone for this long after a run starts, so only one process works on it at a time.
+	// Must be longer than sagaTimeout.
+	sagaLease = time.Minute
+	// A step after the point of no return that is still failing this long after the checkout started isn't retried
+	// any more, so the order can be cancelled.
+	sagaDeadline = 24 * time.Hour
+)
+
+// Progress of placing one order, as saved in the order_sagas table.
+type Saga struct {
+	ID            string // id of the order being placed
+	State         string
+	Order         Order    // the order as priced by PostOrder
+	Steps         []string // names of the steps done, in order
+	ReservationID string   // set by the reserve_stock step
+	PaymentID     string   // set by the authorize_payment step
+	Failure       string   // error of the step that failed last
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (s *Saga) done(step string) bool {
+	for _, name := range s.Steps {
+		if name == step {
+			return true
+		}
+	}
+	return false
+}
+
+// One step of the checkout. Every step may run again after a crash, so do and undo must be safe to repeat.
+type sagaStep struct {
+	name string
+	do   func(ctx context.Context, s *Saga) error
+	undo func(ctx context.Context, s *Saga) error // nil if there is nothing to undo
+	// The step comes after the point of no return: when it fails, it is retried later instead of undoing the saga.
+	final bool
+}
+
+// The checkout steps. Without a payment service the payment steps are left out and orders stay pending
+// until they are paid through the payment service's PayOrder.
+func (s orderService) checkoutSteps() []sagaStep {
+	steps := []sagaStep{
+		{name: "reserve_stock", do: s.reserveStock, undo: s.releaseStock},
+	}
+	if s.payments != nil {
+		steps = append(steps, sagaStep{name: "authorize_payment", do: s.authorizePayment, undo: s.voidPayment})
+	}
+	steps = append(steps,
+		sagaStep{name: "create_order", do: s.createOrder, undo: s.cancelIncompleteOrder},
+		sagaStep{name: "commit_stock", do: s.commitStock},
+	)
+	if s.payments != nil {
+		steps = append(steps,
+			sagaStep{name: "capture_payment", do: s.capturePayment, final: true},
+			sagaStep{name: "mark_paid", do: s.markPaid, final: true},
+		)
+	}
+	return steps
+}
+
+// Runs the saga from wherever it stands until it completes, is aborted, or can't go on for now.
+// Returns the error of the step that made it abort, or the error that stopped it.
+func (s orderService) runSaga(ctx context.Context, saga *Saga) error {
+	steps := s.checkoutSteps()
+	if saga.State == SagaRunning {
+		for _, step := range steps {
+			if saga.done(step.name) {
+				continue
+			}
+			err := step.do(ctx, saga)
+			if err != nil && step.final {
+				// Left running: ResumeSagas retries the step once the lease runs out, until the deadline.
+				saga.Failure = err.Error()
+				if time.Since(saga.CreatedAt) > sagaDeadline {
+					saga.State = SagaFailed
+				}
+				s.saveSaga(ctx, saga)
+				return fmt.Errorf("checkout of order %s, step %s: %w", saga.ID, step.name, err)
+			}
+			if err != nil {
+				saga.State = SagaCompensating
+				saga.Failure = err.Error()
+				if saveErr := s.saveSaga(ctx, saga); saveErr != nil {
+					return saveErr
+				}
+				if undoErr := s.compensate(ctx, saga, steps); undoErr != nil {
+					log.Printf("undoing checkout of order %s: %v", saga.ID, undoErr)
+				}
+				return err
+			}
+			saga.Steps = append(saga.Steps, step.name)
+			if err := s.saveSaga(ctx, saga); err != nil {
+				return err
+			}
+		}
+		saga.State = SagaCompleted
+		saga.Failure = ""
+		return s.saveSaga(ctx, saga)
+	}
+	if saga.State == SagaCompensating {
+		if err := s.compensate(ctx, saga, steps); err != nil {
+			return err
+		}
+		return errors.New(saga.Failure)
+	}
+	return nil
+}
+
+// Undoes the steps done so far, newest first, and marks the saga aborted. Steps are taken off the saga as they
+// are undone, so a later run carries on where this one stopped.
+func (s orderService) compensate(ctx context.Context, saga *Saga, steps []sagaStep) error {
+	byName := map[string]sagaStep{}
+	for _, step := range steps {
+		byName[step.name] = step
+	}
+	for len(saga.Steps) > 0 {
+		name := saga.Steps[len(saga.Steps)-1]
+		step, ok := byName[name]
+		if !ok {
+			// The step isn't configured any more (e.g. the payment service was removed); it can't be undone here.
+			log.Printf("checkout of order %s: cannot undo unknown step %s", saga.ID, name)
+		} else if step.undo != nil {
+			if err := step.undo(ctx, saga); err != nil {
+				return fmt.Errorf("step %s: %w", name, err)
+			}
+		}
+		saga.Steps = saga.Steps[:len(saga.Steps)-1]
+		if err := s.saveSaga(ctx, saga); err != nil {
+			return err
+		}
+	}
+	saga.State = SagaAborted
+	return s.saveSaga(ctx, saga)
+}
+
+func (s orderService) saveSaga(ctx context.Context, saga *Saga) error {
+	saga.UpdatedAt = time.Now().UTC()
+	if err := s.repository.UpdateSaga(ctx, *saga); err != nil {
+		log.Printf("saving checkout of order %s: %v", saga.ID, err)
+		return err
+	}
+	return nil
+}
+
+// Reports whether the saga is still working on its order: it may yet capture the payment or cancel the order.
+func (s *Saga) unfinished() bool {
+	return s.State == SagaRunning || s.State == SagaCompensating
+}
+
+// Picks up sagas whose lease ran out without them finishing (their process died, or a final step failed)
+// and runs them again. Meant to be run periodically; one run handles at most 100 sagas.
+// Returns how many sagas were completed, aborted or given up on as failed.
+func (s orderService) ResumeSagas(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	sagas, err := s.repository.ClaimSagas(ctx, now, now.Add(sagaLease), 100)
+	if err != nil {
+		return 0, err
+	}
+	finished := 0
+	for i := range sagas {
+		saga := &sagas[i]
+		runCtx, cancel := context.WithTimeout(ctx, sagaTimeout)
+		err := s.runSaga(runCtx, saga)
+		cancel()
+		if saga.State == SagaCompleted || saga.State == SagaAborted || saga.State == SagaFailed {
+			log.Printf("resumed checkout of order %s: %s", saga.ID, saga.State)
+			finished++
+			continue
+		}
+		log.Printf("resuming checkout of order %s: %v", saga.ID, err)
+	}
+	return finished, nil
+}
+
+func (s orderService) reserveStock(ctx context.Context, saga *Saga) error {
+	// A reservation made before a crash, but not saved, is left to expire.
+	id, err := s.inventory.Reserve(ctx, saga.Order.Products)
+	if err != nil {
+		return err
+	}
+	saga.ReservationID = id
+	return nil
+}
+
+func (s orderService) releaseStock(ctx context.Context, saga *Saga) error {
+	if saga.ReservationID == "" {
+		return nil
+	}
+	return s.inventory.Release(ctx, saga.ReservationID)
+}
+
+// The saga id is the idempotency key, so authorizing again after a crash finds the first payment.
+func (s orderService) authorizePayment(ctx context.Context, saga *Saga) error {
+	id, err := s.payments.Authorize(ctx, saga.Order, saga.ID)
+	if err != nil {
+		return err
+	}
+	saga.PaymentID = id
+	return nil
+}
+
+func (s orderService) voidPayment(ctx context.Context, saga *Saga) error {
+	if saga.PaymentID == "" {
+		return nil
+	}
+	return s.payments.Void(ctx, saga.PaymentID)
+}
+
+func (s orderService) createOrder(ctx context.Context, saga *Saga) error {
+	_, err := s.repository.GetOrder(ctx, saga.ID)
+	if err == nil {
+		return nil // written before a crash
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return s.repository.PutOrder(ctx, saga.Order)
+}
+
+// Cancels the order on behalf of the system; nothing was paid, so no refund is due.
+func (s orderService) cancelIncompleteOrder(ctx context.Context, saga *Saga) error {
+	o, err := s.repository.GetOrder(ctx, saga.ID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if o.Status == StatusCancelled {
+		return nil
+	}
+	now := time.Now().UTC()
+	note := "order could not be completed: " + saga.Failure
+	change := StatusChange{From: o.Status, To: StatusCancelled, ChangedAt: now, Note: note}
+	c := Cancellation{Reason: note, RefundDue: money.Zero(o.TotalPrice.Currency), CancelledAt: now}
+	return s.repository.CancelOrder(ctx, o.ID, change, c)
+}
+
+// The point of no return. A reservation that expired in the meantime fails the step and the saga is undone.
+func (s orderService) commitStock(ctx context.Context, saga *Saga) error {
+	if err := s.inventory.Commit(ctx, saga.ReservationID); err != nil {
+		return fmt.Errorf("%w: %v", ErrReservationFailed, err)
+	}
+	return nil
+}
+
+func (s orderService) capturePayment(ctx context.Context, saga *Saga) error {
+	return s.payments.Capture(ctx, saga.PaymentID)
+}
+
+// Moves the order from pending to paid, unless something else moved it already
+// (the payment service marks orders paid when it captures their payment).
+func (s orderService) markPaid(ctx context.Context, saga *Saga) error {
+	o, err := s.repository.GetOrder(ctx, saga.ID)
+	if err != nil {
+		return err
+	}
+	if o.Status != StatusPending {
+		return nil
+	}
+	change := StatusChange{
+		From:      StatusPending,
+		To:        StatusPaid,
+		ChangedAt: time.Now().UTC(),
+		Note:      "payment " + saga.PaymentID + " captured",
+	}
+	if err := s.repository.UpdateOrderStatus(ctx, o.ID, change); err != nil && !errors.Is(err, ErrStatusConflict) {
+		return err
+	}
+	return nil
+}
+
+// Settles the payment of a checkout that was given up on, so its order can be cancelled: an authorization that
+// was never captured is voided, and a payment that was captured is recorded by marking the order paid, so
+// cancelling the order refunds it. Returns the order as it is now.
+func (s orderService) settleFailedCheckout(ctx context.Context, saga *Saga) (*Order, error) {
+	switch {
+	case saga.done("capture_payment"):
+		if err := s.markPaid(ctx, saga); err != nil {
+			return nil, err
+		}
+	case saga.PaymentID != "":
+		if err := s.payments.Void(ctx, saga.PaymentID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repository.GetOrder(ctx, saga.ID)
+}
@@ -0,0 +1,315 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+type checkout struct {
+	service    Service
+	repository *memoryRepository
+	inventory  *fakeInventory
+	payments   *fakePayments
+}
+
+func newCheckout(stock map[string]uint32) checkout {
+	c := checkout{
+		repository: newMemoryRepository(),
+		inventory:  newFakeInventory(stock),
+		payments:   newFakePayments(),
+	}
+	c.service = NewService(c.repository, DefaultLimits, false, 0, c.inventory, c.payments)
+	return c
+}
+
+func line(productID string, price int64, quantity uint32) OrderedProduct {
+	return OrderedProduct{ID: productID, Name: productID, Price: money.New(price, "USD"), Quantity: quantity}
+}
+
+func (c checkout) postOrder(products ...OrderedProduct) (*Order, error) {
+	return c.service.PostOrder(context.Background(), "account", nil, nil, products, "USD", nil, nil)
+}
+
+// The saga of the only order placed so far.
+func (c checkout) onlySaga(t *testing.T) Saga {
+	t.Helper()
+	if len(c.repository.sagas) != 1 {
+		t.Fatalf("got %d sagas, want 1", len(c.repository.sagas))
+	}
+	for _, s := range c.repository.sagas {
+		return s
+	}
+	return Saga{}
+}
+
+// Stores a saga as a process that died after doing steps would have left it, with its lease run out.
+func (c checkout) crashedSaga(t *testing.T, state string, steps ...string) Saga {
+	t.Helper()
+	ctx := context.Background()
+	products := []OrderedProduct{line("p1", 1000, 2)}
+	o := Order{
+		ID:            ksuid.New().String(),
+		CreatedAt:     time.Now().UTC(),
+		AccountID:     "account",
+		Subtotal:      money.New(2000, "USD"),
+		Discount:      money.Zero("USD"),
+		Tax:           money.Zero("USD"),
+		Shipping:      money.Zero("USD"),
+		TotalPrice:    money.New(2000, "USD"),
+		Products:      products,
+		Status:        StatusPending,
+		ExchangeRates: []money.Rate{},
+	}
+	saga := Saga{ID: o.ID, State: state, Order: o, Steps: steps, Failure: "the process died", CreatedAt: o.CreatedAt}
+	for _, step := range steps {
+		var err error
+		switch step {
+		case "reserve_stock":
+			saga.ReservationID, err = c.inventory.Reserve(ctx, products)
+		case "authorize_payment":
+			saga.PaymentID, err = c.payments.Authorize(ctx, o, o.ID)
+		case "create_order":
+			err = c.repository.PutOrder(ctx, o)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.repository.CreateSaga(ctx, saga, time.Now().UTC().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	return saga
+}
+
+func TestCheckoutCompletes(t *testing.T) {
+	c := newCheckout(map[string]uint32{"p1": 5})
+	o, err := c.postOrder(line("p1", 1000, 2), line("p2", 250, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != StatusPaid {
+		t.Errorf("got order %s, want it paid", o.Status)
+	}
+	saga := c.onlySaga(t)
+	wantSteps := []string{"reserve_stock", "authorize_payment", "create_order", "commit_stock", "capture_payment", "mark_paid"}
+	if saga.State != SagaCompleted || !equalIDs(saga.Steps, wantSteps) {
+		t.Errorf("got saga %s after %v, want it completed after %v", saga.State, saga.Steps, wantSteps)
+	}
+	if got := c.payments.status(saga.PaymentID); got != "captured" {
+		t.Errorf("got payment %s, want it captured", got)
+	}
+	if got := c.inventory.reservationStatuses(); !equalIDs(got, []string{"committed"}) {
+		t.Errorf("got reservations %v, want one committed", got)
+	}
+	if got := c.inventory.stock["p1"]; got != 3 {
+		t.Errorf("got %d units of p1 left, want 3", got)
+	}
+}
+
+// A checkout that fails before the point of no return undoes every step it took, newest first.
+func TestCheckoutCompensates(t *testing.T) {
+	tests := []struct {
+		name             string
+		stock            uint32
+		price            int64
+		failCommit       bool
+		wantErr          error
+		wantReservations []string
+		wantPayment      string // status of the authorization, empty if there is none
+		wantOrder        string // status of the order, empty if it was never written
+	}{
+		{
+			name: "out of stock", stock: 1, price: 1000,
+			wantErr: ErrInvalidOrderLines, wantReservations: []string{},
+		},
+		{
+			name: "payment declined", stock: 5, price: 501, // two of them come to 10.02
+			wantErr: ErrPaymentDeclined, wantReservations: []string{"released"},
+		},
+		{
+			name: "stock commit fails", stock: 5, price: 1000, failCommit: true,
+			wantErr: ErrReservationFailed, wantReservations: []string{"released"}, wantPayment: "voided", wantOrder: StatusCancelled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCheckout(map[string]uint32{"p1": tt.stock})
+			c.inventory.failCommit = tt.failCommit
+			_, err := c.postOrder(line("p1", tt.price, 2))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			saga := c.onlySaga(t)
+			if saga.State != SagaAborted || len(saga.Steps) != 0 {
+				t.Errorf("got saga %s with steps %v left, want it aborted with none", saga.State, saga.Steps)
+			}
+			if got := c.inventory.reservationStatuses(); !equalIDs(got, tt.wantReservations) {
+				t.Errorf("got reservations %v, want %v", got, tt.wantReservations)
+			}
+			if got := c.inventory.stock["p1"]; got != tt.stock {
+				t.Errorf("got %d units of p1 left, want all %d back", got, tt.stock)
+			}
+			if got := c.payments.status(saga.PaymentID); got != tt.wantPayment {
+				t.Errorf("got payment %q, want %q", got, tt.wantPayment)
+			}
+			o, err := c.repository.GetOrder(context.Background(), saga.ID)
+			switch {
+			case tt.wantOrder == "" && !errors.Is(err, ErrNotFound):
+				t.Errorf("got order %v (error %v), want none", o, err)
+			case tt.wantOrder != "" && err != nil:
+				t.Errorf("got error %v, want the order", err)
+			case tt.wantOrder != "" && (o.Status != tt.wantOrder || o.Cancellation == nil || !o.Cancellation.RefundDue.IsZero()):
+				t.Errorf("got order %s with cancellation %+v, want it %s with nothing due", o.Status, o.Cancellation, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestResumeSagas(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries a failed capture", func(t *testing.T) {
+		c := newCheckout(nil)
+		c.payments.failCaptures = 1
+		o, err := c.postOrder(line("p1", 1000, 1))
+		if err != nil {
+			t.Fatalf("got error %v, want the order placed and its capture left for later", err)
+		}
+		if o.Status != StatusPending {
+			t.Fatalf("got order %s, want it pending", o.Status)
+		}
+		if saga := c.onlySaga(t); saga.State != SagaRunning || saga.Failure == "" {
+			t.Fatalf("got saga %s (failure %q), want it running with the failure recorded", saga.State, saga.Failure)
+		}
+
+		if n, err := c.service.ResumeSagas(ctx); err != nil || n != 0 {
+			t.Fatalf("resumed %d sagas (error %v) while the lease was held, want none", n, err)
+		}
+		c.repository.expireLeases()
+		if n, err := c.service.ResumeSagas(ctx); err != nil || n != 1 {
+			t.Fatalf("resumed %d sagas (error %v), want 1", n, err)
+		}
+		saga := c.onlySaga(t)
+		if saga.State != SagaCompleted || saga.Failure != "" {
+			t.Errorf("got saga %s (failure %q), want it completed", saga.State, saga.Failure)
+		}
+		if got := c.payments.status(saga.PaymentID); got != "captured" {
+			t.Errorf("got payment %s, want it captured", got)
+		}
+		if o, _ := c.repository.GetOrder(ctx, saga.ID); o.Status != StatusPaid {
+			t.Errorf("got order %s, want it paid", o.Status)
+		}
+	})
+
+	t.Run("gives up on a capture failing past the deadline", func(t *testing.T) {
+		c := newCheckout(nil)
+		c.payments.failCaptures = 1000
+		o, err := c.postOrder(line("p1", 1000, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.repository.ageSagas(sagaDeadline + time.Hour)
+		c.repository.expireLeases()
+		if n, err := c.service.ResumeSagas(ctx); err != nil || n != 1 {
+			t.Fatalf("resumed %d sagas (error %v), want 1", n, err)
+		}
+		saga := c.onlySaga(t)
+		if saga.State != SagaFailed || saga.Failure == "" {
+			t.Fatalf("got saga %s (failure %q), want it failed with the failure recorded", saga.State, saga.Failure)
+		}
+		c.repository.expireLeases()
+		if n, err := c.service.ResumeSagas(ctx); err != nil || n != 0 {
+			t.Errorf("resumed %d sagas (error %v) after giving up, want none", n, err)
+		}
+
+		cancelled, err := c.service.CancelOrder(ctx, o.ID, "admin", "payment could not be taken")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cancelled.Status != StatusCancelled || !cancelled.Cancellation.RefundDue.IsZero() {
+			t.Errorf("got order %s with %s due, want it cancelled with nothing due", cancelled.Status, cancelled.Cancellation.RefundDue)
+		}
+		if got := c.payments.status(saga.PaymentID); got != "voided" {
+			t.Errorf("got payment %s, want it voided", got)
+		}
+	})
+
+	t.Run("finishes a saga that died before writing the order", func(t *testing.T) {
+		c := newCheckout(map[string]uint32{"p1": 5})
+		saga := c.crashedSaga(t, SagaRunning, "reserve_stock", "authorize_payment")
+		if n, err := c.service.ResumeSagas(ctx); err != nil || n != 1 {
+			t.Fatalf("resumed %d sagas (error %v), want 1", n, err)
+		}
+		if got := c.onlySaga(t); got.State != SagaCompleted {
+			t.Errorf("got saga %s, want it completed", got.State)
+		}
+		o, err := c.repository.GetOrder(ctx, saga.ID)
+		if err != nil || o.Status != StatusPaid {
+			t.Fatalf("got order %v (error %v), want it written and paid", o, err)
+		}
+		if got := c.payments.status(saga.PaymentID); got != "captured" {
+			t.Errorf("got payment %s, want it captured", got)
+		}
+		if got := c.inventory.reservationStatuses(); !equalIDs(got, []string{"committed"}) {
+			t.Errorf("got reservations %v, want one committed", got)
+		}
+	})
+
+	t.Run("finishes undoing a saga that died while compensating", func(t *testing.T) {
+		c := newCheckout(map[string]uint32{"p1": 5})
+		saga := c.crashedSaga(t, SagaCompensating, "reserve_stock", "authorize_payment", "create_order")
+		if n, err := c.service.ResumeSagas(ctx); err != nil || n != 1 {
+			t.Fatalf("resumed %d sagas (error %v), want 1", n, err)
+		}
+		if got := c.onlySaga(t); got.State != SagaAborted || len(got.Steps) != 0 {
+			t.Errorf("got saga %s with steps %v left, want it aborted with none", got.State, got.Steps)
+		}
+		if o, _ := c.repository.GetOrder(ctx, saga.ID); o.Status != StatusCancelled {
+			t.Errorf("got order %s, want it cancelled", o.Status)
+		}
+		if got := c.payments.status(saga.PaymentID); got != "voided" {
+			t.Errorf("got payment %s, want it voided", got)
+		}
+		if got := c.inventory.stock["p1"]; got != 5 {
+			t.Errorf("got %d units of p1 left, want all 5 back", got)
+		}
+	})
+}
+
+// An order can't be cancelled while its checkout may still capture the payment; once the checkout is done,
+// cancelling the paid order refunds its total.
+func TestCancelOrderWaitsForCheckout(t *testing.T) {
+	ctx := context.Background()
+	c := newCheckout(nil)
+	c.payments.failCaptures = 1
+	o, err := c.postOrder(line("p1", 1000, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.service.CancelOrder(ctx, o.ID, "account", "changed my mind"); !errors.Is(err, ErrCheckoutInProgress) {
+		t.Fatalf("got error %v, want %v", err, ErrCheckoutInProgress)
+	}
+	c.repository.expireLeases()
+	if _, err := c.service.ResumeSagas(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cancelled, err := c.service.CancelOrder(ctx, o.ID, "account", "changed my mind")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.Status != StatusCancelled || cancelled.Cancellation.RefundDue != o.TotalPrice {
+		t.Errorf("got order %s with %s due, want it cancelled with %s due", cancelled.Status, cancelled.Cancellation.RefundDue, o.TotalPrice)
+	}
+	if len(cancelled.Refunds) != 1 || cancelled.Refunds[0].CompletedAt.IsZero() {
+		t.Errorf("got refunds %+v, want one completed", cancelled.Refunds)
+	}
+	if got := c.payments.refunds[o.ID]; got != o.TotalPrice {
+		t.Errorf("got %s refunded, want %s", got, o.TotalPrice)
+	}
+}
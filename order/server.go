@@ -2,13 +2,16 @@ package order
 
 import (
 	"Microservices-based-E-commerce-System/account"
+	"Microservices-based-E-commerce-System/auth"
 	"Microservices-based-E-commerce-System/catalog"
+	"Microservices-based-E-commerce-System/idempotency"
+	"Microservices-based-E-commerce-System/money"
 	"Microservices-based-E-commerce-System/order/pb"
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -19,9 +22,43 @@ type grpcServer struct {
 	service       Service
 	accountClient *account.Client
 	catalogClient *catalog.Client
+	idempotency   idempotency.Store // remembers PostOrder results by idempotency key
 }
 
-func ListenGRPC(s Service, accountURL, catalogURL string, port int) error {
+// Every order call needs a caller; the methods themselves check that the caller owns the account (or is an admin).
+// Moving orders through their lifecycle, shipping them, handling returns, refunding them, reading the ledger and
+// managing promotions, tax rules and shipping methods is staff work and admin-only. Anyone may list the shipping
+// methods to choose from.
+var policy = auth.Policy{
+	pb.OrderService_PostOrder_FullMethodName:               auth.Authenticated,
+	pb.OrderService_GetOrder_FullMethodName:                auth.Authenticated,
+	pb.OrderService_GetOrdersForAccount_FullMethodName:     auth.Authenticated,
+	pb.OrderService_ListOrdersForAccount_FullMethodName:    auth.Authenticated,
+	pb.OrderService_UpdateOrderStatus_FullMethodName:       auth.AdminOnly,
+	pb.OrderService_CancelOrder_FullMethodName:             auth.Authenticated,
+	pb.OrderService_CreatePromotion_FullMethodName:         auth.AdminOnly,
+	pb.OrderService_ListPromotions_FullMethodName:          auth.AdminOnly,
+	pb.OrderService_SetPromotionActive_FullMethodName:      auth.AdminOnly,
+	pb.OrderService_SetTaxRule_FullMethodName:              auth.AdminOnly,
+	pb.OrderService_ListTaxRules_FullMethodName:            auth.AdminOnly,
+	pb.OrderService_DeleteTaxRule_FullMethodName:           auth.AdminOnly,
+	pb.OrderService_CreateShippingMethod_FullMethodName:    auth.AdminOnly,
+	pb.OrderService_ListShippingMethods_FullMethodName:     auth.Public,
+	pb.OrderService_SetShippingMethodActive_FullMethodName: auth.AdminOnly,
+	pb.OrderService_CreateShipment_FullMethodName:          auth.AdminOnly,
+	pb.OrderService_UpdateShipmentStatus_FullMethodName:    auth.AdminOnly,
+	pb.OrderService_RequestReturn_FullMethodName:           auth.Authenticated,
+	pb.OrderService_ListReturns_FullMethodName:             auth.AdminOnly,
+	pb.OrderService_ApproveReturn_FullMethodName:           auth.AdminOnly,
+	pb.OrderService_RejectReturn_FullMethodName:            auth.AdminOnly,
+	pb.OrderService_ReceiveReturn_FullMethodName:           auth.AdminOnly,
+	pb.OrderService_RefundOrder_FullMethodName:             auth.AdminOnly,
+	pb.OrderService_ListLedgerEntries_FullMethodName:       auth.AdminOnly,
+	pb.OrderService_GetStoreCredit_FullMethodName:          auth.Authenticated,
+	pb.OrderService_ReconcileLedger_FullMethodName:         auth.AdminOnly,
+}
+
+func ListenGRPC(s Service, tokens *auth.TokenManager, keys idempotency.Store, accountURL, catalogURL string, port int) error {
 	accountClient, err := account.NewClient(accountURL)
 	if err != nil {
 		return err
@@ -37,162 +74,1035 @@ func ListenGRPC(s Service, accountURL, catalogURL string, port int) error {
 		catalogClient.Close()
 		return err
 	}
-	serv := grpc.NewServer()
+	serv := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(tokens, policy)))
 	pb.RegisterOrderServiceServer(serv, &grpcServer{
 		UnimplementedOrderServiceServer: pb.UnimplementedOrderServiceServer{},
 		service:                         s,
 		accountClient:                   accountClient,
 		catalogClient:                   catalogClient,
+		idempotency:                     keys,
 	})
 	reflection.Register(serv)
 	return serv.Serve(lis)
 }
 
-// Handles creation of a new order
+// Handles creation of a new order.
+// A retry carrying the same idempotency key gets the order placed by the first attempt instead of a duplicate.
 func (s *grpcServer) PostOrder(ctx context.Context, r *pb.PostOrderRequest) (*pb.PostOrderResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.PostOrderResponse{}
+	err := idempotency.Do(ctx, s.idempotency, pb.OrderService_PostOrder_FullMethodName, r.IdempotencyKey, r, res, func() error {
+		order, err := s.postOrder(ctx, r)
+		if err != nil {
+			return err
+		}
+		res.Order = orderToProto(*order)
+		return nil
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return res, nil
+}
+
+func (s *grpcServer) postOrder(ctx context.Context, r *pb.PostOrderRequest) (*Order, error) {
 	// Check if account exists before creating order
-	_, err := s.accountClient.GetAccount(ctx, r.AccountId)
+	// Soft-deleted accounts are not returned by the account service, so closed accounts are rejected here too.
+	acc, err := s.accountClient.GetAccount(ctx, r.AccountId)
+	if err != nil {
+		return nil, upstreamError(err, ErrAccountNotFound)
+	}
+
+	// The order is priced in the requested currency, else the account's preferred one, else the store's default.
+	currency := r.Currency
+	if currency == "" {
+		currency = acc.PreferredCurrency
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	if currency, err = money.NormalizeCurrency(currency); err != nil {
+		return nil, ErrInvalidCurrency
+	}
+
+	// Snapshot the shipping address the order goes to (the chosen one, or the account's default)
+	shippingAddress, err := s.shippingAddress(ctx, r.AccountId, r.AddressId)
 	if err != nil {
-		log.Println("Error getting account", err)
-		return nil, errors.New("account not found")
+		return nil, err
+	}
+
+	// The shipping method is checked against the order by the service, once the order is priced.
+	var shippingMethod *ShippingMethod
+	if r.ShippingMethodId != "" {
+		if shippingMethod, err = s.service.GetShippingMethod(ctx, r.ShippingMethodId); err != nil {
+			return nil, err
+		}
 	}
 
-	// Extract product IDs from request
+	// An empty id list would make the catalog return its first page of products instead.
+	if len(r.Products) == 0 {
+		return nil, ErrEmptyOrder
+	}
+
+	// Extract the distinct product IDs from the request.
 	// The client only sends productId + quantity, but we need the full product details (name, desc, price)
 	// So we extract the list of productIds to fetch details from Catalog service
 	productIDs := []string{}
+	seen := map[string]bool{}
 	for _, p := range r.Products {
-		productIDs = append(productIDs, p.ProductId)
+		if !seen[p.ProductId] {
+			seen[p.ProductId] = true
+			productIDs = append(productIDs, p.ProductId)
+		}
 	}
 
 	// Call Catalog service to get real product details (price, name, desc)
 	// Catalog service is the source of truth for product details.
-	orderedProducts, err := s.catalogClient.GetProducts(ctx, productIDs, "", 0, 0)
+	catalogProducts, err := s.catalogClient.GetProducts(ctx, productIDs, "", 0, 0)
 	if err != nil {
-		log.Println("Error getting products", err)
-		return nil, errors.New("products not found")
+		return nil, upstreamError(err, ErrProductsNotFound)
+	}
+	known := map[string]catalog.Product{}
+	for _, p := range catalogProducts {
+		known[p.ID] = p
 	}
 
-	// Build OrderedProduct slice to pass to Order Service
-	// We use the product details from Catalog service
-	// For each product, we also need to get the correct quantity from the original request
+	// Build one OrderedProduct per request line, in request order, so the service can report problems by line.
+	// The product details from Catalog service are stored with the order lines as a snapshot.
+	// Every line naming a product the catalog doesn't know is reported at once.
 	products := []OrderedProduct{}
-	for _, p := range orderedProducts {
-		product := OrderedProduct{
+	violations := []LineViolation{}
+	for i, rp := range r.Products {
+		p, ok := known[rp.ProductId]
+		if !ok {
+			violations = append(violations, LineViolation{i, rp.ProductId, "unknown product"})
+			continue
+		}
+		products = append(products, OrderedProduct{
 			ID:          p.ID,
 			Name:        p.Name,
 			Description: p.Description,
 			Price:       p.Price,
-			Quantity:    0, // Initially 0 - we will set correct quantity next
-		}
-		// Match this product with request product to get correct quantity
-		for _, rp := range r.Products {
-			if rp.ProductId == p.ID {
-				product.Quantity = rp.Quantity
-				break
+			Quantity:    rp.Quantity,
+			TaxClass:    p.TaxClass,
+			Weight:      billableWeight(p),
+		})
+	}
+	if len(violations) > 0 {
+		return nil, &LineError{violations}
+	}
+
+	// Exchange rates are only needed when some product, or the shipping, isn't priced in the order's currency.
+	rates := []money.Rate{}
+	foreign := shippingMethod != nil && shippingMethod.Currency() != currency
+	for _, p := range products {
+		if foreign || p.Price.Currency != currency {
+			if rates, err = s.exchangeRates(ctx); err != nil {
+				return nil, err
 			}
-		}
-		// Only add products with non-zero quantity
-		if product.Quantity != 0 {
-			products = append(products, product)
+			break
 		}
 	}
 
-	// Call actual Order Service to create the order
-	// Pass in the accountId + correct list of OrderedProducts
-	order, err := s.service.PostOrder(ctx, r.AccountId, products)
+	// Call actual Order Service to create the order; it merges duplicate lines, enforces the quantity limits,
+	// converts the prices into the order's currency, applies the coupons, charges the shipping and reserves the stock of every line.
+	return s.service.PostOrder(ctx, r.AccountId, shippingAddress, shippingMethod, products, currency, rates, r.CouponCodes)
+}
+
+// Fetches the exchange-rate table from the Catalog service, which manages it.
+func (s *grpcServer) exchangeRates(ctx context.Context) ([]money.Rate, error) {
+	catalogRates, err := s.catalogClient.ListExchangeRates(ctx)
 	if err != nil {
-		log.Println("error posting order")
-		return nil, errors.New("could not post order")
+		return nil, upstreamError(err, ErrNoExchangeRate)
 	}
+	rates := []money.Rate{}
+	for _, r := range catalogRates {
+		rates = append(rates, r.Rate)
+	}
+	return rates, nil
+}
 
-	// Build the gRPC response - pb.Order
-	// We need to convert the internal Go Order model to the proto model pb.Order
-	orderProto := &pb.Order{
-		Id:         order.ID,
-		AccountId:  order.AccountID,
-		TotalPrice: order.TotalPrice,
-		Products:   []*pb.Order_OrderProduct{},
+// Fetches the order with the given id if the caller placed it (or is an admin). Orders of other accounts are
+// reported as not found, so callers can't tell which order ids exist.
+func (s *grpcServer) callerOrder(ctx context.Context, id string) (*Order, error) {
+	o, err := s.service.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	orderProto.CreatedAt, _ = order.CreatedAt.MarshalBinary() // Serialize the Go time.Time to binary (bytes) using MarshalBinary
+	if !auth.CanAccessAccount(ctx, o.AccountID) {
+		return nil, ErrNotFound
+	}
+	return o, nil
+}
 
-	// Add each product to the proto response
-	for _, p := range order.Products {
-		orderProto.Products = append(orderProto.Products, &pb.Order_OrderProduct{
-			Id:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			Quantity:    p.Quantity,
-		})
+// Fetches a single order. Only the account that placed it (or an admin) may read it.
+func (s *grpcServer) GetOrder(ctx context.Context, r *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	o, err := s.callerOrder(ctx, r.Id)
+	if err != nil {
+		return nil, toStatus(err)
 	}
-	return &pb.PostOrderResponse{
-		Order: orderProto,
-	}, nil
+	return &pb.GetOrderResponse{Order: s.decorateOrders(ctx, []Order{*o})[0]}, nil
+}
+
+// Moves an order to a new status, recording the calling admin in the status history.
+func (s *grpcServer) UpdateOrderStatus(ctx context.Context, r *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	changedBy := ""
+	if claims, ok := auth.FromContext(ctx); ok {
+		changedBy = claims.AccountID()
+	}
+	o, err := s.service.UpdateOrderStatus(ctx, r.Id, r.Status, changedBy, r.Note)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateOrderStatusResponse{Order: s.decorateOrders(ctx, []Order{*o})[0]}, nil
+}
+
+// Cancels an order on behalf of its owner (or an admin).
+func (s *grpcServer) CancelOrder(ctx context.Context, r *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	o, err := s.callerOrder(ctx, r.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	claims, _ := auth.FromContext(ctx) // always set: the policy requires a caller
+	o, err = s.service.CancelOrder(ctx, r.Id, claims.AccountID(), r.Reason)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CancelOrderResponse{Order: s.decorateOrders(ctx, []Order{*o})[0]}, nil
 }
 
 // Fetches the list of orders made by a particular account
 func (s *grpcServer) GetOrdersForAccount(ctx context.Context, r *pb.GetOrdersForAccountRequest) (*pb.GetOrdersForAccountResponse, error) {
-	// Get all orders for the given account
-	// These orders contain only Order ID, Account ID, Total price and Products - id and quanitity only (no product details like name, desc, price).
-	accountOrders, err := s.service.GetOrdersForAccount(ctx, r.AccountId)
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+
+	filter, err := filterFromProto(r.Filter)
 	if err != nil {
-		log.Println(err)
-		return nil, err
+		return nil, toStatus(err)
+	}
+	// Get one page of orders for the given account, each with its product lines.
+	accountOrders, err := s.service.GetOrdersForAccount(ctx, r.AccountId, filter, r.Skip, r.Take)
+	if err != nil {
+		return nil, toStatus(err)
 	}
-	// Build a unique list of all product IDs across all the orders.
-	// Because we want to fetch full details of a product.
+	return &pb.GetOrdersForAccountResponse{Orders: s.decorateOrders(ctx, accountOrders)}, nil
+}
+
+// Converts orders to their proto form. Every line already carries the name, description and unit price
+// it was ordered at; the Catalog service is only asked about lines whose snapshot has no name or description,
+// and if it can't answer the orders are returned as stored rather than failing the request.
+// Prices are never taken from the catalog, so past orders keep the price that was paid.
+func (s *grpcServer) decorateOrders(ctx context.Context, accountOrders []Order) []*pb.Order {
+	// Build a unique list of the product IDs that need filling in.
 	// We use a MAP here to automatically deduplicate — so we only fetch each product once even if it appears in multiple orders.
 	productIDMap := map[string]bool{}
 	for _, o := range accountOrders {
 		for _, p := range o.Products {
-			productIDMap[p.ID] = true
+			if p.Name == "" || p.Description == "" {
+				productIDMap[p.ID] = true
+			}
 		}
 	}
-	// Convert the map keys into a simple slice of product IDs, so we can send it to CatalogClient.GetProducts
 	productIDs := []string{}
 	for id := range productIDMap {
 		productIDs = append(productIDs, id)
 	}
-	// Fetch full product details (Name, Description, Price) for the given productIDs
-	products, err := s.catalogClient.GetProducts(ctx, productIDs, "", 0, 0)
-	if err != nil {
-		log.Println("Error getting account products: ", err)
-		return nil, err
+	// (an empty id list would make the catalog return its first page of products instead)
+	catalogProducts := map[string]catalog.Product{}
+	if len(productIDs) > 0 {
+		products, err := s.catalogClient.GetProducts(ctx, productIDs, "", 0, 0)
+		if err != nil {
+			log.Println("Skipping product details from catalog:", err)
+		}
+		for _, p := range products {
+			catalogProducts[p.ID] = p
+		}
 	}
-	// Construct the response orders for the gRPC response.
-	// We need to decorate each product inside each order with its full details fetched above.
 	orders := []*pb.Order{}
-	for _, o := range accountOrders { // o is each Order from the Order service.
-		// Create the base proto.Order object
-		op := &pb.Order{
-			Id:         o.ID,
-			AccountId:  o.AccountID,
-			TotalPrice: o.TotalPrice,
-			Products:   []*pb.Order_OrderProduct{},
-		}
-		op.CreatedAt, _ = o.CreatedAt.MarshalBinary()
-		// For each product in this order, find its corresponding full details and populate the proto product.
-		for _, product := range o.Products {
-			// Find the matching product from the list of full Catalog products:
-			for _, p := range products { // p is a full product returned by CatalogClient (ID, Name, Desc, Price).
-				if p.ID == product.ID {
-					product.Name = p.Name
-					product.Description = p.Description
-					product.Price = p.Price
-					break
+	for _, o := range accountOrders {
+		for i, product := range o.Products {
+			if p, ok := catalogProducts[product.ID]; ok {
+				if product.Name == "" {
+					o.Products[i].Name = p.Name
+				}
+				if product.Description == "" {
+					o.Products[i].Description = p.Description
 				}
 			}
-			// Append the fully decorated product to the proto Order.
-			op.Products = append(op.Products, &pb.Order_OrderProduct{
-				Id:          product.ID,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-				Quantity:    product.Quantity,
+		}
+		orders = append(orders, orderToProto(o))
+	}
+	return orders
+}
+
+func orderToProto(o Order) *pb.Order {
+	op := &pb.Order{
+		Id:                 o.ID,
+		AccountId:          o.AccountID,
+		Subtotal:           moneyToProto(o.Subtotal),
+		DiscountTotal:      moneyToProto(o.Discount),
+		TaxTotal:           moneyToProto(o.Tax),
+		ShippingTotal:      moneyToProto(o.Shipping),
+		TotalPrice:         moneyToProto(o.TotalPrice),
+		PricesIncludeTax:   o.PricesIncludeTax,
+		Products:           []*pb.Order_OrderProduct{},
+		ShippingAddress:    addressToProto(o.ShippingAddress),
+		ShippingMethodId:   o.ShippingMethodID,
+		ShippingMethodName: o.ShippingMethodName,
+		Shipments:          []*pb.Shipment{},
+		Returns:            []*pb.Return{},
+		Refunds:            []*pb.OrderRefund{},
+		Status:             o.Status,
+		StatusHistory:      []*pb.StatusChange{},
+	}
+	op.CreatedAt, _ = o.CreatedAt.MarshalBinary() // Serialize the Go time.Time to binary (bytes) using MarshalBinary
+	for _, c := range o.StatusHistory {
+		pc := &pb.StatusChange{
+			From:      c.From,
+			To:        c.To,
+			ChangedBy: c.ChangedBy,
+			Note:      c.Note,
+		}
+		pc.ChangedAt, _ = c.ChangedAt.MarshalBinary()
+		op.StatusHistory = append(op.StatusHistory, pc)
+	}
+	if c := o.Cancellation; c != nil {
+		op.Cancellation = &pb.Cancellation{
+			Reason:      c.Reason,
+			RefundDue:   moneyToProto(c.RefundDue),
+			CancelledBy: c.CancelledBy,
+		}
+		op.Cancellation.CancelledAt, _ = c.CancelledAt.MarshalBinary()
+	}
+	for _, r := range o.ExchangeRates {
+		op.ExchangeRates = append(op.ExchangeRates, &pb.ExchangeRate{From: r.From, To: r.To, Rate: r.Decimal()})
+	}
+	for _, sh := range o.Shipments {
+		op.Shipments = append(op.Shipments, shipmentToProto(sh))
+	}
+	for _, ret := range o.Returns {
+		op.Returns = append(op.Returns, returnToProto(ret))
+	}
+	for _, ref := range o.Refunds {
+		op.Refunds = append(op.Refunds, refundToProto(ref))
+	}
+	for _, p := range o.Products {
+		line := &pb.Order_OrderProduct{
+			Id:          p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			Price:       moneyToProto(p.Price),
+			Quantity:    p.Quantity,
+			Discounts:   []*pb.LineDiscount{},
+			TaxClass:    p.TaxClass,
+			TaxRate:     p.TaxRate.Percent(),
+			Tax:         moneyToProto(p.Tax),
+			Weight:      p.Weight,
+		}
+		for _, d := range p.Discounts {
+			line.Discounts = append(line.Discounts, &pb.LineDiscount{
+				PromotionId: d.PromotionID,
+				Code:        d.Code,
+				Description: d.Description,
+				Amount:      moneyToProto(d.Amount),
 			})
 		}
-		orders = append(orders, op)
+		op.Products = append(op.Products, line)
+	}
+	return op
+}
+
+// Fetches one page of an account's orders (cursor-based)
+func (s *grpcServer) ListOrdersForAccount(ctx context.Context, r *pb.ListOrdersForAccountRequest) (*pb.ListOrdersForAccountResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+
+	filter, err := filterFromProto(r.Filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	page, err := s.service.ListOrdersForAccount(ctx, r.AccountId, filter, r.After, r.First)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	pageOrders := []Order{}
+	for _, e := range page.Edges {
+		pageOrders = append(pageOrders, e.Order)
+	}
+	orders := s.decorateOrders(ctx, pageOrders)
+	edges := []*pb.ListOrdersForAccountResponse_Edge{}
+	for i, e := range page.Edges {
+		edges = append(edges, &pb.ListOrdersForAccountResponse_Edge{
+			Cursor: e.Cursor,
+			Order:  orders[i],
+		})
+	}
+	return &pb.ListOrdersForAccountResponse{
+		Edges:       edges,
+		HasNextPage: page.HasNextPage,
+		TotalCount:  page.TotalCount,
+	}, nil
+}
+
+func (s *grpcServer) CreatePromotion(ctx context.Context, r *pb.CreatePromotionRequest) (*pb.CreatePromotionResponse, error) {
+	p, err := promotionFromProto(r.Promotion)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	created, err := s.service.CreatePromotion(ctx, p)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreatePromotionResponse{Promotion: promotionToProto(*created)}, nil
+}
+
+func (s *grpcServer) ListPromotions(ctx context.Context, r *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error) {
+	promotions, err := s.service.ListPromotions(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.ListPromotionsResponse{Promotions: []*pb.Promotion{}}
+	for _, p := range promotions {
+		res.Promotions = append(res.Promotions, promotionToProto(p))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) SetPromotionActive(ctx context.Context, r *pb.SetPromotionActiveRequest) (*pb.SetPromotionActiveResponse, error) {
+	p, err := s.service.SetPromotionActive(ctx, r.Id, r.Active)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetPromotionActiveResponse{Promotion: promotionToProto(*p)}, nil
+}
+
+func (s *grpcServer) SetTaxRule(ctx context.Context, r *pb.SetTaxRuleRequest) (*pb.SetTaxRuleResponse, error) {
+	rule, err := s.service.SetTaxRule(ctx, r.Country, r.Region, r.TaxClass, r.Rate)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetTaxRuleResponse{Rule: taxRuleToProto(*rule)}, nil
+}
+
+func (s *grpcServer) ListTaxRules(ctx context.Context, r *pb.ListTaxRulesRequest) (*pb.ListTaxRulesResponse, error) {
+	rules, err := s.service.ListTaxRules(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.ListTaxRulesResponse{Rules: []*pb.TaxRule{}}
+	for _, rule := range rules {
+		res.Rules = append(res.Rules, taxRuleToProto(rule))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) DeleteTaxRule(ctx context.Context, r *pb.DeleteTaxRuleRequest) (*pb.DeleteTaxRuleResponse, error) {
+	if err := s.service.DeleteTaxRule(ctx, r.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteTaxRuleResponse{}, nil
+}
+
+func (s *grpcServer) CreateShippingMethod(ctx context.Context, r *pb.CreateShippingMethodRequest) (*pb.CreateShippingMethodResponse, error) {
+	if r.Method == nil {
+		return nil, toStatus(fmt.Errorf("%w: method is required", ErrInvalidShippingMethod))
+	}
+	m, err := s.service.CreateShippingMethod(ctx, shippingMethodFromProto(r.Method))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreateShippingMethodResponse{Method: shippingMethodToProto(*m)}, nil
+}
+
+// Inactive methods are only listed for admins.
+func (s *grpcServer) ListShippingMethods(ctx context.Context, r *pb.ListShippingMethodsRequest) (*pb.ListShippingMethodsResponse, error) {
+	includeInactive := false
+	if claims, ok := auth.FromContext(ctx); ok && claims.IsAdmin() {
+		includeInactive = r.IncludeInactive
+	}
+	methods, err := s.service.ListShippingMethods(ctx, r.Country, includeInactive)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.ListShippingMethodsResponse{Methods: []*pb.ShippingMethod{}}
+	for _, m := range methods {
+		res.Methods = append(res.Methods, shippingMethodToProto(m))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) SetShippingMethodActive(ctx context.Context, r *pb.SetShippingMethodActiveRequest) (*pb.SetShippingMethodActiveResponse, error) {
+	m, err := s.service.SetShippingMethodActive(ctx, r.Id, r.Active)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetShippingMethodActiveResponse{Method: shippingMethodToProto(*m)}, nil
+}
+
+// Records a shipment, with the calling admin in its history.
+func (s *grpcServer) CreateShipment(ctx context.Context, r *pb.CreateShipmentRequest) (*pb.CreateShipmentResponse, error) {
+	claims, _ := auth.FromContext(ctx) // always set: the policy requires a caller
+	sh, err := s.service.CreateShipment(ctx, r.OrderId, r.Carrier, r.TrackingNumber, claims.AccountID())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreateShipmentResponse{Shipment: shipmentToProto(*sh)}, nil
+}
+
+func (s *grpcServer) UpdateShipmentStatus(ctx context.Context, r *pb.UpdateShipmentStatusRequest) (*pb.UpdateShipmentStatusResponse, error) {
+	claims, _ := auth.FromContext(ctx) // always set: the policy requires a caller
+	sh, err := s.service.UpdateShipmentStatus(ctx, r.Id, r.Status, r.Note, claims.AccountID())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateShipmentStatusResponse{Shipment: shipmentToProto(*sh)}, nil
+}
+
+// Requests a return on behalf of the order's owner (or an admin).
+func (s *grpcServer) RequestReturn(ctx context.Context, r *pb.RequestReturnRequest) (*pb.RequestReturnResponse, error) {
+	if _, err := s.callerOrder(ctx, r.OrderId); err != nil {
+		return nil, toStatus(err)
+	}
+	lines := []ReturnLine{}
+	for _, l := range r.Lines {
+		lines = append(lines, ReturnLine{ProductID: l.ProductId, Quantity: l.Quantity})
+	}
+	claims, _ := auth.FromContext(ctx) // always set: the policy requires a caller
+	ret, err := s.service.RequestReturn(ctx, r.OrderId, lines, r.Reason, claims.AccountID())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RequestReturnResponse{Return: returnToProto(*ret)}, nil
+}
+
+func (s *grpcServer) ListReturns(ctx context.Context, r *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	returns, err := s.service.ListReturns(ctx, r.Status)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.ListReturnsResponse{Returns: []*pb.Return{}}
+	for _, ret := range returns {
+		res.Returns = append(res.Returns, returnToProto(ret))
+	}
+	return res, nil
+}
+
+// Approves a return, with the calling admin in its history.
+func (s *grpcServer) ApproveReturn(ctx context.Context, r *pb.ApproveReturnRequest) (*pb.ApproveReturnResponse, error) {
+	claims, _ := auth.FromContext(ctx) // always set: the policy requires a caller
+	ret, err := s.service.ApproveReturn(ctx, r.Id, r.Note, claims.AccountID())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ApproveReturnResponse{Return: returnToProto(*ret)}, nil
+}
+
+func (s *grpcServer) RejectReturn(ctx context.Context, r *pb.RejectReturnRequest) (*pb.RejectReturnResponse, error) {
+	claims, _ := auth.FromContext(ctx) // always set: the policy requires a caller
+	ret, err := s.service.RejectReturn(ctx, r.Id, r.Note, claims.AccountID())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RejectReturnResponse{Return: returnToProto(*ret)}, nil
+}
+
+func (s *grpcServer) ReceiveReturn(ctx context.Context, r *pb.ReceiveReturnRequest) (*pb.ReceiveReturnResponse, error) {
+	claims, _ := auth.FromContext(ctx) // always set: the policy requires a caller
+	ret, err := s.service.ReceiveReturn(ctx, r.Id, r.Restock, r.Note, claims.AccountID())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ReceiveReturnResponse{Return: returnToProto(*ret)}, nil
+}
+
+// Refunds an order, with the calling admin recorded as having given the refund.
+func (s *grpcServer) RefundOrder(ctx context.Context, r *pb.RefundOrderRequest) (*pb.RefundOrderResponse, error) {
+	lines := []RefundLine{}
+	for _, l := range r.Lines {
+		lines = append(lines, RefundLine{ProductID: l.ProductId, Quantity: l.Quantity})
+	}
+	claims, _ := auth.FromContext(ctx) // always set: the policy requires a caller
+	ref, err := s.service.RefundOrder(ctx, r.OrderId, lines, moneyFromProto(r.Amount), r.Destination, r.Reason, claims.AccountID())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RefundOrderResponse{Refund: refundToProto(*ref)}, nil
+}
+
+func (s *grpcServer) ListLedgerEntries(ctx context.Context, r *pb.ListLedgerEntriesRequest) (*pb.ListLedgerEntriesResponse, error) {
+	entries, err := s.service.ListLedgerEntries(ctx, r.OrderId, r.AccountId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.ListLedgerEntriesResponse{Entries: []*pb.LedgerEntry{}}
+	for _, e := range entries {
+		res.Entries = append(res.Entries, ledgerEntryToProto(e))
+	}
+	return res, nil
+}
+
+// Customers may read their own store credit; admins anyone's.
+func (s *grpcServer) GetStoreCredit(ctx context.Context, r *pb.GetStoreCreditRequest) (*pb.GetStoreCreditResponse, error) {
+	if err := auth.AuthorizeAccount(ctx, r.AccountId); err != nil {
+		return nil, toStatus(err)
+	}
+	credit, err := s.service.GetStoreCredit(ctx, r.AccountId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.GetStoreCreditResponse{Credit: []*pb.Money{}}
+	for _, m := range credit {
+		res.Credit = append(res.Credit, moneyToProto(m))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) ReconcileLedger(ctx context.Context, r *pb.ReconcileLedgerRequest) (*pb.ReconcileLedgerResponse, error) {
+	var from, to time.Time
+	if len(r.From) > 0 {
+		if err := from.UnmarshalBinary(r.From); err != nil {
+			return nil, toStatus(ErrInvalidFilter)
+		}
+	}
+	if len(r.To) > 0 {
+		if err := to.UnmarshalBinary(r.To); err != nil {
+			return nil, toStatus(ErrInvalidFilter)
+		}
+	}
+	rec, err := s.service.Reconcile(ctx, from, to)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.ReconcileLedgerResponse{
+		Orders:            uint32(rec.Orders),
+		Mismatches:        []*pb.LedgerMismatch{},
+		UnbalancedEntries: rec.UnbalancedEntries,
+	}
+	for _, m := range rec.Mismatches {
+		res.Mismatches = append(res.Mismatches, &pb.LedgerMismatch{
+			OrderId:  m.OrderID,
+			Account:  m.Account,
+			Expected: moneyToProto(m.Expected),
+			Actual:   moneyToProto(m.Actual),
+		})
+	}
+	return res, nil
+}
+
+// Finds the shipping address for a new order among the account's addresses.
+// An explicit addressId must name one of the account's shipping addresses; without one the default
+// shipping address is used, and an order without any shipping address gets nil.
+func (s *grpcServer) shippingAddress(ctx context.Context, accountID, addressID string) (*Address, error) {
+	addresses, err := s.accountClient.ListAddresses(ctx, accountID)
+	if err != nil {
+		return nil, upstreamError(err, ErrAccountNotFound)
+	}
+	for _, a := range addresses {
+		if a.Kind != account.AddressShipping {
+			continue
+		}
+		if (addressID != "" && a.ID == addressID) || (addressID == "" && a.IsDefault) {
+			return &Address{
+				ID:         a.ID,
+				Name:       a.Name,
+				Line1:      a.Line1,
+				Line2:      a.Line2,
+				City:       a.City,
+				Region:     a.Region,
+				PostalCode: a.PostalCode,
+				Country:    a.Country,
+			}, nil
+		}
+	}
+	if addressID != "" {
+		return nil, ErrAddressNotFound
+	}
+	return nil, nil
+}
+
+// Decodes the optional filter of a request; a missing filter or bound means "no restriction".
+func filterFromProto(f *pb.OrderFilter) (OrderFilter, error) {
+	filter := OrderFilter{}
+	if f == nil {
+		return filter, nil
+	}
+	filter.Statuses = f.Statuses
+	if len(f.CreatedAfter) > 0 {
+		if err := filter.CreatedAfter.UnmarshalBinary(f.CreatedAfter); err != nil {
+			return filter, ErrInvalidFilter
+		}
+	}
+	if len(f.CreatedBefore) > 0 {
+		if err := filter.CreatedBefore.UnmarshalBinary(f.CreatedBefore); err != nil {
+			return filter, ErrInvalidFilter
+		}
+	}
+	return filter, nil
+}
+
+func filterToProto(f OrderFilter) *pb.OrderFilter {
+	p := &pb.OrderFilter{Statuses: f.Statuses}
+	if !f.CreatedAfter.IsZero() {
+		p.CreatedAfter, _ = f.CreatedAfter.MarshalBinary()
+	}
+	if !f.CreatedBefore.IsZero() {
+		p.CreatedBefore, _ = f.CreatedBefore.MarshalBinary()
+	}
+	return p
+}
+
+// Unset amounts and times are sent as nil and empty bytes.
+func promotionToProto(p Promotion) *pb.Promotion {
+	pp := &pb.Promotion{
+		Id:                p.ID,
+		Code:              p.Code,
+		Description:       p.Description,
+		Kind:              p.Kind,
+		PercentOff:        p.PercentOff,
+		BuyQuantity:       p.BuyQuantity,
+		GetQuantity:       p.GetQuantity,
+		ProductIds:        p.ProductIDs,
+		MaxUsesPerAccount: p.MaxUsesPerAccount,
+		Stackable:         p.Stackable,
+		Active:            p.Active,
+	}
+	if !p.AmountOff.IsZero() {
+		pp.AmountOff = moneyToProto(p.AmountOff)
+	}
+	if !p.MinSpend.IsZero() {
+		pp.MinSpend = moneyToProto(p.MinSpend)
+	}
+	if !p.StartsAt.IsZero() {
+		pp.StartsAt, _ = p.StartsAt.MarshalBinary()
+	}
+	if !p.EndsAt.IsZero() {
+		pp.EndsAt, _ = p.EndsAt.MarshalBinary()
+	}
+	pp.CreatedAt, _ = p.CreatedAt.MarshalBinary()
+	return pp
+}
+
+func promotionFromProto(pp *pb.Promotion) (Promotion, error) {
+	if pp == nil {
+		return Promotion{}, fmt.Errorf("%w: promotion is required", ErrInvalidPromotion)
+	}
+	p := Promotion{
+		ID:                pp.Id,
+		Code:              pp.Code,
+		Description:       pp.Description,
+		Kind:              pp.Kind,
+		PercentOff:        pp.PercentOff,
+		BuyQuantity:       pp.BuyQuantity,
+		GetQuantity:       pp.GetQuantity,
+		ProductIDs:        pp.ProductIds,
+		MaxUsesPerAccount: pp.MaxUsesPerAccount,
+		Stackable:         pp.Stackable,
+		Active:            pp.Active,
+	}
+	if pp.AmountOff != nil {
+		p.AmountOff = moneyFromProto(pp.AmountOff)
+	}
+	if pp.MinSpend != nil {
+		p.MinSpend = moneyFromProto(pp.MinSpend)
+	}
+	var err error
+	if p.StartsAt, err = timeFromProto(pp.StartsAt); err != nil {
+		return Promotion{}, err
+	}
+	if p.EndsAt, err = timeFromProto(pp.EndsAt); err != nil {
+		return Promotion{}, err
+	}
+	if p.CreatedAt, err = timeFromProto(pp.CreatedAt); err != nil {
+		return Promotion{}, err
+	}
+	return p, nil
+}
+
+// Decodes an optional time of a promotion; empty bytes mean the zero time.
+func timeFromProto(b []byte) (time.Time, error) {
+	t := time.Time{}
+	if len(b) > 0 {
+		if err := t.UnmarshalBinary(b); err != nil {
+			return t, fmt.Errorf("%w: invalid time", ErrInvalidPromotion)
+		}
+	}
+	return t, nil
+}
+
+func taxRuleToProto(r TaxRule) *pb.TaxRule {
+	pr := &pb.TaxRule{
+		Id:       r.ID,
+		Country:  r.Country,
+		Region:   r.Region,
+		TaxClass: r.TaxClass,
+		Rate:     r.Rate.Percent(),
+	}
+	pr.UpdatedAt, _ = r.UpdatedAt.MarshalBinary()
+	return pr
+}
+
+// Amounts a method's kind doesn't use are sent as nil.
+func shippingMethodToProto(m ShippingMethod) *pb.ShippingMethod {
+	pm := &pb.ShippingMethod{
+		Id:          m.ID,
+		Name:        m.Name,
+		Carrier:     m.Carrier,
+		Kind:        m.Kind,
+		WeightRates: []*pb.ShippingMethod_WeightRate{},
+		Countries:   m.Countries,
+		Active:      m.Active,
+	}
+	if m.Kind != ShippingWeightBased {
+		pm.Price = moneyToProto(m.Price)
+	}
+	if m.Kind == ShippingFreeOverThreshold {
+		pm.FreeOver = moneyToProto(m.FreeOver)
+	}
+	for _, r := range m.WeightRates {
+		pm.WeightRates = append(pm.WeightRates, &pb.ShippingMethod_WeightRate{MaxWeight: r.MaxWeight, Price: moneyToProto(r.Price)})
+	}
+	pm.CreatedAt, _ = m.CreatedAt.MarshalBinary()
+	return pm
+}
+
+func shippingMethodFromProto(pm *pb.ShippingMethod) ShippingMethod {
+	m := ShippingMethod{
+		ID:          pm.Id,
+		Name:        pm.Name,
+		Carrier:     pm.Carrier,
+		Kind:        pm.Kind,
+		WeightRates: []WeightRate{},
+		Countries:   pm.Countries,
+		Active:      pm.Active,
+	}
+	if pm.Price != nil {
+		m.Price = moneyFromProto(pm.Price)
+	}
+	if pm.FreeOver != nil {
+		m.FreeOver = moneyFromProto(pm.FreeOver)
+	}
+	for _, r := range pm.WeightRates {
+		m.WeightRates = append(m.WeightRates, WeightRate{MaxWeight: r.MaxWeight, Price: moneyFromProto(r.Price)})
+	}
+	m.CreatedAt.UnmarshalBinary(pm.CreatedAt)
+	return m
+}
+
+func shipmentToProto(sh Shipment) *pb.Shipment {
+	ps := &pb.Shipment{
+		Id:             sh.ID,
+		OrderId:        sh.OrderID,
+		Carrier:        sh.Carrier,
+		TrackingNumber: sh.TrackingNumber,
+		Status:         sh.Status,
+		History:        []*pb.ShipmentEvent{},
+	}
+	for _, ev := range sh.History {
+		pe := &pb.ShipmentEvent{Status: ev.Status, Note: ev.Note, ChangedBy: ev.ChangedBy}
+		pe.OccurredAt, _ = ev.OccurredAt.MarshalBinary()
+		ps.History = append(ps.History, pe)
+	}
+	ps.CreatedAt, _ = sh.CreatedAt.MarshalBinary()
+	ps.UpdatedAt, _ = sh.UpdatedAt.MarshalBinary()
+	return ps
+}
+
+func shipmentFromProto(ps *pb.Shipment) Shipment {
+	sh := Shipment{
+		ID:             ps.Id,
+		OrderID:        ps.OrderId,
+		Carrier:        ps.Carrier,
+		TrackingNumber: ps.TrackingNumber,
+		Status:         ps.Status,
+		History:        []ShipmentEvent{},
+	}
+	for _, pe := range ps.History {
+		ev := ShipmentEvent{Status: pe.Status, Note: pe.Note, ChangedBy: pe.ChangedBy}
+		ev.OccurredAt.UnmarshalBinary(pe.OccurredAt)
+		sh.History = append(sh.History, ev)
+	}
+	sh.CreatedAt.UnmarshalBinary(ps.CreatedAt)
+	sh.UpdatedAt.UnmarshalBinary(ps.UpdatedAt)
+	return sh
+}
+
+// A return that wasn't refunded yet is sent with empty refundedAt.
+func returnToProto(ret Return) *pb.Return {
+	pr := &pb.Return{
+		Id:        ret.ID,
+		OrderId:   ret.OrderID,
+		AccountId: ret.AccountID,
+		Status:    ret.Status,
+		Reason:    ret.Reason,
+		Lines:     []*pb.Return_Line{},
+		Refund:    moneyToProto(ret.Refund),
+		Restocked: ret.Restocked,
+		History:   []*pb.ReturnEvent{},
+	}
+	for _, l := range ret.Lines {
+		pr.Lines = append(pr.Lines, &pb.Return_Line{ProductId: l.ProductID, Quantity: l.Quantity, Refund: moneyToProto(l.Refund)})
+	}
+	for _, ev := range ret.History {
+		pe := &pb.ReturnEvent{Status: ev.Status, Note: ev.Note, ChangedBy: ev.ChangedBy}
+		pe.OccurredAt, _ = ev.OccurredAt.MarshalBinary()
+		pr.History = append(pr.History, pe)
+	}
+	if !ret.RefundedAt.IsZero() {
+		pr.RefundedAt, _ = ret.RefundedAt.MarshalBinary()
+	}
+	pr.CreatedAt, _ = ret.CreatedAt.MarshalBinary()
+	pr.UpdatedAt, _ = ret.UpdatedAt.MarshalBinary()
+	return pr
+}
+
+func returnFromProto(pr *pb.Return) Return {
+	ret := Return{
+		ID:        pr.Id,
+		OrderID:   pr.OrderId,
+		AccountID: pr.AccountId,
+		Status:    pr.Status,
+		Reason:    pr.Reason,
+		Lines:     []ReturnLine{},
+		Refund:    moneyFromProto(pr.Refund),
+		Restocked: pr.Restocked,
+		History:   []ReturnEvent{},
+	}
+	for _, l := range pr.Lines {
+		ret.Lines = append(ret.Lines, ReturnLine{ProductID: l.ProductId, Quantity: l.Quantity, Refund: moneyFromProto(l.Refund)})
+	}
+	for _, pe := range pr.History {
+		ev := ReturnEvent{Status: pe.Status, Note: pe.Note, ChangedBy: pe.ChangedBy}
+		ev.OccurredAt.UnmarshalBinary(pe.OccurredAt)
+		ret.History = append(ret.History, ev)
+	}
+	if len(pr.RefundedAt) > 0 {
+		ret.RefundedAt.UnmarshalBinary(pr.RefundedAt)
+	}
+	ret.CreatedAt.UnmarshalBinary(pr.CreatedAt)
+	ret.UpdatedAt.UnmarshalBinary(pr.UpdatedAt)
+	return ret
+}
+
+// A refund that is still pending is sent with empty completedAt.
+func refundToProto(ref OrderRefund) *pb.OrderRefund {
+	pr := &pb.OrderRefund{
+		Id:          ref.ID,
+		OrderId:     ref.OrderID,
+		ReturnId:    ref.ReturnID,
+		Amount:      moneyToProto(ref.Amount),
+		Destination: ref.Destination,
+		Reason:      ref.Reason,
+		Lines:       []*pb.OrderRefund_Line{},
+		RefundedBy:  ref.RefundedBy,
+	}
+	for _, l := range ref.Lines {
+		pr.Lines = append(pr.Lines, &pb.OrderRefund_Line{ProductId: l.ProductID, Quantity: l.Quantity, Amount: moneyToProto(l.Amount)})
+	}
+	pr.CreatedAt, _ = ref.CreatedAt.MarshalBinary()
+	if !ref.CompletedAt.IsZero() {
+		pr.CompletedAt, _ = ref.CompletedAt.MarshalBinary()
+	}
+	return pr
+}
+
+func refundFromProto(pr *pb.OrderRefund) OrderRefund {
+	ref := OrderRefund{
+		ID:          pr.Id,
+		OrderID:     pr.OrderId,
+		ReturnID:    pr.ReturnId,
+		Amount:      moneyFromProto(pr.Amount),
+		Destination: pr.Destination,
+		Reason:      pr.Reason,
+		Lines:       []RefundLine{},
+		RefundedBy:  pr.RefundedBy,
+	}
+	for _, l := range pr.Lines {
+		ref.Lines = append(ref.Lines, RefundLine{ProductID: l.ProductId, Quantity: l.Quantity, Amount: moneyFromProto(l.Amount)})
+	}
+	ref.CreatedAt.UnmarshalBinary(pr.CreatedAt)
+	if len(pr.CompletedAt) > 0 {
+		ref.CompletedAt.UnmarshalBinary(pr.CompletedAt)
+	}
+	return ref
+}
+
+func ledgerEntryToProto(e LedgerEntry) *pb.LedgerEntry {
+	pe := &pb.LedgerEntry{
+		Id:          e.ID,
+		Kind:        e.Kind,
+		OrderId:     e.OrderID,
+		AccountId:   e.AccountID,
+		RefundId:    e.RefundID,
+		Description: e.Description,
+		Postings:    []*pb.LedgerEntry_Posting{},
+	}
+	for _, p := range e.Postings {
+		pe.Postings = append(pe.Postings, &pb.LedgerEntry_Posting{Account: p.Account, Amount: moneyToProto(p.Amount)})
+	}
+	pe.CreatedAt, _ = e.CreatedAt.MarshalBinary()
+	return pe
+}
+
+func ledgerEntryFromProto(pe *pb.LedgerEntry) LedgerEntry {
+	e := LedgerEntry{
+		ID:          pe.Id,
+		Kind:        pe.Kind,
+		OrderID:     pe.OrderId,
+		AccountID:   pe.AccountId,
+		RefundID:    pe.RefundId,
+		Description: pe.Description,
+		Postings:    []Posting{},
+	}
+	for _, p := range pe.Postings {
+		e.Postings = append(e.Postings, Posting{Account: p.Account, Amount: moneyFromProto(p.Amount)})
+	}
+	e.CreatedAt.UnmarshalBinary(pe.CreatedAt)
+	return e
+}
+
+func addressToProto(a *Address) *pb.Address {
+	if a == nil {
+		return nil
+	}
+	return &pb.Address{
+		Id:         a.ID,
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func addressFromProto(a *pb.Address) *Address {
+	if a == nil {
+		return nil
+	}
+	return &Address{
+		ID:         a.Id,
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func moneyToProto(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func moneyFromProto(m *pb.Money) money.Money {
+	if m == nil {
+		return money.Zero(money.DefaultCurrency)
 	}
-	return &pb.GetOrdersForAccountResponse{Orders: orders}, nil
+	return money.New(m.Amount, m.Currency)
 }
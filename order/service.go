@@ -3,59 +3,492 @@ package order
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"Microservices-based-E-commerce-System/money"
+
 	"github.com/segmentio/ksuid"
 )
 
 type Service interface {
-	PostOrder(ctx context.Context, accountID string, products []OrderedProduct) (*Order, error)
-	GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error)
+	PostOrder(ctx context.Context, accountID string, shippingAddress *Address, shippingMethod *ShippingMethod, products []OrderedProduct, currency string, rates []money.Rate, couponCodes []string) (*Order, error)
+	GetOrder(ctx context.Context, id string) (*Order, error)
+	GetOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter, skip uint64, take uint64) ([]Order, error)
+	ListOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter, after string, first uint64) (*OrderPage, error)
+	UpdateOrderStatus(ctx context.Context, id string, status string, changedBy string, note string) (*Order, error)
+	CancelOrder(ctx context.Context, id string, cancelledBy string, reason string) (*Order, error)
+	ResumeSagas(ctx context.Context) (int, error)
+	CreatePromotion(ctx context.Context, p Promotion) (*Promotion, error)
+	ListPromotions(ctx context.Context) ([]Promotion, error)
+	SetPromotionActive(ctx context.Context, id string, active bool) (*Promotion, error)
+	SetTaxRule(ctx context.Context, country, region, taxClass, rate string) (*TaxRule, error)
+	ListTaxRules(ctx context.Context) ([]TaxRule, error)
+	DeleteTaxRule(ctx context.Context, id string) error
+	CreateShippingMethod(ctx context.Context, m ShippingMethod) (*ShippingMethod, error)
+	GetShippingMethod(ctx context.Context, id string) (*ShippingMethod, error)
+	ListShippingMethods(ctx context.Context, country string, includeInactive bool) ([]ShippingMethod, error)
+	SetShippingMethodActive(ctx context.Context, id string, active bool) (*ShippingMethod, error)
+	CreateShipment(ctx context.Context, orderID, carrier, trackingNumber, createdBy string) (*Shipment, error)
+	UpdateShipmentStatus(ctx context.Context, id, status, note, changedBy string) (*Shipment, error)
+	RequestReturn(ctx context.Context, orderID string, lines []ReturnLine, reason, requestedBy string) (*Return, error)
+	GetReturn(ctx context.Context, id string) (*Return, error)
+	ListReturns(ctx context.Context, status string) ([]Return, error)
+	ApproveReturn(ctx context.Context, id, note, changedBy string) (*Return, error)
+	RejectReturn(ctx context.Context, id, note, changedBy string) (*Return, error)
+	ReceiveReturn(ctx context.Context, id string, restock bool, note, changedBy string) (*Return, error)
+	RefundOrder(ctx context.Context, orderID string, lines []RefundLine, amount money.Money, destination, reason, refundedBy string) (*OrderRefund, error)
+	ResumeRefunds(ctx context.Context) (int, error)
+	ListLedgerEntries(ctx context.Context, orderID, accountID string) ([]LedgerEntry, error)
+	GetStoreCredit(ctx context.Context, accountID string) ([]money.Money, error)
+	Reconcile(ctx context.Context, from, to time.Time) (*Reconciliation, error)
 }
 
 type Order struct {
-	ID         string
-	CreatedAt  time.Time
-	AccountID  string
-	TotalPrice float64
-	Products   []OrderedProduct
+	ID                 string
+	CreatedAt          time.Time
+	AccountID          string
+	Subtotal           money.Money // quantity × unit price of every line, before discounts
+	Discount           money.Money // taken off by coupons, see the lines' Discounts
+	Tax                money.Money // sum of the lines' Tax
+	Shipping           money.Money // what the shipping method charged, see shipping.go
+	TotalPrice         money.Money // Subtotal - Discount + Shipping, plus Tax unless PricesIncludeTax; every amount is in this currency
+	Products           []OrderedProduct
+	ShippingAddress    *Address
+	ShippingMethodID   string        // empty if the order was placed without a shipping method
+	ShippingMethodName string        // name of the shipping method when the order was placed
+	Shipments          []Shipment    // oldest first
+	Returns            []Return      // oldest first, see returns.go
+	Refunds            []OrderRefund // oldest first, see refund.go
+	Status             string
+	StatusHistory      []StatusChange // oldest first
+	Cancellation       *Cancellation  // nil unless the order was cancelled
+	ExchangeRates      []money.Rate   // rates used to convert catalog prices into the order's currency
+	PricesIncludeTax   bool           // the line prices already contained their tax, see tax.go
+}
+
+// Copy of the account's shipping address taken when the order is placed,
+// so later edits or deletes of the address don't change where a past order went.
+type Address struct {
+	ID         string `json:"id"` // id of the account address it was copied from
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
 }
 
 type OrderedProduct struct {
 	ID          string
 	Name        string
 	Description string
-	Price       float64
+	Price       money.Money // unit price
 	Quantity    uint32
+	Discounts   []LineDiscount // in the order the coupons were applied
+	TaxClass    string         // the product's tax class when it was ordered
+	TaxRate     TaxRate        // 0 if no tax rule applied
+	Tax         money.Money    // on the line after its discounts
+	Weight      uint32         // billable grams per unit, see billableWeight
+}
+
+// One page of orders returned by ListOrdersForAccount.
+type OrderPage struct {
+	Edges       []OrderEdge
+	HasNextPage bool
+	TotalCount  uint64
+}
+
+// An order together with the opaque cursor pointing at it.
+type OrderEdge struct {
+	Cursor string
+	Order  Order
+}
+
+// Narrows down an account's order history. Zero values mean "no restriction".
+type OrderFilter struct {
+	CreatedAfter  time.Time // inclusive
+	CreatedBefore time.Time // exclusive
+	Statuses      []string  // any of these statuses
+}
+
+// Position of an order in the (created_at, id) ordering used for paging.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        string
 }
 
 type orderService struct {
-	repository Repository
+	repository       Repository
+	limits           Limits
+	pricesIncludeTax bool          // catalog prices contain tax, see tax.go
+	returnWindow     time.Duration // how long after delivery orders can be returned
+	inventory        Inventory
+	payments         Payments // nil if orders are paid separately
 }
 
-func NewService(r Repository) Service {
-	return &orderService{r}
+// payments may be nil: orders are then placed as pending and paid through the payment service afterwards,
+// approved returns are refunded by staff, and refunds can only go to store credit.
+func NewService(r Repository, limits Limits, pricesIncludeTax bool, returnWindow time.Duration, inventory Inventory, payments Payments) Service {
+	return &orderService{r, limits, pricesIncludeTax, returnWindow, inventory, payments}
 }
 
-func (s orderService) PostOrder(ctx context.Context, accountID string, products []OrderedProduct) (*Order, error) {
+// Places an order. Lines for the same product are merged; empty orders, zero quantities and
+// quantities over the configured limits are rejected (see normalizeLines).
+// Unit prices in other currencies are converted into currency with rates, and the rates used are kept on the order.
+// The coupons are checked and applied to the lines (see promotion.go); one that can't be used fails the order.
+// The discounted lines are then taxed by the rules for the shipping address (see tax.go), and shipping is charged
+// by the shipping method, if any (see shipping.go).
+// The order is placed by the checkout saga (see saga.go): it is only written once stock for every line is reserved
+// and, with a payment service, its total authorized; lines short of stock are reported in a *LineError.
+func (s orderService) PostOrder(ctx context.Context, accountID string, shippingAddress *Address, shippingMethod *ShippingMethod, products []OrderedProduct, currency string, rates []money.Rate, couponCodes []string) (*Order, error) {
+	requested := products
+	products, err := normalizeLines(products, s.limits)
+	if err != nil {
+		return nil, err
+	}
+	products, usedRates, err := convertLines(products, currency, rates)
+	if err != nil {
+		return nil, err
+	}
 	o := &Order{
-		ID:        ksuid.New().String(),
-		CreatedAt: time.Now().UTC(),
-		AccountID: accountID,
-		Products:  products,
+		ID:              ksuid.New().String(),
+		CreatedAt:       time.Now().UTC(),
+		AccountID:       accountID,
+		Products:        products,
+		ShippingAddress: shippingAddress,
+		Status:          StatusPending,
+		ExchangeRates:   usedRates,
 	}
+	if o.Subtotal, err = orderTotal(products); err != nil {
+		return nil, err
+	}
+	promotions, err := s.redeemableCoupons(ctx, accountID, couponCodes, o.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if o.Products, err = applyPromotions(products, o.Subtotal, promotions); err != nil {
+		return nil, err
+	}
+	o.Discount = discountTotal(o.Products, o.Subtotal.Currency)
+	rules := []TaxRule{}
+	if shippingAddress != nil {
+		if rules, err = s.repository.FindTaxRules(ctx, shippingAddress.Country); err != nil {
+			return nil, err
+		}
+	}
+	o.Products = applyTaxes(o.Products, shippingAddress, rules, s.pricesIncludeTax)
+	o.Tax = taxTotal(o.Products, o.Subtotal.Currency)
+	o.PricesIncludeTax = s.pricesIncludeTax
+	o.Shipping = money.Zero(o.Subtotal.Currency)
+	if shippingMethod != nil {
+		o.ShippingMethodID, o.ShippingMethodName = shippingMethod.ID, shippingMethod.Name
+		goods, err := o.Subtotal.Sub(o.Discount)
+		if err != nil {
+			return nil, err
+		}
+		var rate *money.Rate
+		if o.Shipping, rate, err = shippingCost(*shippingMethod, shippingAddress, o.Products, goods, rates); err != nil {
+			return nil, err
+		}
+		if rate != nil {
+			if _, err := money.FindRate(o.ExchangeRates, rate.From, rate.To); err != nil {
+				o.ExchangeRates = append(o.ExchangeRates, *rate)
+			}
+		}
+	}
+	if o.TotalPrice, err = o.Subtotal.Sub(o.Discount); err != nil {
+		return nil, err
+	}
+	if o.TotalPrice, err = o.TotalPrice.Add(o.Shipping); err != nil {
+		return nil, err
+	}
+	if !o.PricesIncludeTax {
+		if o.TotalPrice, err = o.TotalPrice.Add(o.Tax); err != nil {
+			return nil, err
+		}
+	}
+	saga := &Saga{
+		ID:        o.ID,
+		State:     SagaRunning,
+		Order:     *o,
+		Steps:     []string{},
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.CreatedAt,
+	}
+	if err := s.repository.CreateSaga(ctx, *saga, time.Now().UTC().Add(sagaLease)); err != nil {
+		return nil, err
+	}
+	// The saga goes on if the caller gives up, so it isn't cut off halfway through undoing its steps.
+	sagaCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sagaTimeout)
+	defer cancel()
+	err = s.runSaga(sagaCtx, saga)
+	var stockErr *StockError
+	if errors.As(err, &stockErr) {
+		return nil, outOfStockLines(requested, stockErr)
+	}
+	if err != nil && (saga.State == SagaCompensating || saga.State == SagaAborted) {
+		return nil, err
+	}
+	if err != nil {
+		// The saga stopped short but wasn't undone, so ResumeSagas will place the order: the caller gets it
+		// rather than an error that would have them place it again.
+		log.Println(err)
+	}
+	placed, err := s.repository.GetOrder(context.WithoutCancel(ctx), o.ID)
+	if err != nil {
+		log.Printf("reading back order %s: %v", o.ID, err)
+		return &saga.Order, nil
+	}
+	return placed, nil
+}
 
-	o.TotalPrice = 0.0
-	for _, p := range products {
-		o.TotalPrice += p.Price * float64(p.Quantity)
+// Reports every product short of stock at the first request line naming it.
+func outOfStockLines(requested []OrderedProduct, e *StockError) error {
+	short := map[string]bool{}
+	for _, id := range e.ProductIDs {
+		short[id] = true
+	}
+	violations := []LineViolation{}
+	for i, p := range requested {
+		if short[p.ID] {
+			violations = append(violations, LineViolation{i, p.ID, "insufficient stock"})
+			delete(short, p.ID)
+		}
 	}
-	err := s.repository.PutOrder(ctx, *o)
+	return &LineError{violations}
+}
+
+func (s orderService) GetOrder(ctx context.Context, id string) (*Order, error) {
+	return s.repository.GetOrder(ctx, id)
+}
+
+// Moves an order to a new status if the lifecycle allows it (see CanTransition) and records the change in its history.
+// Orders are cancelled with CancelOrder instead, which writes the cancellation record together with the status.
+// changedBy is the account id of the caller, note an optional free-text reason.
+func (s orderService) UpdateOrderStatus(ctx context.Context, id string, status string, changedBy string, note string) (*Order, error) {
+	if !ValidStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+	if status == StatusCancelled {
+		return nil, fmt.Errorf("%w: orders are cancelled with CancelOrder", ErrInvalidTransition)
+	}
+	o, err := s.repository.GetOrder(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return o, nil
+	if !CanTransition(o.Status, status) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, o.Status, status)
+	}
+	change := StatusChange{
+		From:      o.Status,
+		To:        status,
+		ChangedAt: time.Now().UTC(),
+		ChangedBy: changedBy,
+		Note:      note,
+	}
+	if err := s.repository.UpdateOrderStatus(ctx, id, change); err != nil {
+		return nil, err
+	}
+	return s.repository.GetOrder(ctx, id)
+}
+
+// Cancels an order that hasn't shipped yet. If it was already paid, what is left of its total after its refunds is
+// recorded as due and, with a payment service, refunded to the payment like any other refund (see refund.go).
+// The status change and the cancellation record are written together.
+// A pending order whose checkout saga hasn't finished can't be cancelled yet (ErrCheckoutInProgress): the saga
+// would still capture its payment, leaving money taken for a cancelled order with no refund recorded as due.
+// One whose saga was given up on has its payment settled first, see settleFailedCheckout.
+func (s orderService) CancelOrder(ctx context.Context, id string, cancelledBy string, reason string) (*Order, error) {
+	o, err := s.repository.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status == StatusCancelled {
+		return nil, ErrAlreadyCancelled
+	}
+	if o.Status == StatusPending {
+		saga, err := s.repository.GetSaga(ctx, id)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		if err == nil && saga.unfinished() {
+			return nil, ErrCheckoutInProgress
+		}
+		if err == nil && saga.State == SagaFailed {
+			if o, err = s.settleFailedCheckout(ctx, saga); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !CanTransition(o.Status, StatusCancelled) {
+		return nil, fmt.Errorf("%w: order is %s", ErrNotCancellable, o.Status)
+	}
+	now := time.Now().UTC()
+	reason = strings.TrimSpace(reason)
+	c := Cancellation{
+		Reason:      reason,
+		CancelledAt: now,
+		CancelledBy: cancelledBy,
+	}
+	c.RefundDue = refundable(*o)
+	change := StatusChange{
+		From:      o.Status,
+		To:        StatusCancelled,
+		ChangedAt: now,
+		ChangedBy: cancelledBy,
+		Note:      reason,
+	}
+	if err := s.repository.CancelOrder(ctx, id, change, c); err != nil {
+		return nil, err
+	}
+	if c.RefundDue.IsPositive() && s.payments != nil {
+		ref := OrderRefund{
+			ID:          ksuid.New().String(),
+			OrderID:     id,
+			Amount:      c.RefundDue,
+			Destination: RefundToPayment,
+			Reason:      strings.TrimSuffix("order cancelled: "+reason, ": "),
+			Lines:       []RefundLine{},
+			RefundedBy:  cancelledBy,
+			CreatedAt:   now,
+		}
+		// The order is cancelled either way; a refund left pending is resumed by ResumeRefunds.
+		if err := s.refund(ctx, o, &ref); err != nil {
+			log.Printf("refunding cancelled order %s: %v", id, err)
+		}
+	}
+	return s.repository.GetOrder(ctx, id)
+}
+
+// Offset-based paging over an account's orders, newest first.
+func (s orderService) GetOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter, skip uint64, take uint64) ([]Order, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+	if take > 100 || (skip == 0 && take == 0) {
+		take = 100
+	}
+	return s.repository.GetOrdersForAccount(ctx, accountID, filter, skip, take)
+}
+
+// Cursor-based paging over an account's orders, newest first.
+// The total count respects the filter, so it describes the filtered result set.
+func (s orderService) ListOrdersForAccount(ctx context.Context, accountID string, filter OrderFilter, after string, first uint64) (*OrderPage, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+	if first > 100 || first == 0 {
+		first = 100
+	}
+	var cursor *OrderCursor
+	if after != "" {
+		c, err := decodeOrderCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		cursor = c
+	}
+	// One extra order tells us whether there is a next page.
+	orders, err := s.repository.ListOrdersForAccountAfter(ctx, accountID, filter, cursor, first+1)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repository.CountOrdersForAccount(ctx, accountID, filter)
+	if err != nil {
+		return nil, err
+	}
+	page := &OrderPage{
+		Edges:       []OrderEdge{},
+		HasNextPage: uint64(len(orders)) > first,
+		TotalCount:  total,
+	}
+	for i, o := range orders {
+		if uint64(i) == first {
+			break
+		}
+		page.Edges = append(page.Edges, OrderEdge{
+			Cursor: encodeOrderCursor(OrderCursor{o.CreatedAt, o.ID}),
+			Order:  o,
+		})
+	}
+	return page, nil
+}
+
+func validateFilter(f OrderFilter) error {
+	for _, status := range f.Statuses {
+		if !ValidStatus(status) {
+			return ErrInvalidStatus
+		}
+	}
+	return nil
+}
+
+// Cursors are "<created_at>,<id>" base64-encoded, so clients treat them as opaque strings.
+func encodeOrderCursor(c OrderCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + c.ID))
+}
+
+func decodeOrderCursor(cursor string) (*OrderCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(b), ",")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &OrderCursor{t, id}, nil
 }
 
-func (s orderService) GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error) {
-	return s.repository.GetOrdersForAccount(ctx, accountID)
+// Converts the unit price of every line into currency. Each rate that was needed is returned once.
+func convertLines(products []OrderedProduct, currency string, rates []money.Rate) ([]OrderedProduct, []money.Rate, error) {
+	converted := []OrderedProduct{}
+	used := []money.Rate{}
+	for _, p := range products {
+		if p.Price.Currency != currency {
+			rate, err := money.FindRate(rates, p.Price.Currency, currency)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: %v", ErrNoExchangeRate, err)
+			}
+			if p.Price, err = rate.Convert(p.Price); err != nil {
+				return nil, nil, err
+			}
+			if _, err := money.FindRate(used, rate.From, rate.To); err != nil {
+				used = append(used, rate)
+			}
+		}
+		converted = append(converted, p)
+	}
+	return converted, used, nil
+}
+
+// Adds up quantity × unit price of every line in integer minor units, so the total is exact.
+// All lines must be priced in the same currency.
+func orderTotal(products []OrderedProduct) (money.Money, error) {
+	if len(products) == 0 {
+		return money.Zero(money.DefaultCurrency), nil
+	}
+	total := money.Zero(products[0].Price.Currency)
+	for _, p := range products {
+		line, err := p.Price.Mul(int64(p.Quantity))
+		if err != nil {
+			return money.Money{}, err
+		}
+		if total, err = total.Add(line); errors.Is(err, money.ErrCurrencyMismatch) {
+			return money.Money{}, fmt.Errorf("%w: %v", ErrMixedCurrencies, err)
+		} else if err != nil {
+			return money.Money{}, err
+		}
+	}
+	return total, nil
 }
@@ -0,0 +1,413 @@
+// Shipping methods and shipments. A customer picks a shipping method for a new order; its rate table prices the
+// shipping, which is added to the order's total (it isn't taxed):
+//
+//	subtotal - discounts (+ tax) + shipping = total
+//
+// Weight-based rates go by the order's billable weight: for every line, the actual or the volumetric weight of
+// a packed unit, whichever is higher, times the quantity. Once an order is fulfilled, admins record the shipments
+// it goes out in and update their status as the carrier reports; the order follows its shipments to shipped and
+// delivered.
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/catalog"
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	ShippingFlat              = "flat"                // Price for every order
+	ShippingWeightBased       = "weight_based"        // Price of the first WeightRate the order's weight fits in
+	ShippingFreeOverThreshold = "free_over_threshold" // Price, or nothing once the goods reach FreeOver
+)
+
+const (
+	ShipmentLabelCreated   = "label_created"
+	ShipmentInTransit      = "in_transit"
+	ShipmentOutForDelivery = "out_for_delivery"
+	ShipmentDelivered      = "delivered"
+	ShipmentFailed         = "failed" // lost, or returned to the sender
+)
+
+// Cubic centimetres per kilogram of volumetric weight, the figure most parcel carriers use.
+const volumetricDivisor = 5000
+
+var (
+	ErrInvalidShippingMethod  = errors.New("invalid shipping method")
+	ErrShippingMethodNotFound = errors.New("shipping method not found")
+	ErrShippingUnavailable    = errors.New("shipping method cannot be used for this order")
+	ErrInvalidShipment        = errors.New("invalid shipment")
+	ErrShipmentNotFound       = errors.New("shipment not found")
+	ErrShipmentExists         = errors.New("a shipment with this carrier and tracking number already exists")
+	ErrInvalidShipmentStatus  = errors.New("unknown shipment status")
+	ErrShipmentFinal          = errors.New("shipment is already delivered or failed")
+	ErrShipmentStatusConflict = errors.New("shipment status was changed concurrently, reload and try again")
+	ErrOrderNotReadyToShip    = errors.New("only fulfilled or shipped orders can get shipments")
+)
+
+type ShippingMethod struct {
+	ID      string
+	Name    string // shown to customers, e.g. "Standard (3-5 days)"
+	Carrier string // carrier of the method's shipments unless one is given, e.g. "DHL"
+	Kind    string
+	// Every amount of a method is in the same currency; orders in other currencies are charged the converted amounts.
+	Price       money.Money  // flat and free_over_threshold methods only
+	FreeOver    money.Money  // free_over_threshold methods only: goods total after discounts from which shipping is free
+	WeightRates []WeightRate // weight_based methods only, lightest first; heavier orders can't use the method
+	Countries   []string     // ISO 3166-1 alpha-2 codes it ships to; empty means everywhere
+	Active      bool
+	CreatedAt   time.Time
+}
+
+// Price of shipping orders up to MaxWeight grams (inclusive) with a weight-based method.
+type WeightRate struct {
+	MaxWeight uint32
+	Price     money.Money
+}
+
+type Shipment struct {
+	ID             string
+	OrderID        string
+	Carrier        string
+	TrackingNumber string
+	Status         string
+	History        []ShipmentEvent // oldest first
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// One status update of a shipment.
+type ShipmentEvent struct {
+	Status     string
+	Note       string // e.g. where the carrier scanned the parcel
+	OccurredAt time.Time
+	ChangedBy  string // account id of the caller who recorded it
+}
+
+// The currency every amount of the method is in.
+func (m ShippingMethod) Currency() string {
+	if len(m.WeightRates) > 0 {
+		return m.WeightRates[0].Price.Currency
+	}
+	return m.Price.Currency
+}
+
+func (m ShippingMethod) shipsTo(country string) bool {
+	if len(m.Countries) == 0 {
+		return true
+	}
+	for _, c := range m.Countries {
+		if strings.EqualFold(c, country) {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks a shipping method before it is stored.
+func validateShippingMethod(m ShippingMethod) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s", ErrInvalidShippingMethod, reason)
+	}
+	if m.Name == "" || len(m.Name) > 128 {
+		return invalid("name must be 1 to 128 characters")
+	}
+	if len(m.Carrier) > 64 {
+		return invalid("carrier must be at most 64 characters")
+	}
+	currency := m.Currency()
+	if !money.ValidCurrency(currency) {
+		return invalid("prices must be in a valid currency")
+	}
+	switch m.Kind {
+	case ShippingFlat, ShippingFreeOverThreshold:
+		if m.Price.IsNegative() {
+			return invalid("price must not be negative")
+		}
+		if m.Kind == ShippingFreeOverThreshold && (!m.FreeOver.IsPositive() || m.FreeOver.Currency != currency) {
+			return invalid("freeOver must be a positive amount in the currency of the price")
+		}
+	case ShippingWeightBased:
+		if len(m.WeightRates) == 0 {
+			return invalid("weightRates must not be empty")
+		}
+		for i, r := range m.WeightRates {
+			if r.Price.IsNegative() || r.Price.Currency != currency {
+				return invalid("weight rates must have prices in one currency, none negative")
+			}
+			if i > 0 && r.MaxWeight <= m.WeightRates[i-1].MaxWeight {
+				return invalid("weight rates must be sorted by maxWeight, lightest first, without repeats")
+			}
+		}
+	default:
+		return invalid("unknown kind " + m.Kind)
+	}
+	for _, c := range m.Countries {
+		if len(c) != 2 {
+			return invalid("countries must be 2-letter ISO 3166-1 codes")
+		}
+	}
+	return nil
+}
+
+// Creates a shipping method; it is active right away.
+func (s orderService) CreateShippingMethod(ctx context.Context, m ShippingMethod) (*ShippingMethod, error) {
+	m.ID = ksuid.New().String()
+	m.Name = strings.TrimSpace(m.Name)
+	m.Carrier = strings.TrimSpace(m.Carrier)
+	m.Active = true
+	m.CreatedAt = time.Now().UTC()
+	countries := []string{}
+	for _, c := range m.Countries {
+		countries = append(countries, strings.ToUpper(strings.TrimSpace(c)))
+	}
+	m.Countries = countries
+	// Only the settings of the method's kind are kept.
+	if m.Kind == ShippingWeightBased {
+		m.Price = money.Money{}
+	} else {
+		m.WeightRates = []WeightRate{}
+	}
+	if m.Kind != ShippingFreeOverThreshold {
+		m.FreeOver = money.Money{}
+	}
+	if err := validateShippingMethod(m); err != nil {
+		return nil, err
+	}
+	if err := s.repository.PutShippingMethod(ctx, m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (s orderService) GetShippingMethod(ctx context.Context, id string) (*ShippingMethod, error) {
+	return s.repository.GetShippingMethod(ctx, id)
+}
+
+// The methods that ship to country (every method if country is empty), by name.
+// Inactive methods are left out unless includeInactive is set.
+func (s orderService) ListShippingMethods(ctx context.Context, country string, includeInactive bool) ([]ShippingMethod, error) {
+	all, err := s.repository.ListShippingMethods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	methods := []ShippingMethod{}
+	for _, m := range all {
+		if (m.Active || includeInactive) && (country == "" || m.shipsTo(country)) {
+			methods = append(methods, m)
+		}
+	}
+	return methods, nil
+}
+
+// Switches a shipping method on or off. Orders already placed keep their shipping cost.
+func (s orderService) SetShippingMethodActive(ctx context.Context, id string, active bool) (*ShippingMethod, error) {
+	if err := s.repository.SetShippingMethodActive(ctx, id, active); err != nil {
+		return nil, err
+	}
+	return s.repository.GetShippingMethod(ctx, id)
+}
+
+// The weight a carrier charges for one unit of p in grams: its actual weight or its volumetric weight,
+// whichever is higher.
+func billableWeight(p catalog.Product) uint32 {
+	d := p.Dimensions
+	// mm³ / 1000 = cm³, and cm³ / volumetricDivisor = kg, so mm³ / volumetricDivisor = g.
+	hi, volume := bits.Mul64(uint64(d.Length)*uint64(d.Width), uint64(d.Height))
+	if hi != 0 {
+		return ^uint32(0)
+	}
+	volumetric := volume / volumetricDivisor
+	if volumetric > uint64(p.Weight) {
+		return uint32(min(volumetric, uint64(^uint32(0))))
+	}
+	return p.Weight
+}
+
+// What shipping the order's lines (already discounted) to address with the method costs, in the order's currency.
+// Amounts in another currency are converted with rates; the rate used, if any, is returned.
+func shippingCost(m ShippingMethod, address *Address, products []OrderedProduct, goods money.Money, rates []money.Rate) (money.Money, *money.Rate, error) {
+	if !m.Active {
+		return money.Money{}, nil, fmt.Errorf("%w: %s is not available", ErrShippingUnavailable, m.Name)
+	}
+	if address == nil {
+		return money.Money{}, nil, fmt.Errorf("%w: the order has no shipping address", ErrShippingUnavailable)
+	}
+	if !m.shipsTo(address.Country) {
+		return money.Money{}, nil, fmt.Errorf("%w: %s doesn't ship to %s", ErrShippingUnavailable, m.Name, address.Country)
+	}
+	var rate *money.Rate
+	convert := func(amount money.Money) (money.Money, error) {
+		if amount.Currency == goods.Currency {
+			return amount, nil
+		}
+		r, err := money.FindRate(rates, amount.Currency, goods.Currency)
+		if err != nil {
+			return money.Money{}, fmt.Errorf("%w: %v", ErrNoExchangeRate, err)
+		}
+		rate = &r
+		return r.Convert(amount)
+	}
+	var price money.Money
+	switch m.Kind {
+	case ShippingFlat:
+		price = m.Price
+	case ShippingFreeOverThreshold:
+		freeOver, err := convert(m.FreeOver)
+		if err != nil {
+			return money.Money{}, nil, err
+		}
+		price = m.Price
+		if goods.Amount >= freeOver.Amount {
+			price = money.Zero(m.Price.Currency)
+		}
+	case ShippingWeightBased:
+		var weight uint64
+		for _, p := range products {
+			weight += uint64(p.Weight) * uint64(p.Quantity)
+		}
+		found := false
+		for _, r := range m.WeightRates {
+			if weight <= uint64(r.MaxWeight) {
+				price, found = r.Price, true
+				break
+			}
+		}
+		if !found {
+			return money.Money{}, nil, fmt.Errorf("%w: the order weighs more than %s takes", ErrShippingUnavailable, m.Name)
+		}
+	}
+	cost, err := convert(price)
+	if err != nil {
+		return money.Money{}, nil, err
+	}
+	return cost, rate, nil
+}
+
+// Records a shipment of a fulfilled (or already shipped, for orders sent in several parcels) order.
+// carrier defaults to the carrier of the order's shipping method.
+func (s orderService) CreateShipment(ctx context.Context, orderID, carrier, trackingNumber, createdBy string) (*Shipment, error) {
+	o, err := s.repository.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != StatusFulfilled && o.Status != StatusShipped {
+		return nil, fmt.Errorf("%w: order is %s", ErrOrderNotReadyToShip, o.Status)
+	}
+	carrier = strings.TrimSpace(carrier)
+	if carrier == "" && o.ShippingMethodID != "" {
+		m, err := s.repository.GetShippingMethod(ctx, o.ShippingMethodID)
+		if err != nil && !errors.Is(err, ErrShippingMethodNotFound) {
+			return nil, err
+		}
+		if m != nil {
+			carrier = m.Carrier
+		}
+	}
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if carrier == "" || trackingNumber == "" || len(carrier) > 64 || len(trackingNumber) > 128 {
+		return nil, fmt.Errorf("%w: carrier and tracking number are required", ErrInvalidShipment)
+	}
+	now := time.Now().UTC()
+	sh := Shipment{
+		ID:             ksuid.New().String(),
+		OrderID:        orderID,
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+		Status:         ShipmentLabelCreated,
+		History:        []ShipmentEvent{{Status: ShipmentLabelCreated, OccurredAt: now, ChangedBy: createdBy}},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.repository.PutShipment(ctx, sh); err != nil {
+		return nil, err
+	}
+	return &sh, nil
+}
+
+func validShipmentStatus(status string) bool {
+	switch status {
+	case ShipmentLabelCreated, ShipmentInTransit, ShipmentOutForDelivery, ShipmentDelivered, ShipmentFailed:
+		return true
+	}
+	return false
+}
+
+// Records a status reported by the carrier. Delivered and failed shipments don't change any more; other statuses
+// may follow each other in any order, as carriers report them. The order moves to shipped once one of its shipments
+// is on its way, and to delivered once every shipment that didn't fail is delivered.
+func (s orderService) UpdateShipmentStatus(ctx context.Context, id, status, note, changedBy string) (*Shipment, error) {
+	if !validShipmentStatus(status) {
+		return nil, ErrInvalidShipmentStatus
+	}
+	sh, err := s.repository.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sh.Status == ShipmentDelivered || sh.Status == ShipmentFailed {
+		return nil, fmt.Errorf("%w: %s", ErrShipmentFinal, sh.Status)
+	}
+	ev := ShipmentEvent{
+		Status:     status,
+		Note:       strings.TrimSpace(note),
+		OccurredAt: time.Now().UTC(),
+		ChangedBy:  changedBy,
+	}
+	if err := s.repository.UpdateShipmentStatus(ctx, id, sh.Status, ev); err != nil {
+		return nil, err
+	}
+	if err := s.followShipments(ctx, sh.OrderID, changedBy, "shipment "+sh.TrackingNumber+" "+status); err != nil {
+		return nil, err
+	}
+	return s.repository.GetShipment(ctx, id)
+}
+
+// Moves the order along with its shipments: fulfilled -> shipped once one is on its way, then
+// shipped -> delivered once every shipment that didn't fail is delivered.
+// A concurrent status change of the order wins; the order is left as it is.
+func (s orderService) followShipments(ctx context.Context, orderID, changedBy, note string) error {
+	o, err := s.repository.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	underway, delivered, outstanding := false, false, false
+	for _, sh := range o.Shipments {
+		switch sh.Status {
+		case ShipmentInTransit, ShipmentOutForDelivery:
+			underway, outstanding = true, true
+		case ShipmentDelivered:
+			underway, delivered = true, true
+		case ShipmentLabelCreated:
+			outstanding = true
+		}
+	}
+	next := []string{}
+	if o.Status == StatusFulfilled && underway {
+		next = append(next, StatusShipped)
+	}
+	if (o.Status == StatusFulfilled || o.Status == StatusShipped) && delivered && !outstanding {
+		next = append(next, StatusDelivered)
+	}
+	from := o.Status
+	for _, to := range next {
+		change := StatusChange{From: from, To: to, ChangedAt: time.Now().UTC(), ChangedBy: changedBy, Note: note}
+		err := s.repository.UpdateOrderStatus(ctx, orderID, change)
+		if errors.Is(err, ErrStatusConflict) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		from = to
+	}
+	return nil
+}
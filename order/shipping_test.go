@@ -0,0 +1,122 @@
+package order
+
+import (
+	"errors"
+	"testing"
+
+	"Microservices-based-E-commerce-System/catalog"
+	"Microservices-based-E-commerce-System/money"
+)
+
+func TestBillableWeight(t *testing.T) {
+	const most = ^uint32(0)
+	tests := []struct {
+		name       string
+		weight     uint32
+		dimensions catalog.Dimensions
+		want       uint32
+	}{
+		{"actual weight heavier", 2000, catalog.Dimensions{Length: 300, Width: 200, Height: 100}, 2000},
+		{"volumetric weight heavier", 500, catalog.Dimensions{Length: 300, Width: 200, Height: 100}, 1200},
+		{"volumetric rounded down", 0, catalog.Dimensions{Length: 100, Width: 100, Height: 1}, 2},
+		{"no dimensions", 750, catalog.Dimensions{}, 750},
+		{"volumetric weight past the largest weight", 0, catalog.Dimensions{Length: 1000000, Width: 1000000, Height: 1000000}, most},
+		{"volume past the largest volume", 0, catalog.Dimensions{Length: most, Width: most, Height: most}, most},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := billableWeight(catalog.Product{Weight: tt.weight, Dimensions: tt.dimensions})
+			if got != tt.want {
+				t.Errorf("got %d g, want %d g", got, tt.want)
+			}
+		})
+	}
+}
+
+// The threshold of a free-over method is compared with the goods after it is converted into the order's currency,
+// and weight-based methods charge the first bracket the lines' total weight fits in.
+func TestShippingCost(t *testing.T) {
+	eurToUSD, err := money.ParseRate("EUR", "USD", "1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oddRate, err := money.ParseRate("EUR", "USD", "1.0853")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weighing := func(weight uint32, quantity uint32) []OrderedProduct {
+		p := line("p1", 1000, quantity)
+		p.Weight = weight
+		return []OrderedProduct{p}
+	}
+	flat := ShippingMethod{Name: "flat", Kind: ShippingFlat, Price: money.New(499, "USD"), Active: true}
+	freeOver := func(currency string) ShippingMethod {
+		return ShippingMethod{
+			Name: "free over", Kind: ShippingFreeOverThreshold, Active: true,
+			Price: money.New(500, currency), FreeOver: money.New(5000, currency),
+		}
+	}
+	byWeight := ShippingMethod{
+		Name: "by weight", Kind: ShippingWeightBased, Active: true,
+		WeightRates: []WeightRate{
+			{MaxWeight: 1000, Price: money.New(300, "USD")},
+			{MaxWeight: 5000, Price: money.New(800, "USD")},
+		},
+	}
+	inactive := flat
+	inactive.Active = false
+	germanyOnly := flat
+	germanyOnly.Countries = []string{"DE"}
+	us := &Address{Country: "US"}
+
+	tests := []struct {
+		name     string
+		method   ShippingMethod
+		address  *Address
+		products []OrderedProduct
+		goods    int64 // in USD
+		rates    []money.Rate
+		want     int64 // in USD
+		wantRate *money.Rate
+		wantErr  error
+	}{
+		{name: "flat", method: flat, address: us, goods: 10000, want: 499},
+		{name: "flat in another currency", method: ShippingMethod{Kind: ShippingFlat, Price: money.New(499, "EUR"), Active: true},
+			address: us, goods: 1000, rates: []money.Rate{oddRate}, want: 542, wantRate: &oddRate},
+		{name: "under the threshold", method: freeOver("USD"), address: us, goods: 4999, want: 500},
+		{name: "at the threshold", method: freeOver("USD"), address: us, goods: 5000, want: 0},
+		{name: "under the converted threshold", method: freeOver("EUR"), address: us, goods: 5499,
+			rates: []money.Rate{eurToUSD}, want: 550, wantRate: &eurToUSD},
+		{name: "at the converted threshold", method: freeOver("EUR"), address: us, goods: 5500,
+			rates: []money.Rate{eurToUSD}, want: 0, wantRate: &eurToUSD},
+		{name: "over the threshold before conversion only", method: freeOver("EUR"), address: us, goods: 5200,
+			rates: []money.Rate{eurToUSD}, want: 550, wantRate: &eurToUSD},
+		{name: "no rate for the threshold", method: freeOver("EUR"), address: us, goods: 5500, wantErr: ErrNoExchangeRate},
+		{name: "lightest bracket", method: byWeight, address: us, products: weighing(400, 2), want: 300},
+		{name: "bracket's max weight included", method: byWeight, address: us, products: weighing(500, 2), want: 300},
+		{name: "next bracket", method: byWeight, address: us, products: weighing(1001, 1), want: 800},
+		{name: "weight of every unit", method: byWeight, address: us, products: weighing(1000, 5), want: 800},
+		{name: "heavier than the heaviest bracket", method: byWeight, address: us, products: weighing(1000, 6), wantErr: ErrShippingUnavailable},
+		{name: "inactive", method: inactive, address: us, wantErr: ErrShippingUnavailable},
+		{name: "no shipping address", method: flat, wantErr: ErrShippingUnavailable},
+		{name: "country not shipped to", method: germanyOnly, address: us, wantErr: ErrShippingUnavailable},
+		{name: "country shipped to", method: germanyOnly, address: &Address{Country: "de"}, want: 499},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rate, err := shippingCost(tt.method, tt.address, tt.products, money.New(tt.goods, "USD"), tt.rates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got != money.New(tt.want, "USD") {
+				t.Errorf("got %s, want %d minor units of USD", got, tt.want)
+			}
+			if (rate == nil) != (tt.wantRate == nil) || rate != nil && *rate != *tt.wantRate {
+				t.Errorf("got rate %v, want %v", rate, tt.wantRate)
+			}
+		})
+	}
+}
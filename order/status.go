@@ -0,0 +1,83 @@
+// Order lifecycle. An order starts as pending and moves forward one step at a time:
+//
+//	pending -> paid -> fulfilled -> shipped -> delivered
+//
+// It can be cancelled until it ships. Once it has been paid for, it becomes refunded when its completed refunds
+// come to its total; that is only ever set by recording a refund (see refund.go), never moved to by hand.
+// cancelled and refunded are final.
+
+package order
+
+import (
+	"errors"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusPaid      = "paid"
+	StatusFulfilled = "fulfilled"
+	StatusShipped   = "shipped"
+	StatusDelivered = "delivered"
+	StatusCancelled = "cancelled"
+	StatusRefunded  = "refunded"
+)
+
+var (
+	ErrInvalidStatus     = errors.New("unknown order status")
+	ErrInvalidTransition = errors.New("order cannot move to that status from its current status")
+	ErrStatusConflict    = errors.New("order status was changed concurrently, reload and try again")
+	ErrAlreadyCancelled  = errors.New("order is already cancelled")
+	ErrNotCancellable    = errors.New("order can no longer be cancelled")
+)
+
+// Allowed next statuses for each status.
+var transitions = map[string][]string{
+	StatusPending:   {StatusPaid, StatusCancelled},
+	StatusPaid:      {StatusFulfilled, StatusCancelled},
+	StatusFulfilled: {StatusShipped, StatusCancelled},
+	StatusShipped:   {StatusDelivered},
+	StatusDelivered: {},
+	StatusCancelled: {},
+	StatusRefunded:  {},
+}
+
+// Details recorded when an order is cancelled.
+type Cancellation struct {
+	Reason      string
+	RefundDue   money.Money // amount already paid that has to be refunded; zero if the order was never paid
+	CancelledAt time.Time
+	CancelledBy string
+}
+
+// One entry of an order's status history.
+type StatusChange struct {
+	From      string // empty for the entry recorded when the order is placed
+	To        string
+	ChangedAt time.Time
+	ChangedBy string // account id of the caller who made the change, empty for the system
+	Note      string
+}
+
+func ValidStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// Reports whether an order in this status has been paid for and is still open, i.e. it becomes refunded once its
+// completed refunds come to its total.
+func isPaid(status string) bool {
+	return status == StatusPaid || status == StatusFulfilled || status == StatusShipped || status == StatusDelivered
+}
+
+// Reports whether an order in status from may move to status to.
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
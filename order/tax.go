@@ -0,0 +1,163 @@
+// Tax on new orders. Tax rules set a rate per country, optionally narrowed to one region of it, and product tax class.
+// Each line is taxed by the most specific rule for the order's shipping address and the line's tax class;
+// lines without a matching rule, and orders without a shipping address, are not taxed.
+//
+// Tax is computed on what a line costs after its discounts and rounded half away from zero to the minor unit.
+// With tax-exclusive prices it is added to the order's total; with tax-inclusive prices it is already part of
+// the prices, so the total stays the same and the tax is only broken out.
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrInvalidTaxRule  = errors.New("invalid tax rule")
+	ErrTaxRuleNotFound = errors.New("tax rule not found")
+)
+
+// A tax rate in millionths, e.g. 190000 for 19%.
+type TaxRate uint32
+
+const taxRateDecimals = 4 // of the percentage
+
+// Parses a percentage with up to four decimals ("19", "8.875") exactly. Rates above 100% are rejected.
+func ParseTaxRate(percent string) (TaxRate, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(percent), ".")
+	if whole == "" || len(frac) > taxRateDecimals || strings.Trim(whole+frac, "0123456789") != "" {
+		return 0, fmt.Errorf("%w: rate must be a percentage like \"19\" or \"8.875\"", ErrInvalidTaxRule)
+	}
+	frac += strings.Repeat("0", taxRateDecimals-len(frac))
+	n, err := strconv.ParseUint(whole+frac, 10, 32)
+	if err != nil || n > 100*10000 {
+		return 0, fmt.Errorf("%w: rate must be between 0 and 100 percent", ErrInvalidTaxRule)
+	}
+	return TaxRate(n), nil
+}
+
+// The rate as a percentage without trailing zeros, e.g. "8.875".
+func (r TaxRate) Percent() string {
+	whole, frac := uint32(r)/10000, uint32(r)%10000
+	if frac == 0 {
+		return strconv.FormatUint(uint64(whole), 10)
+	}
+	return fmt.Sprintf("%d.%s", whole, strings.TrimRight(fmt.Sprintf("%04d", frac), "0"))
+}
+
+type TaxRule struct {
+	ID        string
+	Country   string // ISO 3166-1 alpha-2, upper case
+	Region    string // as on addresses, upper case; empty for the whole country
+	TaxClass  string // tax class of the products it applies to, see catalog.Product
+	Rate      TaxRate
+	UpdatedAt time.Time
+}
+
+// Sets (or replaces) the rate for one tax class in a country, or in one region of it when region isn't empty.
+func (s orderService) SetTaxRule(ctx context.Context, country, region, taxClass, rate string) (*TaxRule, error) {
+	r := TaxRule{
+		ID:        ksuid.New().String(),
+		Country:   strings.ToUpper(strings.TrimSpace(country)),
+		Region:    strings.ToUpper(strings.TrimSpace(region)),
+		TaxClass:  strings.ToLower(strings.TrimSpace(taxClass)),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if len(r.Country) != 2 {
+		return nil, fmt.Errorf("%w: country must be a 2-letter ISO 3166-1 code", ErrInvalidTaxRule)
+	}
+	if r.TaxClass == "" {
+		return nil, fmt.Errorf("%w: tax class is required", ErrInvalidTaxRule)
+	}
+	var err error
+	if r.Rate, err = ParseTaxRate(rate); err != nil {
+		return nil, err
+	}
+	return s.repository.PutTaxRule(ctx, r)
+}
+
+func (s orderService) ListTaxRules(ctx context.Context) ([]TaxRule, error) {
+	return s.repository.ListTaxRules(ctx)
+}
+
+// Orders already placed keep the tax they were charged.
+func (s orderService) DeleteTaxRule(ctx context.Context, id string) error {
+	return s.repository.DeleteTaxRule(ctx, id)
+}
+
+// The rule for taxClass in the address's region, else the one for its whole country.
+func matchTaxRule(rules []TaxRule, a *Address, taxClass string) (TaxRule, bool) {
+	var countryWide *TaxRule
+	region := strings.ToUpper(strings.TrimSpace(a.Region))
+	for i, r := range rules {
+		if r.Country != strings.ToUpper(a.Country) || r.TaxClass != taxClass {
+			continue
+		}
+		if r.Region != "" && r.Region == region {
+			return r, true
+		}
+		if r.Region == "" {
+			countryWide = &rules[i]
+		}
+	}
+	if countryWide != nil {
+		return *countryWide, true
+	}
+	return TaxRule{}, false
+}
+
+// Sets the tax rate and amount of every line of an order shipped to address, whose prices include tax or not.
+// Must run after the discounts are applied.
+func applyTaxes(products []OrderedProduct, address *Address, rules []TaxRule, pricesIncludeTax bool) []OrderedProduct {
+	lines := make([]OrderedProduct, len(products))
+	copy(lines, products)
+	for i := range lines {
+		lines[i].TaxRate = 0
+		lines[i].Tax = money.Zero(lines[i].Price.Currency)
+		if address == nil {
+			continue
+		}
+		rule, ok := matchTaxRule(rules, address, lines[i].TaxClass)
+		if !ok {
+			continue
+		}
+		// Exclusive: tax = net × rate. Inclusive: the price is net × (1 + rate), so tax = price × rate / (1 + rate).
+		den := int64(1000000)
+		if pricesIncludeTax {
+			den += int64(rule.Rate)
+		}
+		lines[i].TaxRate = rule.Rate
+		lines[i].Tax.Amount = mulDivRound(lines[i].Total().Amount, int64(rule.Rate), den)
+	}
+	return lines
+}
+
+// a × b / c rounded half away from zero, without overflowing on the way.
+func mulDivRound(a, b, c int64) int64 {
+	num := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	den := big.NewInt(c)
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	return q.Int64()
+}
+
+// Adds up the tax of the lines.
+func taxTotal(products []OrderedProduct, currency string) money.Money {
+	total := money.Zero(currency)
+	for _, p := range products {
+		total.Amount += p.Tax.Amount
+	}
+	return total
+}
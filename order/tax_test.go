@@ -0,0 +1,125 @@
+package order
+
+import (
+	"errors"
+	"testing"
+
+	"Microservices-based-E-commerce-System/money"
+)
+
+func TestParseTaxRate(t *testing.T) {
+	tests := []struct {
+		percent string
+		want    TaxRate
+		wantErr error
+	}{
+		{"19", 190000, nil},
+		{" 8.875 ", 88750, nil},
+		{"0.0001", 1, nil},
+		{"100", 1000000, nil},
+		{"100.0001", 0, ErrInvalidTaxRule},
+		{"8.87501", 0, ErrInvalidTaxRule},
+		{"-1", 0, ErrInvalidTaxRule},
+		{".5", 0, ErrInvalidTaxRule},
+		{"abc", 0, ErrInvalidTaxRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.percent, func(t *testing.T) {
+			got, err := ParseTaxRate(tt.percent)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got rate %d, want %d", got, tt.want)
+			}
+			if err == nil {
+				if again, _ := ParseTaxRate(got.Percent()); again != got {
+					t.Errorf("%q parses as %d, want it to round-trip", got.Percent(), again)
+				}
+			}
+		})
+	}
+}
+
+// The rule for the address's region wins over the one for its whole country; only the line's tax class counts.
+func TestMatchTaxRule(t *testing.T) {
+	rules := []TaxRule{
+		{ID: "us-ny", Country: "US", Region: "NY", TaxClass: "standard", Rate: 88750},
+		{ID: "de", Country: "DE", TaxClass: "standard", Rate: 190000},
+		{ID: "de-reduced", Country: "DE", TaxClass: "reduced", Rate: 70000},
+		{ID: "de-by", Country: "DE", Region: "BY", TaxClass: "reduced", Rate: 50000},
+	}
+	tests := []struct {
+		name     string
+		address  Address
+		taxClass string
+		want     string // id of the rule, empty for none
+	}{
+		{"country-wide", Address{Country: "DE", Region: "BE"}, "standard", "de"},
+		{"region over country", Address{Country: "DE", Region: "BY"}, "reduced", "de-by"},
+		{"other region falls back to the country", Address{Country: "DE", Region: "HH"}, "reduced", "de-reduced"},
+		{"address matched case-insensitively", Address{Country: "de", Region: " by "}, "reduced", "de-by"},
+		{"region without a country-wide rule", Address{Country: "US", Region: "CA"}, "standard", ""},
+		{"other tax class", Address{Country: "US", Region: "NY"}, "reduced", ""},
+		{"other country", Address{Country: "FR"}, "standard", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := matchTaxRule(rules, &tt.address, tt.taxClass)
+			if got.ID != tt.want || ok != (tt.want != "") {
+				t.Errorf("got rule %q (found %t), want %q", got.ID, ok, tt.want)
+			}
+		})
+	}
+}
+
+// Tax is taken from what a line costs after its discounts and rounded half away from zero to the minor unit;
+// with tax-inclusive prices it is the part of the price that is tax.
+func TestApplyTaxes(t *testing.T) {
+	taxed := func(price int64, quantity uint32, taxClass string) OrderedProduct {
+		p := line("p1", price, quantity)
+		p.TaxClass = taxClass
+		return p
+	}
+	discounted := taxed(1000, 1, "standard")
+	discounted.Discounts = []LineDiscount{{Code: "OFF", Amount: money.New(100, "USD")}}
+	rules := []TaxRule{
+		{Country: "US", TaxClass: "standard", Rate: 100000},
+		{Country: "US", TaxClass: "reduced", Rate: 10000},
+		{Country: "US", TaxClass: "odd", Rate: 88750},
+		{Country: "DE", TaxClass: "standard", Rate: 190000},
+	}
+	us := &Address{Country: "US"}
+	de := &Address{Country: "DE"}
+	tests := []struct {
+		name      string
+		product   OrderedProduct
+		address   *Address
+		inclusive bool
+		wantRate  TaxRate
+		wantTax   int64
+	}{
+		{"exclusive", taxed(1000, 2, "standard"), us, false, 100000, 200},
+		{"exclusive after discounts", discounted, us, false, 100000, 90},
+		{"exclusive rounded up from a half", taxed(50, 1, "reduced"), us, false, 10000, 1},
+		{"exclusive rounded down", taxed(49, 1, "reduced"), us, false, 10000, 0},
+		{"exclusive fractional rate", taxed(999, 1, "odd"), us, false, 88750, 89},
+		{"inclusive, exact", taxed(1190, 1, "standard"), de, true, 190000, 190},
+		{"inclusive rounded", taxed(1000, 1, "standard"), de, true, 190000, 160},
+		{"inclusive after discounts", discounted, us, true, 100000, 82},
+		{"no rule for the tax class", taxed(1000, 1, "luxury"), us, false, 0, 0},
+		{"no shipping address", taxed(1000, 1, "standard"), nil, false, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := []OrderedProduct{tt.product}
+			got := applyTaxes(products, tt.address, rules, tt.inclusive)[0]
+			if got.TaxRate != tt.wantRate || got.Tax != money.New(tt.wantTax, "USD") {
+				t.Errorf("got %s at rate %d, want %d minor units at rate %d", got.Tax, got.TaxRate, tt.wantTax, tt.wantRate)
+			}
+			if !products[0].Tax.IsZero() {
+				t.Error("the given line was changed")
+			}
+		})
+	}
+}
@@ -2,12 +2,276 @@ CREATE TABLE IF NOT EXISTS orders (
     id CHAR(27) PRIMARY KEY,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL,
     account_id CHAR(27) NOT NULL,
-    total_price MONEY NOT NULL
+    subtotal BIGINT NOT NULL, -- minor units; quantity × price of every line, before discounts
+    discount_total BIGINT NOT NULL DEFAULT 0, -- minor units taken off by coupons, see order_line_discounts
+    tax_total BIGINT NOT NULL DEFAULT 0, -- minor units; sum of the lines' tax
+    shipping_total BIGINT NOT NULL DEFAULT 0, -- minor units charged by the shipping method
+    -- minor units (cents) of currency; subtotal - discount_total + shipping_total (+ tax_total unless prices_include_tax)
+    total_price BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL, -- ISO 4217; every amount of the order is in this currency
+    prices_include_tax BOOLEAN NOT NULL DEFAULT FALSE, -- the line prices already contained their tax
+    shipping_address JSONB, -- copy of the account address at order time, NULL if none was given
+    shipping_method_id CHAR(27), -- NULL if the order was placed without a shipping method
+    shipping_method_name TEXT NOT NULL DEFAULT '', -- as it was when the order was placed
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    exchange_rates JSONB NOT NULL DEFAULT '[]' -- rates used to convert catalog prices into currency, see money.Rate
 );
 
 CREATE TABLE IF NOT EXISTS order_products (
     order_id CHAR(27) REFERENCES orders(id) ON DELETE CASCADE,
     product_id CHAR(27) NOT NULL,
     quantity INT NOT NULL,
+    -- product details as they were when the order was placed, so later catalog edits don't rewrite history
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    price BIGINT NOT NULL, -- unit price in minor units of the order's currency
+    tax_class VARCHAR(32) NOT NULL DEFAULT 'standard',
+    tax_rate INT NOT NULL DEFAULT 0, -- millionths, e.g. 190000 for 19%; 0 if no tax rule applied
+    tax BIGINT NOT NULL DEFAULT 0, -- minor units; on the line after its discounts
+    weight INT NOT NULL DEFAULT 0, -- billable grams per unit
     PRIMARY KEY(product_id, order_id)
-);
\ No newline at end of file
+);
+
+-- Tax rates by country (or one region of it) and product tax class, see tax.go.
+CREATE TABLE IF NOT EXISTS tax_rules (
+    id CHAR(27) PRIMARY KEY,
+    country CHAR(2) NOT NULL, -- ISO 3166-1 alpha-2
+    region VARCHAR(64) NOT NULL DEFAULT '', -- upper case; empty for the whole country
+    tax_class VARCHAR(32) NOT NULL,
+    rate INT NOT NULL, -- millionths, e.g. 190000 for 19%
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE(country, region, tax_class)
+);
+
+-- Coupon codes customers can redeem on new orders, see promotion.go.
+CREATE TABLE IF NOT EXISTS promotions (
+    id CHAR(27) PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE, -- upper case
+    description TEXT NOT NULL DEFAULT '',
+    kind VARCHAR(16) NOT NULL, -- percentage, fixed_amount or buy_x_get_y
+    percent_off INT NOT NULL DEFAULT 0,
+    amount_off BIGINT NOT NULL DEFAULT 0, -- minor units of currency
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    product_ids TEXT[] NOT NULL DEFAULT '{}', -- products the promotion applies to; empty means all
+    min_spend BIGINT NOT NULL DEFAULT 0, -- minor units of currency; 0 means no minimum
+    currency VARCHAR(3) NOT NULL DEFAULT '', -- of amount_off and min_spend, empty if neither is set
+    max_uses_per_account INT NOT NULL DEFAULT 0, -- 0 means no limit
+    starts_at TIMESTAMP WITH TIME ZONE, -- NULL means no start
+    ends_at TIMESTAMP WITH TIME ZONE, -- exclusive; NULL means no end
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- What each promotion took off an order line. The lines of an order add up to its discount_total.
+CREATE TABLE IF NOT EXISTS order_line_discounts (
+    order_id CHAR(27) NOT NULL,
+    product_id CHAR(27) NOT NULL,
+    seq SMALLINT NOT NULL, -- position among the line's discounts, in the order they were applied
+    promotion_id CHAR(27) NOT NULL REFERENCES promotions(id),
+    code VARCHAR(64) NOT NULL, -- coupon code and description as they were when the order was placed
+    description TEXT NOT NULL DEFAULT '',
+    amount BIGINT NOT NULL, -- minor units of the order's currency, off the whole line
+    PRIMARY KEY(order_id, product_id, seq),
+    FOREIGN KEY(product_id, order_id) REFERENCES order_products(product_id, order_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS order_line_discounts_promotion_id ON order_line_discounts(promotion_id);
+
+-- Shipping methods customers pick for new orders, see shipping.go.
+CREATE TABLE IF NOT EXISTS shipping_methods (
+    id CHAR(27) PRIMARY KEY,
+    name VARCHAR(128) NOT NULL,
+    carrier VARCHAR(64) NOT NULL DEFAULT '',
+    kind VARCHAR(24) NOT NULL, -- flat, weight_based or free_over_threshold
+    currency CHAR(3) NOT NULL, -- of every amount of the method
+    price BIGINT NOT NULL DEFAULT 0, -- minor units; flat and free_over_threshold
+    free_over BIGINT NOT NULL DEFAULT 0, -- minor units; free_over_threshold
+    weight_rates JSONB NOT NULL DEFAULT '[]', -- weight_based: [{"maxWeight": grams, "price": minor units}], lightest first
+    countries TEXT[] NOT NULL DEFAULT '{}', -- ISO 3166-1 alpha-2 codes it ships to; empty means everywhere
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Parcels an order goes out in.
+CREATE TABLE IF NOT EXISTS shipments (
+    id CHAR(27) PRIMARY KEY,
+    order_id CHAR(27) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    carrier VARCHAR(64) NOT NULL,
+    tracking_number VARCHAR(128) NOT NULL,
+    status VARCHAR(24) NOT NULL, -- label_created, in_transit, out_for_delivery, delivered or failed
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE(carrier, tracking_number)
+);
+
+CREATE INDEX IF NOT EXISTS shipments_order_id ON shipments(order_id);
+
+-- Append-only log of every status a shipment has been in.
+CREATE TABLE IF NOT EXISTS shipment_events (
+    id BIGSERIAL PRIMARY KEY,
+    shipment_id CHAR(27) NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    status VARCHAR(24) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    changed_by CHAR(27) -- account that recorded it
+);
+
+CREATE INDEX IF NOT EXISTS shipment_events_shipment_id ON shipment_events(shipment_id, id);
+
+-- Returns (RMAs) of delivered orders, see returns.go.
+CREATE TABLE IF NOT EXISTS returns (
+    id CHAR(27) PRIMARY KEY,
+    order_id CHAR(27) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    account_id CHAR(27) NOT NULL, -- account that placed the order
+    status VARCHAR(16) NOT NULL, -- requested, approved, rejected or received
+    reason TEXT NOT NULL DEFAULT '',
+    refund BIGINT NOT NULL, -- minor units; sum of the lines' refund
+    currency CHAR(3) NOT NULL, -- the order's
+    refunded_at TIMESTAMP WITH TIME ZONE, -- NULL until the refund went through
+    restocked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS returns_order_id ON returns(order_id);
+CREATE INDEX IF NOT EXISTS returns_status ON returns(status, created_at);
+
+CREATE TABLE IF NOT EXISTS return_lines (
+    return_id CHAR(27) NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    product_id CHAR(27) NOT NULL,
+    quantity INT NOT NULL,
+    refund BIGINT NOT NULL, -- minor units; what the customer paid for these units
+    PRIMARY KEY(return_id, product_id)
+);
+
+-- Append-only log of every status a return has been in.
+CREATE TABLE IF NOT EXISTS return_events (
+    id BIGSERIAL PRIMARY KEY,
+    return_id CHAR(27) NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    changed_by CHAR(27) -- account that made the change
+);
+
+CREATE INDEX IF NOT EXISTS return_events_return_id ON return_events(return_id, id);
+
+-- Append-only log of every status an order has been in.
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id CHAR(27) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(16), -- NULL for the entry written when the order is placed
+    to_status VARCHAR(16) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    changed_by CHAR(27), -- account that made the change, NULL for the system
+    note TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id ON order_status_history(order_id, id);
+
+-- Audit record of a cancelled order; written in the same transaction as the status change.
+CREATE TABLE IF NOT EXISTS order_cancellations (
+    order_id CHAR(27) PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    refund_due BIGINT NOT NULL, -- minor units; what was paid and has to go back to the customer
+    cancelled_by CHAR(27), -- account that cancelled the order
+    cancelled_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Money given back for an order, to the customer's payment or as store credit, see refund.go.
+CREATE TABLE IF NOT EXISTS order_refunds (
+    id CHAR(27) PRIMARY KEY,
+    order_id CHAR(27) NOT NULL REFERENCES orders(id),
+    return_id CHAR(27) REFERENCES returns(id), -- NULL unless the refund is for a return
+    amount BIGINT NOT NULL CHECK (amount >= 0), -- minor units of the order's currency
+    currency CHAR(3) NOT NULL,
+    destination VARCHAR(16) NOT NULL, -- payment or store_credit
+    reason TEXT NOT NULL DEFAULT '',
+    refunded_by CHAR(27), -- account that gave the refund
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE -- NULL while a refund to the payment waits for the payment service
+);
+
+CREATE INDEX IF NOT EXISTS order_refunds_order_id ON order_refunds(order_id, created_at);
+
+-- A return is refunded once, however often or concurrently it is approved.
+CREATE UNIQUE INDEX IF NOT EXISTS order_refunds_return_id ON order_refunds(return_id);
+
+CREATE INDEX IF NOT EXISTS order_refunds_pending ON order_refunds(created_at) WHERE completed_at IS NULL;
+
+-- Units of the order's lines a refund is for; refunds of a plain amount have none.
+CREATE TABLE IF NOT EXISTS order_refund_lines (
+    refund_id CHAR(27) NOT NULL REFERENCES order_refunds(id) ON DELETE CASCADE,
+    product_id CHAR(27) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    amount BIGINT NOT NULL, -- minor units; what the customer paid for these units
+    PRIMARY KEY(refund_id, product_id)
+);
+
+-- Double-entry ledger of the orders' money, see ledger.go. Each entry is written in the same transaction as the
+-- change it records, and the postings of an entry add up to zero.
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id CHAR(27) PRIMARY KEY,
+    kind VARCHAR(24) NOT NULL, -- order_placed, charge, order_cancelled or refund
+    order_id CHAR(27) NOT NULL REFERENCES orders(id),
+    account_id CHAR(27) NOT NULL, -- account that placed the order
+    refund_id CHAR(27) REFERENCES order_refunds(id), -- NULL unless kind is refund
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ledger_entries_order_id ON ledger_entries(order_id, created_at);
+CREATE INDEX IF NOT EXISTS ledger_entries_account_id ON ledger_entries(account_id, created_at);
+
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id CHAR(27) NOT NULL REFERENCES ledger_entries(id),
+    ledger_account VARCHAR(24) NOT NULL, -- receivable, sales, discounts, tax, shipping, payments, refunds or store_credit
+    amount BIGINT NOT NULL CHECK (amount <> 0), -- minor units; debits are positive, credits negative
+    currency CHAR(3) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ledger_postings_entry_id ON ledger_postings(entry_id);
+
+-- The ledger is append-only: mistakes are corrected by new entries, never by changing old ones.
+CREATE OR REPLACE FUNCTION ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'the ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_entries_append_only ON ledger_entries;
+CREATE TRIGGER ledger_entries_append_only BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE PROCEDURE ledger_append_only();
+
+DROP TRIGGER IF EXISTS ledger_postings_append_only ON ledger_postings;
+CREATE TRIGGER ledger_postings_append_only BEFORE UPDATE OR DELETE ON ledger_postings
+    FOR EACH ROW EXECUTE PROCEDURE ledger_append_only();
+
+-- Progress of placing each order, see saga.go. Unfinished sagas whose lease ran out are resumed by ResumeSagas.
+CREATE TABLE IF NOT EXISTS order_sagas (
+    id CHAR(27) PRIMARY KEY, -- id of the order being placed; the orders row only exists once create_order is done
+    state VARCHAR(16) NOT NULL, -- running, compensating, completed, aborted or failed
+    order_data JSONB NOT NULL, -- the order as priced when it was requested
+    steps TEXT[] NOT NULL DEFAULT '{}', -- names of the steps done, in order
+    reservation_id VARCHAR(64) NOT NULL DEFAULT '',
+    payment_id VARCHAR(64) NOT NULL DEFAULT '',
+    failure TEXT NOT NULL DEFAULT '', -- error of the step that failed last
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL, -- the process running the saga holds it until then
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_sagas_unfinished ON order_sagas(created_at) WHERE state IN ('running', 'compensating');
+
+-- Idempotency keys of create requests, see the idempotency package.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(400) PRIMARY KEY, -- "<method>|<caller>|<client key>"
+    request_hash CHAR(64) NOT NULL,
+    token CHAR(32) NOT NULL, -- identifies the attempt holding the key
+    response BYTEA, -- NULL while the first attempt is running
+    failure BYTEA, -- gRPC status of an attempt that failed after making changes that stand
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
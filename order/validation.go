@@ -0,0 +1,98 @@
+// Rules for the lines of a new order.
+
+package order
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Quantity limits enforced on every new order.
+type Limits struct {
+	MaxLineQuantity  uint32 // units of one product
+	MaxOrderQuantity uint32 // units across all lines
+}
+
+var DefaultLimits = Limits{
+	MaxLineQuantity:  100,
+	MaxOrderQuantity: 1000,
+}
+
+var (
+	ErrEmptyOrder        = errors.New("order has no products")
+	ErrInvalidOrderLines = errors.New("invalid order lines")
+)
+
+// A problem with one line of a PostOrder request.
+type LineViolation struct {
+	Index     int // position of the line in the request
+	ProductID string
+	Reason    string
+}
+
+// Lists every offending line of a request. errors.Is(err, ErrInvalidOrderLines) holds for it.
+type LineError struct {
+	Violations []LineViolation
+}
+
+func (e *LineError) Error() string {
+	reasons := []string{}
+	for _, v := range e.Violations {
+		reasons = append(reasons, fmt.Sprintf("products[%d] (%s): %s", v.Index, v.ProductID, v.Reason))
+	}
+	return ErrInvalidOrderLines.Error() + ": " + strings.Join(reasons, "; ")
+}
+
+func (e *LineError) Is(target error) bool {
+	return target == ErrInvalidOrderLines
+}
+
+// Merges lines for the same product into one (keeping the position of the first) and checks them against limits.
+// Lines are reported by their index in products, so callers should pass them in request order.
+func normalizeLines(products []OrderedProduct, limits Limits) ([]OrderedProduct, error) {
+	if len(products) == 0 {
+		return nil, ErrEmptyOrder
+	}
+	violations := []LineViolation{}
+	merged := []OrderedProduct{}
+	quantities := []uint64{} // summed in 64 bits so merged lines can't overflow
+	firstIndex := []int{}
+	position := map[string]int{} // product id -> position in merged
+	for i, p := range products {
+		if p.ID == "" {
+			violations = append(violations, LineViolation{i, p.ID, "product id is required"})
+			continue
+		}
+		if p.Quantity == 0 {
+			violations = append(violations, LineViolation{i, p.ID, "quantity must be at least 1"})
+			continue
+		}
+		if j, ok := position[p.ID]; ok {
+			quantities[j] += uint64(p.Quantity)
+			continue
+		}
+		position[p.ID] = len(merged)
+		merged = append(merged, p)
+		quantities = append(quantities, uint64(p.Quantity))
+		firstIndex = append(firstIndex, i)
+	}
+	var total uint64
+	for j := range merged {
+		if quantities[j] > uint64(limits.MaxLineQuantity) {
+			violations = append(violations, LineViolation{firstIndex[j], merged[j].ID, fmt.Sprintf("at most %d units per product", limits.MaxLineQuantity)})
+			continue
+		}
+		merged[j].Quantity = uint32(quantities[j])
+		total += quantities[j]
+	}
+	if len(violations) > 0 {
+		sort.Slice(violations, func(a, b int) bool { return violations[a].Index < violations[b].Index })
+		return nil, &LineError{violations}
+	}
+	if total > uint64(limits.MaxOrderQuantity) {
+		return nil, fmt.Errorf("%w: at most %d units per order, got %d", ErrInvalidOrderLines, limits.MaxOrderQuantity, total)
+	}
+	return merged, nil
+}
@@ -0,0 +1,96 @@
+package payment
+
+import (
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/money"
+	"Microservices-based-E-commerce-System/payment/pb"
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type Client struct {
+	conn    *grpc.ClientConn
+	service pb.PaymentServiceClient
+}
+
+func NewClient(url string) (*Client, error) {
+	conn, err := grpc.Dial(url, grpc.WithInsecure(), grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor()))
+	if err != nil {
+		return nil, err
+	}
+	c := pb.NewPaymentServiceClient(conn)
+	return &Client{conn, c}, nil
+}
+
+func (c *Client) Close() {
+	c.conn.Close()
+}
+
+// Pays the total of a pending order. idempotencyKey is optional; retries with the same key return the first payment.
+func (c *Client) PayOrder(ctx context.Context, orderID string, idempotencyKey string) (*Payment, error) {
+	r, err := c.service.PayOrder(ctx, &pb.PayOrderRequest{
+		OrderId:        orderID,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p := paymentFromProto(r.Payment)
+	return &p, nil
+}
+
+// Authorizes amount for an order without capturing it; the caller needs a service token.
+func (c *Client) AuthorizePayment(ctx context.Context, orderID, accountID string, amount money.Money, idempotencyKey string) (*Payment, error) {
+	r, err := c.service.AuthorizePayment(ctx, &pb.AuthorizePaymentRequest{
+		OrderId:        orderID,
+		AccountId:      accountID,
+		Amount:         moneyToProto(amount),
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p := paymentFromProto(r.Payment)
+	return &p, nil
+}
+
+func (c *Client) CapturePayment(ctx context.Context, id string) (*Payment, error) {
+	r, err := c.service.CapturePayment(ctx, &pb.CapturePaymentRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	p := paymentFromProto(r.Payment)
+	return &p, nil
+}
+
+func (c *Client) VoidPayment(ctx context.Context, id string) (*Payment, error) {
+	r, err := c.service.VoidPayment(ctx, &pb.VoidPaymentRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	p := paymentFromProto(r.Payment)
+	return &p, nil
+}
+
+func (c *Client) GetPayment(ctx context.Context, id string) (*Payment, error) {
+	r, err := c.service.GetPayment(ctx, &pb.GetPaymentRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	p := paymentFromProto(r.Payment)
+	return &p, nil
+}
+
+// Every payment attempt for an order, oldest first.
+func (c *Client) GetPaymentsForOrder(ctx context.Context, orderID string) ([]Payment, error) {
+	r, err := c.service.GetPaymentsForOrder(ctx, &pb.GetPaymentsForOrderRequest{OrderId: orderID})
+	if err != nil {
+		return nil, err
+	}
+	payments := []Payment{}
+	for _, p := range r.Payments {
+		payments = append(payments, paymentFromProto(p))
+	}
+	return payments, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/idempotency"
+	"Microservices-based-E-commerce-System/payment"
+	"log"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/tinrab/retry"
+)
+
+type Config struct {
+	DatabaseURL string `envconfig:"DATABASE_URL"`
+	OrderURL    string `envconfig:"ORDER_SERVICE_URL"`
+	JWTSecret   string `envconfig:"JWT_SECRET" required:"true"`
+	Provider    string `envconfig:"PAYMENT_PROVIDER" default:"fake"` // only "fake" exists so far
+}
+
+func main() {
+	var cfg Config
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var provider payment.PaymentProvider
+	switch cfg.Provider {
+	case "fake":
+		provider = payment.NewFakeProvider()
+	default:
+		log.Fatalf("unknown payment provider %q", cfg.Provider)
+	}
+	var r payment.Repository
+	retry.ForeverSleep(2*time.Millisecond, func(_ int) (err error) {
+		r, err = payment.NewPostgresRepository(cfg.DatabaseURL)
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	})
+	defer r.Close()
+	keys, err := idempotency.NewPostgresStore(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Listening on port 8080...")
+	s := payment.NewService(r, provider)
+	log.Fatal(payment.ListenGRPC(s, auth.NewTokenManager(cfg.JWTSecret), keys, cfg.OrderURL, 8080))
+}
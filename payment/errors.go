@@ -0,0 +1,102 @@
+// Domain errors of the payment service and their translation to gRPC status codes.
+
+package payment
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrNotFound        = errors.New("payment not found")
+	ErrOrderNotFound   = errors.New("order not found")
+	ErrOrderNotPayable = errors.New("only pending orders can be paid")
+	ErrAlreadyPaid     = errors.New("order already has a payment that is in progress or completed")
+	ErrInvalidAmount   = errors.New("invalid payment amount")
+	ErrInvalidState    = errors.New("payment is not in a state that allows this")
+	ErrFullyRefunded   = errors.New("payment is fully refunded")
+	ErrPaymentConflict = errors.New("payment was changed concurrently, reload and try again")
+	ErrUnavailable     = errors.New("a required service is unavailable, try again later")
+	ErrOrderNotUpdated = errors.New("payment went through but the order could not be updated, try again")
+	ErrPaymentStranded = errors.New("payment went through but neither the order could be updated nor the money given back")
+)
+
+// Converts an error returned by the service or one of its dependencies into a gRPC status error.
+// Errors that already carry a status are passed through; anything unexpected is logged and reported
+// as Internal without leaking details.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, ErrFullyRefunded):
+		return fullyRefundedStatus(err)
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidAmount), errors.Is(err, money.ErrCurrencyMismatch), errors.Is(err, money.ErrOverflow):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrOrderNotPayable), errors.Is(err, ErrAlreadyPaid), errors.Is(err, ErrInvalidState),
+		errors.Is(err, ErrDeclined):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrPaymentConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, ErrUnavailable), errors.Is(err, ErrProviderUnavailable), errors.Is(err, ErrOrderNotUpdated):
+		log.Println(err)
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, ErrPaymentStranded):
+		log.Println(err)
+		return status.Error(codes.Internal, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+		log.Println(err)
+		return status.Error(codes.Unavailable, "payment database unavailable")
+	}
+	log.Println(err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// PreconditionFailure violation type telling that a refund was refused because the payment is fully refunded
+// already, as opposed to refused for any other reason; the order service only gives up on a refund for this one.
+const fullyRefundedViolation = "FULLY_REFUNDED"
+
+// FailedPrecondition with a fullyRefundedViolation detail.
+func fullyRefundedStatus(err error) error {
+	pf := &errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+		{Type: fullyRefundedViolation, Description: err.Error()},
+	}}
+	st, detailErr := status.New(codes.FailedPrecondition, err.Error()).WithDetails(pf)
+	if detailErr != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return st.Err()
+}
+
+// Interprets an error from the order service: NotFound becomes notFound,
+// connection problems become ErrUnavailable, and anything else (e.g. PermissionDenied) is kept as is.
+func upstreamError(err error, notFound error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return notFound
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return err
+}
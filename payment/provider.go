@@ -0,0 +1,64 @@
+// The payment provider (card processor) that actually moves the money.
+
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Microservices-based-E-commerce-System/money"
+)
+
+var (
+	ErrDeclined            = errors.New("payment was declined")
+	ErrProviderUnavailable = errors.New("payment provider is unavailable, try again later")
+)
+
+// A payment provider. Authorize reserves an amount on the customer's payment method and returns the provider's
+// id for the authorization; the other methods act on that authorization. Amounts passed to Capture and Refund
+// are never larger than what was authorized or captured. Refund is the provider's idempotent call: a refund is
+// made once per reference, however often it is asked for. A declined authorization returns ErrDeclined,
+// a provider that can't be reached ErrProviderUnavailable.
+type PaymentProvider interface {
+	Name() string
+	Authorize(ctx context.Context, amount money.Money, reference string) (string, error)
+	Capture(ctx context.Context, authorizationID string, amount money.Money) error
+	Void(ctx context.Context, authorizationID string) error
+	Refund(ctx context.Context, authorizationID string, amount money.Money, reference string) error
+}
+
+// A provider for local development that moves no money. It decides by the amount alone, so runs are reproducible:
+// amounts whose minor units end in 02 (e.g. 10.02) are declined, amounts ending in 03 fail as if the provider
+// were down, and everything else succeeds. Authorization ids are derived from the reference.
+type FakeProvider struct{}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) Authorize(ctx context.Context, amount money.Money, reference string) (string, error) {
+	switch amount.Amount % 100 {
+	case 2:
+		return "", fmt.Errorf("%w: insufficient funds", ErrDeclined)
+	case 3:
+		return "", ErrProviderUnavailable
+	}
+	return "fake_auth_" + reference, nil
+}
+
+func (p *FakeProvider) Capture(ctx context.Context, authorizationID string, amount money.Money) error {
+	return nil
+}
+
+func (p *FakeProvider) Void(ctx context.Context, authorizationID string) error {
+	return nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, authorizationID string, amount money.Money, reference string) error {
+	return nil
+}
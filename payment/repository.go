@@ -0,0 +1,210 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/lib/pq"
+)
+
+type Repository interface {
+	Close()
+	CreatePayment(ctx context.Context, p Payment) error
+	UpdatePayment(ctx context.Context, p Payment, from Payment) error
+	PutRefund(ctx context.Context, p Payment, from Payment, r Refund) error
+	DeleteRefund(ctx context.Context, p Payment, from Payment, refundID string) error
+	GetPayment(ctx context.Context, id string) (*Payment, error)
+	GetPaymentsForOrder(ctx context.Context, orderID string) ([]Payment, error)
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(url string) (Repository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	return &postgresRepository{db}, nil
+}
+
+func (r *postgresRepository) Close() {
+	r.db.Close()
+}
+
+// A second live payment for the same order violates payments_one_per_order and is reported as ErrAlreadyPaid.
+func (r *postgresRepository) CreatePayment(ctx context.Context, p Payment) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO payments(id, order_id, account_id, amount, refunded, currency, status, provider,
+			authorization_id, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		p.ID, p.OrderID, p.AccountID, p.Amount.Amount, p.Refunded.Amount, p.Amount.Currency, p.Status, p.Provider,
+		p.AuthorizationID, p.FailureReason, p.CreatedAt, p.UpdatedAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+		return ErrAlreadyPaid
+	}
+	return err
+}
+
+// Writes the new state of a payment, provided it is still in the state from (same status and refunded amount);
+// otherwise ErrPaymentConflict is returned and nothing changes.
+func (r *postgresRepository) UpdatePayment(ctx context.Context, p Payment, from Payment) error {
+	return updatePayment(ctx, r.db, p, from)
+}
+
+// Records a refund together with the payment's new refunded amount and status.
+func (r *postgresRepository) PutRefund(ctx context.Context, p Payment, from Payment, refund Refund) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = updatePayment(ctx, tx, p, from); err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO payment_refunds(id, payment_id, amount, reason, created_at) VALUES ($1, $2, $3, $4, $5)",
+		refund.ID, p.ID, refund.Amount.Amount, refund.Reason, refund.CreatedAt,
+	)
+	return
+}
+
+// Undoes PutRefund: removes the refund and puts the payment back into state p.
+func (r *postgresRepository) DeleteRefund(ctx context.Context, p Payment, from Payment, refundID string) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = updatePayment(ctx, tx, p, from); err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM payment_refunds WHERE id = $1", refundID)
+	return
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func updatePayment(ctx context.Context, db execer, p Payment, from Payment) error {
+	res, err := db.ExecContext(ctx,
+		`UPDATE payments
+		SET status = $1, refunded = $2, authorization_id = $3, failure_reason = $4, updated_at = $5
+		WHERE id = $6 AND status = $7 AND refunded = $8`,
+		p.Status, p.Refunded.Amount, p.AuthorizationID, p.FailureReason, p.UpdatedAt,
+		p.ID, from.Status, from.Refunded.Amount,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrPaymentConflict
+	}
+	return nil
+}
+
+const paymentColumns = `id, order_id, account_id, amount, refunded, currency, status, provider,
+	authorization_id, failure_reason, created_at, updated_at`
+
+func (r *postgresRepository) GetPayment(ctx context.Context, id string) (*Payment, error) {
+	payments, err := r.queryPayments(ctx, "SELECT "+paymentColumns+" FROM payments WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(payments) == 0 {
+		return nil, ErrNotFound
+	}
+	return &payments[0], nil
+}
+
+// Oldest first, including declined and failed attempts.
+func (r *postgresRepository) GetPaymentsForOrder(ctx context.Context, orderID string) ([]Payment, error) {
+	return r.queryPayments(ctx,
+		"SELECT "+paymentColumns+" FROM payments WHERE order_id = $1 ORDER BY created_at, id",
+		orderID,
+	)
+}
+
+// Runs a query selecting paymentColumns and attaches the refunds of every payment found.
+func (r *postgresRepository) queryPayments(ctx context.Context, query string, args ...interface{}) ([]Payment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	payments := []Payment{}
+	ids := []string{}
+	for rows.Next() {
+		p := Payment{Refunds: []Refund{}}
+		var amount, refunded int64
+		var currency string
+		if err := rows.Scan(&p.ID, &p.OrderID, &p.AccountID, &amount, &refunded, &currency, &p.Status, &p.Provider,
+			&p.AuthorizationID, &p.FailureReason, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		p.Amount = money.New(amount, currency)
+		p.Refunded = money.New(refunded, currency)
+		payments = append(payments, p)
+		ids = append(ids, p.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return payments, nil
+	}
+	refundRows, err := r.db.QueryContext(ctx,
+		`SELECT payment_id, id, amount, reason, created_at
+		FROM payment_refunds
+		WHERE payment_id = ANY($1)
+		ORDER BY created_at, id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer refundRows.Close()
+	position := map[string]int{}
+	for i, p := range payments {
+		position[p.ID] = i
+	}
+	for refundRows.Next() {
+		var paymentID string
+		var amount int64
+		refund := Refund{}
+		if err := refundRows.Scan(&paymentID, &refund.ID, &amount, &refund.Reason, &refund.CreatedAt); err != nil {
+			return nil, err
+		}
+		p := &payments[position[paymentID]]
+		refund.Amount = money.New(amount, p.Amount.Currency)
+		p.Refunds = append(p.Refunds, refund)
+	}
+	return payments, refundRows.Err()
+}
@@ -0,0 +1,291 @@
+package payment
+
+import (
+	"Microservices-based-E-commerce-System/auth"
+	"Microservices-based-E-commerce-System/idempotency"
+	"Microservices-based-E-commerce-System/money"
+	"Microservices-based-E-commerce-System/order"
+	"Microservices-based-E-commerce-System/payment/pb"
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type grpcServer struct {
+	pb.UnimplementedPaymentServiceServer
+	service     Service
+	orderClient *order.Client
+	tokens      *auth.TokenManager // signs the payment service's own token for moving orders between statuses
+	idempotency idempotency.Store  // remembers PayOrder, AuthorizePayment and RefundPayment results by idempotency key
+}
+
+// Customers pay and read the payments of their own orders (checked by the methods); capturing and voiding
+// is staff work, also done by other services. Bare authorizations and refunds are only made by other services:
+// refunds go through the order service's RefundOrder, which records them and their ledger entries.
+var policy = auth.Policy{
+	pb.PaymentService_PayOrder_FullMethodName:            auth.Authenticated,
+	pb.PaymentService_AuthorizePayment_FullMethodName:    auth.ServiceOnly,
+	pb.PaymentService_CapturePayment_FullMethodName:      auth.AdminOnly,
+	pb.PaymentService_VoidPayment_FullMethodName:         auth.AdminOnly,
+	pb.PaymentService_RefundPayment_FullMethodName:       auth.ServiceOnly,
+	pb.PaymentService_GetPayment_FullMethodName:          auth.Authenticated,
+	pb.PaymentService_GetPaymentsForOrder_FullMethodName: auth.Authenticated,
+}
+
+func ListenGRPC(s Service, tokens *auth.TokenManager, keys idempotency.Store, orderURL string, port int) error {
+	orderClient, err := order.NewClient(orderURL)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		orderClient.Close()
+		return err
+	}
+	serv := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(tokens, policy)))
+	pb.RegisterPaymentServiceServer(serv, &grpcServer{
+		UnimplementedPaymentServiceServer: pb.UnimplementedPaymentServiceServer{},
+		service:                           s,
+		orderClient:                       orderClient,
+		tokens:                            tokens,
+		idempotency:                       keys,
+	})
+	reflection.Register(serv)
+	return serv.Serve(lis)
+}
+
+// Pays the whole total of a pending order and marks it paid. The order is read with the caller's token,
+// so only its owner (or an admin) can pay it. If the order can't be marked paid, the money is given back;
+// if that fails too, the failure is kept under the idempotency key so a retry can't charge the order again.
+func (s *grpcServer) PayOrder(ctx context.Context, r *pb.PayOrderRequest) (*pb.PayOrderResponse, error) {
+	res := &pb.PayOrderResponse{}
+	err := idempotency.Do(ctx, s.idempotency, pb.PaymentService_PayOrder_FullMethodName, r.IdempotencyKey, r, res, func() error {
+		p, err := s.payOrder(ctx, r.OrderId)
+		if err != nil {
+			return err
+		}
+		res.Payment = paymentToProto(*p)
+		return nil
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return res, nil
+}
+
+func (s *grpcServer) payOrder(ctx context.Context, orderID string) (*Payment, error) {
+	o, err := s.orderClient.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, upstreamError(err, ErrOrderNotFound)
+	}
+	if o.Status != order.StatusPending {
+		return nil, fmt.Errorf("%w: order is %s", ErrOrderNotPayable, o.Status)
+	}
+	p, err := s.service.Authorize(ctx, o.ID, o.AccountID, o.TotalPrice)
+	if err != nil {
+		return nil, err
+	}
+	captured, err := s.service.Capture(ctx, p.ID)
+	if err != nil {
+		if _, voidErr := s.service.Void(context.WithoutCancel(ctx), p.ID); voidErr != nil {
+			log.Printf("voiding payment %s after a failed capture: %v", p.ID, voidErr)
+		}
+		return nil, err
+	}
+	if err := s.setOrderStatus(ctx, o.ID, order.StatusPaid, "payment "+p.ID+" captured"); err != nil {
+		if _, refundErr := s.service.Refund(context.WithoutCancel(ctx), p.ID, money.Money{}, "order could not be marked paid", ""); refundErr != nil {
+			log.Printf("refunding payment %s: %v", p.ID, refundErr)
+			return nil, idempotency.Committed(toStatus(fmt.Errorf("%w: payment %s: %v", ErrPaymentStranded, p.ID, err)))
+		}
+		return nil, fmt.Errorf("%w: %v", ErrOrderNotUpdated, err)
+	}
+	return captured, nil
+}
+
+// Authorizes an amount without capturing it, on behalf of the service placing the order.
+func (s *grpcServer) AuthorizePayment(ctx context.Context, r *pb.AuthorizePaymentRequest) (*pb.AuthorizePaymentResponse, error) {
+	res := &pb.AuthorizePaymentResponse{}
+	err := idempotency.Do(ctx, s.idempotency, pb.PaymentService_AuthorizePayment_FullMethodName, r.IdempotencyKey, r, res, func() error {
+		p, err := s.service.Authorize(ctx, r.OrderId, r.AccountId, moneyFromProto(r.Amount))
+		if err != nil {
+			return err
+		}
+		res.Payment = paymentToProto(*p)
+		return nil
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return res, nil
+}
+
+// Captures an authorized payment and marks its order paid if it is still pending.
+// Retrying after the order couldn't be updated finishes the job, since capturing twice is harmless.
+func (s *grpcServer) CapturePayment(ctx context.Context, r *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error) {
+	p, err := s.service.Capture(ctx, r.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	o, err := s.getOrder(ctx, p.OrderID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if o.Status == order.StatusPending {
+		if err := s.setOrderStatus(ctx, o.ID, order.StatusPaid, "payment "+p.ID+" captured"); err != nil {
+			return nil, toStatus(fmt.Errorf("%w: %v", ErrOrderNotUpdated, err))
+		}
+	}
+	return &pb.CapturePaymentResponse{Payment: paymentToProto(*p)}, nil
+}
+
+func (s *grpcServer) VoidPayment(ctx context.Context, r *pb.VoidPaymentRequest) (*pb.VoidPaymentResponse, error) {
+	p, err := s.service.Void(ctx, r.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.VoidPaymentResponse{Payment: paymentToProto(*p)}, nil
+}
+
+// Refunds a payment, in part or in full, on behalf of the order service. A retry with the same idempotency key
+// returns the first result; the key is also the provider's reference for the refund. The order service records
+// the refund, and marks the order refunded once its refunds cover it.
+func (s *grpcServer) RefundPayment(ctx context.Context, r *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	amount := money.Money{}
+	if r.Amount != nil {
+		amount = moneyFromProto(r.Amount)
+	}
+	res := &pb.RefundPaymentResponse{}
+	err := idempotency.Do(ctx, s.idempotency, pb.PaymentService_RefundPayment_FullMethodName, r.IdempotencyKey, r, res, func() error {
+		p, err := s.service.Refund(ctx, r.Id, amount, r.Reason, r.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		res.Payment = paymentToProto(*p)
+		return nil
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return res, nil
+}
+
+// Only the account that paid (or an admin) may read a payment.
+func (s *grpcServer) GetPayment(ctx context.Context, r *pb.GetPaymentRequest) (*pb.GetPaymentResponse, error) {
+	p, err := s.service.GetPayment(ctx, r.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := auth.AuthorizeAccount(ctx, p.AccountID); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetPaymentResponse{Payment: paymentToProto(*p)}, nil
+}
+
+// Ownership is checked against the order itself, so an order without payments can't be probed by others.
+func (s *grpcServer) GetPaymentsForOrder(ctx context.Context, r *pb.GetPaymentsForOrderRequest) (*pb.GetPaymentsForOrderResponse, error) {
+	if _, err := s.orderClient.GetOrder(ctx, r.OrderId); err != nil {
+		return nil, toStatus(upstreamError(err, ErrOrderNotFound))
+	}
+	payments, err := s.service.GetPaymentsForOrder(ctx, r.OrderId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.GetPaymentsForOrderResponse{Payments: []*pb.Payment{}}
+	for _, p := range payments {
+		res.Payments = append(res.Payments, paymentToProto(p))
+	}
+	return res, nil
+}
+
+// Reads an order as the payment service, whoever the caller is.
+func (s *grpcServer) getOrder(ctx context.Context, id string) (*order.Order, error) {
+	ctx, err := s.tokens.ServiceContext(ctx, "payment")
+	if err != nil {
+		return nil, err
+	}
+	o, err := s.orderClient.GetOrder(ctx, id)
+	if err != nil {
+		return nil, upstreamError(err, ErrOrderNotFound)
+	}
+	return o, nil
+}
+
+// Moving orders between statuses is admin-only in the order service, so it is done as the payment service.
+func (s *grpcServer) setOrderStatus(ctx context.Context, id string, status string, note string) error {
+	ctx, err := s.tokens.ServiceContext(ctx, "payment")
+	if err != nil {
+		return err
+	}
+	if _, err := s.orderClient.UpdateOrderStatus(ctx, id, status, note); err != nil {
+		return upstreamError(err, ErrOrderNotFound)
+	}
+	return nil
+}
+
+func paymentToProto(p Payment) *pb.Payment {
+	res := &pb.Payment{
+		Id:            p.ID,
+		OrderId:       p.OrderID,
+		AccountId:     p.AccountID,
+		Amount:        moneyToProto(p.Amount),
+		Refunded:      moneyToProto(p.Refunded),
+		Status:        p.Status,
+		Provider:      p.Provider,
+		FailureReason: p.FailureReason,
+		Refunds:       []*pb.Refund{},
+	}
+	for _, r := range p.Refunds {
+		refund := &pb.Refund{
+			Id:     r.ID,
+			Amount: moneyToProto(r.Amount),
+			Reason: r.Reason,
+		}
+		refund.CreatedAt, _ = r.CreatedAt.MarshalBinary()
+		res.Refunds = append(res.Refunds, refund)
+	}
+	res.CreatedAt, _ = p.CreatedAt.MarshalBinary()
+	res.UpdatedAt, _ = p.UpdatedAt.MarshalBinary()
+	return res
+}
+
+func paymentFromProto(p *pb.Payment) Payment {
+	res := Payment{
+		ID:            p.Id,
+		OrderID:       p.OrderId,
+		AccountID:     p.AccountId,
+		Amount:        moneyFromProto(p.Amount),
+		Refunded:      moneyFromProto(p.Refunded),
+		Status:        p.Status,
+		Provider:      p.Provider,
+		FailureReason: p.FailureReason,
+		Refunds:       []Refund{},
+	}
+	for _, r := range p.Refunds {
+		refund := Refund{
+			ID:     r.Id,
+			Amount: moneyFromProto(r.Amount),
+			Reason: r.Reason,
+		}
+		refund.CreatedAt.UnmarshalBinary(r.CreatedAt)
+		res.Refunds = append(res.Refunds, refund)
+	}
+	res.CreatedAt.UnmarshalBinary(p.CreatedAt)
+	res.UpdatedAt.UnmarshalBinary(p.UpdatedAt)
+	return res
+}
+
+func moneyToProto(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+// A missing amount reads as zero in the default currency.
+func moneyFromProto(m *pb.Money) money.Money {
+	if m == nil {
+		return money.Zero(money.DefaultCurrency)
+	}
+	return money.New(m.Amount, m.Currency)
+}
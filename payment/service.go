@@ -0,0 +1,239 @@
+// Handles the business logic.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"Microservices-based-E-commerce-System/money"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Payment lifecycle:
+//
+//	pending -> authorized -> captured -> partially_refunded -> refunded
+//
+// A payment is pending while the provider is asked to authorize it, and ends as declined or failed
+// if that doesn't work out. An authorization can be voided instead of captured.
+const (
+	StatusPending           = "pending"
+	StatusAuthorized        = "authorized"
+	StatusCaptured          = "captured"
+	StatusPartiallyRefunded = "partially_refunded"
+	StatusRefunded          = "refunded"
+	StatusDeclined          = "declined"
+	StatusFailed            = "failed"
+	StatusVoided            = "voided"
+)
+
+type Service interface {
+	Authorize(ctx context.Context, orderID, accountID string, amount money.Money) (*Payment, error)
+	Capture(ctx context.Context, id string) (*Payment, error)
+	Void(ctx context.Context, id string) (*Payment, error)
+	Refund(ctx context.Context, id string, amount money.Money, reason, reference string) (*Payment, error)
+	GetPayment(ctx context.Context, id string) (*Payment, error)
+	GetPaymentsForOrder(ctx context.Context, orderID string) ([]Payment, error)
+}
+
+type Payment struct {
+	ID              string
+	OrderID         string
+	AccountID       string
+	Amount          money.Money
+	Refunded        money.Money // sum of Refunds, in the payment's currency
+	Status          string
+	Provider        string // name of the PaymentProvider that handled it
+	AuthorizationID string // the provider's id, empty until authorized
+	FailureReason   string // why it was declined or failed
+	Refunds         []Refund
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type Refund struct {
+	ID        string
+	Amount    money.Money
+	Reason    string
+	CreatedAt time.Time
+}
+
+// Reports whether the payment's money was (at least partly) taken from the customer.
+func (p Payment) Captured() bool {
+	return p.Status == StatusCaptured || p.Status == StatusPartiallyRefunded || p.Status == StatusRefunded
+}
+
+type paymentService struct {
+	repository Repository
+	provider   PaymentProvider
+}
+
+func NewService(r Repository, provider PaymentProvider) Service {
+	return &paymentService{r, provider}
+}
+
+// Asks the provider to authorize amount for an order. An order has at most one payment that isn't declined,
+// failed or voided; paying it again returns ErrAlreadyPaid. The payment is recorded before the provider is
+// called, so an authorization is never left without a record. A declined payment is returned together with
+// an error wrapping ErrDeclined.
+func (s *paymentService) Authorize(ctx context.Context, orderID, accountID string, amount money.Money) (*Payment, error) {
+	if !amount.IsPositive() || !money.ValidCurrency(amount.Currency) {
+		return nil, ErrInvalidAmount
+	}
+	now := time.Now().UTC()
+	p := Payment{
+		ID:        ksuid.New().String(),
+		OrderID:   orderID,
+		AccountID: accountID,
+		Amount:    amount,
+		Refunded:  money.Zero(amount.Currency),
+		Status:    StatusPending,
+		Provider:  s.provider.Name(),
+		Refunds:   []Refund{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repository.CreatePayment(ctx, p); err != nil {
+		return nil, err
+	}
+	from := p
+	authorizationID, authErr := s.provider.Authorize(ctx, amount, p.ID)
+	switch {
+	case authErr == nil:
+		p.Status = StatusAuthorized
+		p.AuthorizationID = authorizationID
+	case errors.Is(authErr, ErrDeclined):
+		p.Status = StatusDeclined
+		p.FailureReason = authErr.Error()
+	default:
+		p.Status = StatusFailed
+		p.FailureReason = authErr.Error()
+	}
+	p.UpdatedAt = time.Now().UTC()
+	if err := s.repository.UpdatePayment(ctx, p, from); err != nil {
+		return nil, err
+	}
+	if p.Status == StatusDeclined {
+		return &p, authErr
+	}
+	if authErr != nil {
+		return nil, authErr
+	}
+	return &p, nil
+}
+
+// Takes the authorized amount. Capturing a captured payment again returns it unchanged.
+func (s *paymentService) Capture(ctx context.Context, id string) (*Payment, error) {
+	p, err := s.repository.GetPayment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Captured() {
+		return p, nil
+	}
+	if p.Status != StatusAuthorized {
+		return nil, fmt.Errorf("%w: payment is %s", ErrInvalidState, p.Status)
+	}
+	if err := s.provider.Capture(ctx, p.AuthorizationID, p.Amount); err != nil {
+		return nil, err
+	}
+	return s.update(ctx, *p, func(p *Payment) { p.Status = StatusCaptured })
+}
+
+// Releases an authorization that wasn't captured. Voiding twice returns the payment unchanged.
+func (s *paymentService) Void(ctx context.Context, id string) (*Payment, error) {
+	p, err := s.repository.GetPayment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status == StatusVoided {
+		return p, nil
+	}
+	if p.Status != StatusAuthorized {
+		return nil, fmt.Errorf("%w: payment is %s", ErrInvalidState, p.Status)
+	}
+	if err := s.provider.Void(ctx, p.AuthorizationID); err != nil {
+		return nil, err
+	}
+	return s.update(ctx, *p, func(p *Payment) { p.Status = StatusVoided })
+}
+
+// Gives back part of a captured payment; a zero amount refunds whatever hasn't been refunded yet.
+// A payment that is refunded in full already refuses with ErrFullyRefunded.
+// The refund is recorded before the provider is asked for it, so concurrent refunds can't give back more than
+// was captured; if the provider fails, the record is removed again. reference is passed to the provider, which makes
+// one refund per reference; empty uses the refund's own id.
+func (s *paymentService) Refund(ctx context.Context, id string, amount money.Money, reason, reference string) (*Payment, error) {
+	p, err := s.repository.GetPayment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status == StatusRefunded {
+		return nil, fmt.Errorf("%w: payment %s", ErrFullyRefunded, p.ID)
+	}
+	if p.Status != StatusCaptured && p.Status != StatusPartiallyRefunded {
+		return nil, fmt.Errorf("%w: payment is %s", ErrInvalidState, p.Status)
+	}
+	remaining, err := p.Amount.Sub(p.Refunded)
+	if err != nil {
+		return nil, err
+	}
+	if amount.IsZero() {
+		amount = remaining
+	}
+	if amount.Currency != p.Amount.Currency || !amount.IsPositive() || amount.Amount > remaining.Amount {
+		return nil, fmt.Errorf("%w: refund must be between 0 and %s", ErrInvalidAmount, remaining)
+	}
+	refund := Refund{
+		ID:        ksuid.New().String(),
+		Amount:    amount,
+		Reason:    strings.TrimSpace(reason),
+		CreatedAt: time.Now().UTC(),
+	}
+	refunded := *p
+	if refunded.Refunded, err = p.Refunded.Add(amount); err != nil {
+		return nil, err
+	}
+	refunded.Status = StatusPartiallyRefunded
+	if refunded.Refunded == p.Amount {
+		refunded.Status = StatusRefunded
+	}
+	refunded.UpdatedAt = refund.CreatedAt
+	refunded.Refunds = append(append([]Refund{}, p.Refunds...), refund)
+	if err := s.repository.PutRefund(ctx, refunded, *p, refund); err != nil {
+		return nil, err
+	}
+	if reference == "" {
+		reference = refund.ID
+	}
+	if err := s.provider.Refund(ctx, p.AuthorizationID, amount, reference); err != nil {
+		if undoErr := s.repository.DeleteRefund(context.WithoutCancel(ctx), *p, refunded, refund.ID); undoErr != nil {
+			log.Printf("removing refund %s of payment %s after the provider failed: %v", refund.ID, p.ID, undoErr)
+		}
+		return nil, err
+	}
+	return &refunded, nil
+}
+
+func (s *paymentService) GetPayment(ctx context.Context, id string) (*Payment, error) {
+	return s.repository.GetPayment(ctx, id)
+}
+
+func (s *paymentService) GetPaymentsForOrder(ctx context.Context, orderID string) ([]Payment, error) {
+	return s.repository.GetPaymentsForOrder(ctx, orderID)
+}
+
+// Applies change to p and writes it, provided nobody changed the payment in the meantime.
+func (s *paymentService) update(ctx context.Context, p Payment, change func(p *Payment)) (*Payment, error) {
+	from := p
+	change(&p)
+	p.UpdatedAt = time.Now().UTC()
+	if err := s.repository.UpdatePayment(ctx, p, from); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,42 @@
+-- One row per attempt to pay an order. Amounts are minor units (cents) of currency.
+CREATE TABLE IF NOT EXISTS payments (
+    id CHAR(27) PRIMARY KEY,
+    order_id CHAR(27) NOT NULL,
+    account_id CHAR(27) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    refunded BIGINT NOT NULL DEFAULT 0 CHECK (refunded >= 0 AND refunded <= amount),
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(24) NOT NULL,
+    provider VARCHAR(32) NOT NULL,
+    authorization_id VARCHAR(128) NOT NULL DEFAULT '', -- the provider's id of the authorization
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS payments_order_id ON payments(order_id, created_at);
+
+-- An order can't be paid twice: at most one payment per order may be in progress or completed.
+CREATE UNIQUE INDEX IF NOT EXISTS payments_one_per_order ON payments(order_id)
+    WHERE status NOT IN ('declined', 'failed', 'voided');
+
+-- Refunds of a payment, each for part of its amount.
+CREATE TABLE IF NOT EXISTS payment_refunds (
+    id CHAR(27) PRIMARY KEY,
+    payment_id CHAR(27) NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount > 0), -- minor units of the payment's currency
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS payment_refunds_payment_id ON payment_refunds(payment_id, created_at);
+
+-- Idempotency keys of PayOrder and AuthorizePayment requests, see the idempotency package.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(400) PRIMARY KEY, -- "<method>|<caller>|<client key>"
+    request_hash CHAR(64) NOT NULL,
+    token CHAR(32) NOT NULL, -- identifies the attempt holding the key
+    response BYTEA, -- NULL while the first attempt is running
+    failure BYTEA, -- gRPC status of an attempt that failed after making changes that stand
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);